@@ -0,0 +1,661 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pedrohavay/followthemoney/ftm"
+)
+
+// Job states.
+const (
+	jobQueued  = "queued"
+	jobRunning = "running"
+	jobDone    = "done"
+	jobFailed  = "failed"
+)
+
+var (
+	errJobNotFound = errors.New("job not found")
+	errJobKind     = errors.New("unknown job kind")
+	errQueueFull   = errors.New("queue is full")
+)
+
+// job is the status record of a submitted batch operation.
+type job struct {
+	ID       string     `json:"id"`
+	Kind     string     `json:"kind"`
+	Params   jobParams  `json:"params,omitempty"`
+	Status   string     `json:"status"`
+	Error    string     `json:"error,omitempty"`
+	Records  int        `json:"records"`
+	Created  time.Time  `json:"created"`
+	Finished *time.Time `json:"finished,omitempty"`
+}
+
+// jobParams are the options of a job, taken from the query string on submission.
+type jobParams map[string]string
+
+// get returns the named parameter, or def if it is not set.
+func (p jobParams) get(name, def string) string {
+	if v := p[name]; v != "" {
+		return v
+	}
+	return def
+}
+
+// jobRunner executes a job kind, reading JSON lines input and writing JSON lines
+// results. It returns the number of records written.
+type jobRunner func(m *ftm.Model, params jobParams, in io.Reader, out io.Writer) (int, error)
+
+// jobEvents names the server-sent event carrying a result record of each job kind.
+var jobEvents = map[string]string{
+	"aggregate": "entity",
+	"validate":  "invalid",
+	"export":    "statement",
+	"xref":      "match",
+}
+
+var jobRunners = map[string]jobRunner{
+	"aggregate": runAggregateJob,
+	"validate":  runValidateJob,
+	"export":    runExportJob,
+	"xref":      runXrefJob,
+}
+
+// jobStore persists job records, inputs and results, and provides the backlog
+// of jobs waiting for a worker. Stores backed by a shared service (see
+// redisJobStore) allow several server processes to share a queue.
+type jobStore interface {
+	Create(j job, input io.Reader) error
+	Update(j job) error
+	Get(id string) (job, error)
+	// List returns all jobs, oldest first.
+	List() ([]job, error)
+	// Delete removes a job with its input and results.
+	Delete(id string) error
+	Input(id string) (io.ReadCloser, error)
+	ResultWriter(id string) (io.WriteCloser, error)
+	// Result reads the results written so far; reading again after io.EOF
+	// returns results written since.
+	Result(id string) (io.ReadCloser, error)
+	// Backlog returns the queue of job IDs waiting for a worker, holding at
+	// most size jobs.
+	Backlog(size int) jobBacklog
+}
+
+// jobBacklog is a queue of job IDs waiting for a worker.
+type jobBacklog interface {
+	// Push queues a job, failing with errQueueFull if the backlog is full.
+	Push(id string) error
+	// Pop waits for the next job until ctx is done.
+	Pop(ctx context.Context) (string, error)
+	// Len returns the number of waiting jobs.
+	Len() (int, error)
+}
+
+// memBacklog is a backlog held in memory, lost when the process exits; see
+// jobQueue.Resume.
+type memBacklog chan string
+
+func (b memBacklog) Push(id string) error {
+	select {
+	case b <- id:
+		return nil
+	default:
+		return errQueueFull
+	}
+}
+
+func (b memBacklog) Pop(ctx context.Context) (string, error) {
+	select {
+	case id := <-b:
+		return id, nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func (b memBacklog) Len() (int, error) { return len(b), nil }
+
+// diskJobStore keeps each job in its own directory: job.json, input.jsonl, result.jsonl.
+type diskJobStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func newDiskJobStore(dir string) (*diskJobStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &diskJobStore{dir: dir}, nil
+}
+
+func (s *diskJobStore) path(id, name string) string {
+	return filepath.Join(s.dir, filepath.Base(id), name)
+}
+
+func (s *diskJobStore) Create(j job, input io.Reader) error {
+	if err := os.MkdirAll(filepath.Join(s.dir, j.ID), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(s.path(j.ID, "input.jsonl"))
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, input)
+	if err := errors.Join(err, f.Close()); err != nil {
+		return err
+	}
+	return s.Update(j)
+}
+
+func (s *diskJobStore) Update(j job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := json.Marshal(j)
+	if err != nil {
+		return err
+	}
+	tmp := s.path(j.ID, "job.json.tmp")
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path(j.ID, "job.json"))
+}
+
+func (s *diskJobStore) Get(id string) (job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var j job
+	b, err := os.ReadFile(s.path(id, "job.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return j, errJobNotFound
+	}
+	if err != nil {
+		return j, err
+	}
+	return j, json.Unmarshal(b, &j)
+}
+
+func (s *diskJobStore) List() ([]job, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	var out []job
+	for _, ent := range entries {
+		if !ent.IsDir() {
+			continue
+		}
+		j, err := s.Get(ent.Name())
+		if errors.Is(err, errJobNotFound) {
+			continue // input still being written
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, j)
+	}
+	slices.SortStableFunc(out, func(a, b job) int { return a.Created.Compare(b.Created) })
+	return out, nil
+}
+
+func (s *diskJobStore) Delete(id string) error {
+	return os.RemoveAll(filepath.Join(s.dir, filepath.Base(id)))
+}
+
+func (s *diskJobStore) Backlog(size int) jobBacklog {
+	return make(memBacklog, max(size, 1))
+}
+
+func (s *diskJobStore) Input(id string) (io.ReadCloser, error) {
+	return os.Open(s.path(id, "input.jsonl"))
+}
+
+func (s *diskJobStore) ResultWriter(id string) (io.WriteCloser, error) {
+	return os.Create(s.path(id, "result.jsonl"))
+}
+
+func (s *diskJobStore) Result(id string) (io.ReadCloser, error) {
+	return os.Open(s.path(id, "result.jsonl"))
+}
+
+// jobQueue runs submitted jobs on a fixed number of workers. At most size jobs
+// wait for a worker; further submissions are rejected with errQueueFull.
+type jobQueue struct {
+	model   *ftm.Model
+	store   jobStore
+	size    int
+	backlog jobBacklog
+}
+
+func newJobQueue(m *ftm.Model, store jobStore, size int) *jobQueue {
+	size = max(size, 1)
+	return &jobQueue{model: m, store: store, size: size, backlog: store.Backlog(size)}
+}
+
+// Start runs the workers.
+func (q *jobQueue) Start(workers int) {
+	for range max(workers, 1) {
+		go q.work()
+	}
+}
+
+// Resume queues the jobs left queued or running by a previous process, oldest
+// first, and returns their number. Jobs beyond the queue size are handed to the
+// workers as they become free. Only an in-memory backlog needs resuming; a
+// shared backlog keeps its jobs across restarts.
+func (q *jobQueue) Resume() (int, error) {
+	pending, ok := q.backlog.(memBacklog)
+	if !ok {
+		return 0, nil
+	}
+	jobs, err := q.store.List()
+	if err != nil {
+		return 0, err
+	}
+	var ids []string
+	for _, j := range jobs {
+		if j.Status != jobQueued && j.Status != jobRunning {
+			continue
+		}
+		if j.Status == jobRunning {
+			j.Status = jobQueued
+			if err := q.store.Update(j); err != nil {
+				return 0, err
+			}
+		}
+		ids = append(ids, j.ID)
+	}
+	go func() {
+		for _, id := range ids {
+			pending <- id
+		}
+	}()
+	return len(ids), nil
+}
+
+// Submit stores the input and queues a job of the given kind. Submissions
+// while the queue is full fail with errQueueFull and store nothing.
+func (q *jobQueue) Submit(kind string, params jobParams, input io.Reader) (job, error) {
+	if _, ok := jobRunners[kind]; !ok {
+		return job{}, fmt.Errorf("%w: %s", errJobKind, kind)
+	}
+	if n, err := q.backlog.Len(); err != nil {
+		return job{}, err
+	} else if n >= q.size {
+		return job{}, errQueueFull
+	}
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	j := job{ID: hex.EncodeToString(id), Kind: kind, Params: params, Status: jobQueued, Created: time.Now().UTC()}
+	if err := q.store.Create(j, input); err != nil {
+		return job{}, err
+	}
+	if err := q.backlog.Push(j.ID); err != nil {
+		// The queue filled up while the input was stored.
+		return job{}, errors.Join(err, q.store.Delete(j.ID))
+	}
+	return j, nil
+}
+
+// jobRetry is how long a worker waits after failing to take a job.
+var jobRetry = time.Second
+
+func (q *jobQueue) work() {
+	ctx := context.Background()
+	for {
+		id, err := q.backlog.Pop(ctx)
+		if err != nil {
+			time.Sleep(jobRetry)
+			continue
+		}
+		j, err := q.store.Get(id)
+		if err != nil {
+			continue
+		}
+		j.Status = jobRunning
+		_ = q.store.Update(j)
+		j.Records, err = q.run(j)
+		now := time.Now().UTC()
+		j.Status, j.Finished = jobDone, &now
+		if err != nil {
+			j.Status, j.Error = jobFailed, err.Error()
+		}
+		_ = q.store.Update(j)
+	}
+}
+
+func (q *jobQueue) run(j job) (int, error) {
+	in, err := q.store.Input(j.ID)
+	if err != nil {
+		return 0, err
+	}
+	defer in.Close()
+	out, err := q.store.ResultWriter(j.ID)
+	if err != nil {
+		return 0, err
+	}
+	// Records are written as they are produced, for /events to follow.
+	n, err := jobRunners[j.Kind](q.model, j.Params, in, out)
+	return n, errors.Join(err, out.Close())
+}
+
+// handle registers the job endpoints:
+//
+//	POST /jobs/{kind}        submit JSONL input, returns the queued job; query
+//	                         parameters are passed to the job (e.g. dataset=...)
+//	GET  /jobs/{id}          job status
+//	GET  /jobs/{id}/result   JSONL results of a finished job
+//	GET  /jobs/{id}/events   server-sent events: each result record as it is
+//	                         produced (e.g. "match" for xref), then "done" or
+//	                         "failed" with the job
+func (q *jobQueue) handle(mux *http.ServeMux) {
+	mux.HandleFunc("POST /jobs/{kind}", func(w http.ResponseWriter, r *http.Request) {
+		params := jobParams{}
+		for name, values := range r.URL.Query() {
+			params[name] = values[0]
+		}
+		j, err := q.Submit(r.PathValue("kind"), params, r.Body)
+		switch {
+		case errors.Is(err, errJobKind):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		case errors.Is(err, errQueueFull):
+			w.Header().Set("Retry-After", "30")
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusAccepted, j)
+	})
+	mux.HandleFunc("GET /jobs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		j, err := q.store.Get(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, j)
+	})
+	mux.HandleFunc("GET /jobs/{id}/result", func(w http.ResponseWriter, r *http.Request) {
+		j, err := q.store.Get(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if j.Status != jobDone {
+			http.Error(w, "job is "+j.Status, http.StatusConflict)
+			return
+		}
+		res, err := q.store.Result(j.ID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer res.Close()
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = io.Copy(w, res)
+	})
+	mux.HandleFunc("GET /jobs/{id}/events", func(w http.ResponseWriter, r *http.Request) {
+		j, err := q.store.Get(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		sse, ok := newSSEWriter(w)
+		if !ok {
+			http.Error(w, "streaming not supported", http.StatusInternalServerError)
+			return
+		}
+		q.followJob(r.Context(), sse, j.ID)
+	})
+}
+
+// jobEventPoll is how often followJob checks a job for new results.
+var jobEventPoll = 100 * time.Millisecond
+
+// followJob sends the result records of a job as events while they are
+// written, until the job finishes or ctx is cancelled.
+func (q *jobQueue) followJob(ctx context.Context, sse *sseWriter, id string) {
+	var res io.ReadCloser
+	defer func() {
+		if res != nil {
+			res.Close()
+		}
+	}()
+	var partial []byte
+	buf := make([]byte, 32*1024)
+	for {
+		// The status is read first: once finished, the results read after it are complete.
+		j, err := q.store.Get(id)
+		if err != nil {
+			_ = sse.Send("error", map[string]string{"error": err.Error()})
+			return
+		}
+		if res == nil && j.Status != jobQueued {
+			res, err = q.store.Result(id)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				_ = sse.Send("error", map[string]string{"error": err.Error()})
+				return
+			}
+		}
+		for res != nil {
+			n, err := res.Read(buf)
+			partial = append(partial, buf[:n]...)
+			for {
+				line, rest, ok := bytes.Cut(partial, []byte("\n"))
+				if !ok {
+					break
+				}
+				partial = rest
+				if err := sse.Send(jobEvents[j.Kind], json.RawMessage(line)); err != nil {
+					return // client went away
+				}
+			}
+			if err != nil || n == 0 {
+				break
+			}
+		}
+		switch j.Status {
+		case jobDone, jobFailed:
+			_ = sse.Send(j.Status, j)
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(jobEventPoll):
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// runAggregateJob aggregates sorted statements into entities.
+func runAggregateJob(m *ftm.Model, _ jobParams, in io.Reader, out io.Writer) (int, error) {
+	enc := json.NewEncoder(out)
+	n := 0
+	for proxy, err := range ftm.IterAggregate(m, ftm.IterStatementsJSONL(in)) {
+		if err != nil {
+			return n, err
+		}
+		if err := enc.Encode(proxy); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// runValidateJob reports the validation errors of each invalid entity.
+func runValidateJob(m *ftm.Model, _ jobParams, in io.Reader, out io.Writer) (int, error) {
+	enc := json.NewEncoder(out)
+	n := 0
+	err := readEntities(m, in, func(proxy *ftm.EntityProxy) error {
+		errs := proxy.Validate()
+		if len(errs) == 0 {
+			return nil
+		}
+		n++
+		return enc.Encode(ftm.ValidationError{Schema: proxy.Schema.Name, Errors: errs})
+	})
+	return n, err
+}
+
+// runExportJob converts entities into statements of the dataset and origin
+// given as parameters; the dataset defaults to "default", as in pipelines.
+func runExportJob(m *ftm.Model, params jobParams, in io.Reader, out io.Writer) (int, error) {
+	dataset, origin := params.get("dataset", "default"), params.get("origin", "")
+	n := 0
+	err := readEntities(m, in, func(proxy *ftm.EntityProxy) error {
+		stmts := ftm.StatementsFromEntity(proxy, dataset, "", "", false, origin)
+		n += len(stmts)
+		return ftm.WriteStatementsJSONL(out, stmts)
+	})
+	return n, err
+}
+
+// xrefMatch is a candidate duplicate found by an xref job.
+type xrefMatch struct {
+	Left  string  `json:"left"`
+	Right string  `json:"right"`
+	Score float64 `json:"score"`
+}
+
+// xrefMaxBlock skips blocking keys shared by more entities, such as common name
+// parts, which would only yield weak candidates at quadratic cost.
+const xrefMaxBlock = 100
+
+// runXrefJob compares the entities with each other and reports pairs of
+// matchable entities scoring at least the threshold parameter (default 0.7).
+// Candidates share a value of a matchable type (a word, for names); the score
+// is the mean, over the matchable types both entities have values of, of the
+// best comparison between their values. Entities are held in memory.
+func runXrefJob(m *ftm.Model, params jobParams, in io.Reader, out io.Writer) (int, error) {
+	threshold := 0.7
+	if v := params["threshold"]; v != "" {
+		if _, err := fmt.Sscan(v, &threshold); err != nil {
+			return 0, fmt.Errorf("threshold: %w", err)
+		}
+	}
+	var entities []*ftm.EntityProxy
+	blocks := map[string][]int{}
+	err := readEntities(m, in, func(proxy *ftm.EntityProxy) error {
+		if !proxy.Schema.Matchable {
+			return nil
+		}
+		keys := map[string]struct{}{}
+		for typ, values := range xrefValues(proxy) {
+			for _, v := range values {
+				parts := []string{strings.ToLower(v)}
+				if typ == m.Registry().Name {
+					parts = strings.Fields(parts[0])
+				}
+				for _, part := range parts {
+					keys[typ.Name()+":"+part] = struct{}{}
+				}
+			}
+		}
+		for key := range keys {
+			blocks[key] = append(blocks[key], len(entities))
+		}
+		entities = append(entities, proxy)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	type pair struct{ left, right int }
+	var pairs []pair
+	seen := map[pair]bool{}
+	for _, members := range blocks {
+		if len(members) > xrefMaxBlock {
+			continue
+		}
+		for i, l := range members {
+			for _, r := range members[i+1:] {
+				p := pair{l, r}
+				if !seen[p] && entities[l].Schema.CanMatch(entities[r].Schema) {
+					seen[p] = true
+					pairs = append(pairs, p)
+				}
+			}
+		}
+	}
+	slices.SortFunc(pairs, func(a, b pair) int {
+		if a.left != b.left {
+			return a.left - b.left
+		}
+		return a.right - b.right
+	})
+	enc := json.NewEncoder(out)
+	n := 0
+	for _, p := range pairs {
+		left, right := entities[p.left], entities[p.right]
+		score := xrefScore(left, right)
+		if score < threshold {
+			continue
+		}
+		if err := enc.Encode(xrefMatch{Left: left.ID, Right: right.ID, Score: score}); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// xrefValues returns the values of the matchable properties of an entity by type.
+func xrefValues(e *ftm.EntityProxy) map[ftm.PropertyType][]string {
+	out := map[ftm.PropertyType][]string{}
+	for _, p := range e.IterProps() {
+		if p.Type.Matchable() {
+			out[p.Type] = append(out[p.Type], e.Get(p.Name)...)
+		}
+	}
+	return out
+}
+
+// xrefScore averages the best value comparison of each matchable type the two
+// entities share.
+func xrefScore(left, right *ftm.EntityProxy) float64 {
+	lv, rv := xrefValues(left), xrefValues(right)
+	total, types := 0.0, 0
+	for typ, ls := range lv {
+		rs := rv[typ]
+		if len(rs) == 0 {
+			continue
+		}
+		best := 0.0
+		for _, l := range ls {
+			for _, r := range rs {
+				best = max(best, typ.Compare(l, r))
+			}
+		}
+		total += best
+		types++
+	}
+	if types == 0 {
+		return 0
+	}
+	return total / float64(types)
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisJobStore keeps jobs in Redis under a key prefix, so that several server
+// processes share one queue that survives restarts:
+//
+//	{prefix}:job:{id}      job record (JSON)
+//	{prefix}:input:{id}    input JSON lines
+//	{prefix}:result:{id}   result JSON lines, appended as they are written
+//	{prefix}:jobs          sorted set of job IDs by creation time
+//	{prefix}:pending       list of job IDs waiting for a worker
+//
+// Jobs running in a process that stops are not retried.
+type redisJobStore struct {
+	ctx    context.Context
+	rdb    *redis.Client
+	prefix string
+}
+
+func newRedisJobStore(ctx context.Context, rdb *redis.Client, prefix string) *redisJobStore {
+	if prefix == "" {
+		prefix = "ftm"
+	}
+	return &redisJobStore{ctx: ctx, rdb: rdb, prefix: prefix}
+}
+
+func (s *redisJobStore) key(parts ...string) string {
+	key := s.prefix
+	for _, p := range parts {
+		key += ":" + p
+	}
+	return key
+}
+
+func (s *redisJobStore) Create(j job, input io.Reader) error {
+	in, err := io.ReadAll(input)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(j)
+	if err != nil {
+		return err
+	}
+	_, err = s.rdb.TxPipelined(s.ctx, func(p redis.Pipeliner) error {
+		p.Set(s.ctx, s.key("input", j.ID), in, 0)
+		p.Set(s.ctx, s.key("job", j.ID), b, 0)
+		p.ZAdd(s.ctx, s.key("jobs"), redis.Z{Score: float64(j.Created.UnixNano()), Member: j.ID})
+		return nil
+	})
+	return err
+}
+
+func (s *redisJobStore) Update(j job) error {
+	b, err := json.Marshal(j)
+	if err != nil {
+		return err
+	}
+	return s.rdb.Set(s.ctx, s.key("job", j.ID), b, 0).Err()
+}
+
+func (s *redisJobStore) Get(id string) (job, error) {
+	var j job
+	b, err := s.rdb.Get(s.ctx, s.key("job", id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return j, errJobNotFound
+	}
+	if err != nil {
+		return j, err
+	}
+	return j, json.Unmarshal(b, &j)
+}
+
+func (s *redisJobStore) List() ([]job, error) {
+	ids, err := s.rdb.ZRange(s.ctx, s.key("jobs"), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	var out []job
+	for _, id := range ids {
+		j, err := s.Get(id)
+		if errors.Is(err, errJobNotFound) {
+			continue // deleted meanwhile
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, j)
+	}
+	return out, nil
+}
+
+func (s *redisJobStore) Delete(id string) error {
+	_, err := s.rdb.TxPipelined(s.ctx, func(p redis.Pipeliner) error {
+		p.Del(s.ctx, s.key("job", id), s.key("input", id), s.key("result", id))
+		p.ZRem(s.ctx, s.key("jobs"), id)
+		return nil
+	})
+	return err
+}
+
+func (s *redisJobStore) Input(id string) (io.ReadCloser, error) {
+	b, err := s.rdb.Get(s.ctx, s.key("input", id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, errJobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+func (s *redisJobStore) ResultWriter(id string) (io.WriteCloser, error) {
+	key := s.key("result", id)
+	if err := s.rdb.Del(s.ctx, key).Err(); err != nil {
+		return nil, err
+	}
+	return &redisAppender{s: s, key: key}, nil
+}
+
+func (s *redisJobStore) Result(id string) (io.ReadCloser, error) {
+	return &redisTail{s: s, key: s.key("result", id)}, nil
+}
+
+func (s *redisJobStore) Backlog(size int) jobBacklog {
+	return &redisBacklog{s: s, key: s.key("pending"), size: max(size, 1)}
+}
+
+// redisAppender appends each write to a string key.
+type redisAppender struct {
+	s   *redisJobStore
+	key string
+}
+
+func (a *redisAppender) Write(p []byte) (int, error) {
+	if err := a.s.rdb.Append(a.s.ctx, a.key, string(p)).Err(); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (a *redisAppender) Close() error { return nil }
+
+// redisTail reads a string key from the last offset read, so that it picks up
+// data appended after io.EOF.
+type redisTail struct {
+	s   *redisJobStore
+	key string
+	off int64
+}
+
+func (t *redisTail) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	b, err := t.s.rdb.GetRange(t.s.ctx, t.key, t.off, t.off+int64(len(p))-1).Bytes()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return 0, err
+	}
+	if len(b) == 0 {
+		return 0, io.EOF
+	}
+	t.off += int64(copy(p, b))
+	return len(b), nil
+}
+
+func (t *redisTail) Close() error { return nil }
+
+// redisBacklog is a backlog held in a Redis list.
+type redisBacklog struct {
+	s    *redisJobStore
+	key  string
+	size int
+}
+
+func (b *redisBacklog) Push(id string) error {
+	n, err := b.s.rdb.RPush(b.s.ctx, b.key, id).Result()
+	if err != nil {
+		return err
+	}
+	if n > int64(b.size) {
+		// Another server filled the queue first.
+		return errors.Join(errQueueFull, b.s.rdb.LRem(b.s.ctx, b.key, 1, id).Err())
+	}
+	return nil
+}
+
+// redisPopTimeout bounds each blocking pop, so that Pop notices ctx being done.
+const redisPopTimeout = 5 * time.Second
+
+func (b *redisBacklog) Pop(ctx context.Context) (string, error) {
+	for {
+		res, err := b.s.rdb.BLPop(ctx, redisPopTimeout, b.key).Result()
+		if errors.Is(err, redis.Nil) {
+			if err := ctx.Err(); err != nil {
+				return "", err
+			}
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return res[1], nil
+	}
+}
+
+func (b *redisBacklog) Len() (int, error) {
+	n, err := b.s.rdb.LLen(b.s.ctx, b.key).Result()
+	return int(n), err
+}
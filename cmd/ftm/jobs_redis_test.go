@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/pedrohavay/followthemoney/ftm"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedisStore(t *testing.T) *redisJobStore {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return newRedisJobStore(context.Background(), rdb, "test")
+}
+
+func TestRedisJobQueue(t *testing.T) {
+	store := newTestRedisStore(t)
+	q := newJobQueue(ftm.Default(), store, 1)
+	j, err := q.Submit("xref", nil, strings.NewReader(jobEntities))
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	// A second server on the same store shares the queue.
+	other := newJobQueue(ftm.Default(), store, 1)
+	if _, err := other.Submit("validate", nil, strings.NewReader(jobEntities)); !errors.Is(err, errQueueFull) {
+		t.Fatalf("full queue: %v", err)
+	}
+	if jobs, err := store.List(); err != nil || len(jobs) != 1 {
+		t.Fatalf("stored jobs: %v %v", jobs, err)
+	}
+	if n, err := other.Resume(); err != nil || n != 0 {
+		t.Fatalf("resume: %d %v", n, err)
+	}
+
+	other.Start(1)
+	if j = waitJob(t, store, j.ID); j.Status != jobDone || j.Records != 1 {
+		t.Fatalf("job: %+v", j)
+	}
+	var match xrefMatch
+	if err := json.Unmarshal([]byte(jobResult(t, store, j.ID)), &match); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if match.Left != "p1" || match.Right != "p2" {
+		t.Fatalf("match: %+v", match)
+	}
+
+	mux := http.NewServeMux()
+	q.handle(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	resp, err := http.Get(srv.URL + "/jobs/" + j.ID + "/events")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), "event: match\n") || !strings.Contains(string(body), "event: done\n") {
+		t.Fatalf("events: %s", body)
+	}
+}
+
+func TestRedisJobResultTail(t *testing.T) {
+	store := newTestRedisStore(t)
+	w, err := store.ResultWriter("j1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, _ := store.Result("j1")
+	read := func() string {
+		b, err := io.ReadAll(res)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	io.WriteString(w, "a\n")
+	if got := read(); got != "a\n" {
+		t.Fatalf("first read: %q", got)
+	}
+	io.WriteString(w, "b\n")
+	if got := read(); got != "b\n" {
+		t.Fatalf("second read: %q", got)
+	}
+	if err := store.Delete("j1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get("j1"); !errors.Is(err, errJobNotFound) {
+		t.Fatalf("deleted job: %v", err)
+	}
+}
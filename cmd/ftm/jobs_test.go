@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pedrohavay/followthemoney/ftm"
+)
+
+const jobEntities = `{"id":"p1","schema":"Person","properties":{"name":["John Smith"],"nationality":["gb"]}}
+{"id":"p2","schema":"Person","properties":{"name":["John Smith"],"nationality":["gb"]}}
+{"id":"p3","schema":"Person","properties":{"name":["Maria Garcia"]}}
+`
+
+func newTestQueue(t *testing.T, size int) (*jobQueue, *diskJobStore) {
+	t.Helper()
+	store, err := newDiskJobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("store: %v", err)
+	}
+	return newJobQueue(ftm.Default(), store, size), store
+}
+
+func waitJob(t *testing.T, store jobStore, id string) job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		j, err := store.Get(id)
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		if j.Status == jobDone || j.Status == jobFailed {
+			return j
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", id)
+	return job{}
+}
+
+func jobResult(t *testing.T, store jobStore, id string) string {
+	t.Helper()
+	res, err := store.Result(id)
+	if err != nil {
+		t.Fatalf("result: %v", err)
+	}
+	defer res.Close()
+	b, _ := io.ReadAll(res)
+	return string(b)
+}
+
+func TestJobQueueExport(t *testing.T) {
+	q, store := newTestQueue(t, 4)
+	q.Start(1)
+	j, err := q.Submit("export", jobParams{"dataset": "people"}, strings.NewReader(jobEntities))
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	if j = waitJob(t, store, j.ID); j.Status != jobDone || j.Records == 0 {
+		t.Fatalf("job: %+v", j)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(jobResult(t, store, j.ID)), "\n") {
+		var s ftm.Statement
+		if err := json.Unmarshal([]byte(line), &s); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if s.Dataset != "people" {
+			t.Fatalf("dataset: %+v", s)
+		}
+	}
+	if _, err := q.Submit("nope", nil, strings.NewReader("")); !errors.Is(err, errJobKind) {
+		t.Fatalf("unknown kind: %v", err)
+	}
+}
+
+func TestJobQueueXref(t *testing.T) {
+	q, store := newTestQueue(t, 4)
+	q.Start(1)
+	j, err := q.Submit("xref", nil, strings.NewReader(jobEntities))
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	if j = waitJob(t, store, j.ID); j.Status != jobDone || j.Records != 1 {
+		t.Fatalf("job: %+v", j)
+	}
+	var match xrefMatch
+	if err := json.Unmarshal([]byte(jobResult(t, store, j.ID)), &match); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if match.Left != "p1" || match.Right != "p2" || match.Score < 0.99 {
+		t.Fatalf("match: %+v", match)
+	}
+}
+
+func TestJobQueueFull(t *testing.T) {
+	q, store := newTestQueue(t, 1)
+	mux := http.NewServeMux()
+	q.handle(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	statuses := []int{}
+	for range 2 {
+		resp, err := http.Post(srv.URL+"/jobs/validate", "application/x-ndjson", strings.NewReader(jobEntities))
+		if err != nil {
+			t.Fatalf("post: %v", err)
+		}
+		resp.Body.Close()
+		statuses = append(statuses, resp.StatusCode)
+	}
+	if statuses[0] != http.StatusAccepted || statuses[1] != http.StatusServiceUnavailable {
+		t.Fatalf("statuses: %v", statuses)
+	}
+	// The rejected submission leaves nothing behind.
+	if entries, err := os.ReadDir(store.dir); err != nil || len(entries) != 1 {
+		t.Fatalf("stored jobs: %v %v", entries, err)
+	}
+	resp, err := http.Post(srv.URL+"/jobs/nope", "application/x-ndjson", strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("unknown kind status: %d", resp.StatusCode)
+	}
+}
+
+func TestJobQueueResume(t *testing.T) {
+	first, store := newTestQueue(t, 4)
+	queued, err := first.Submit("aggregate", nil, strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	running, err := first.Submit("validate", nil, strings.NewReader(jobEntities))
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	running.Status = jobRunning
+	if err := store.Update(running); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	// A new process on the same store picks both jobs up.
+	second := newJobQueue(ftm.Default(), store, 4)
+	if n, err := second.Resume(); err != nil || n != 2 {
+		t.Fatalf("resume: %d %v", n, err)
+	}
+	second.Start(1)
+	for _, id := range []string{queued.ID, running.ID} {
+		if j := waitJob(t, store, id); j.Status != jobDone {
+			t.Fatalf("job: %+v", j)
+		}
+	}
+	if n, err := newJobQueue(ftm.Default(), store, 4).Resume(); err != nil || n != 0 {
+		t.Fatalf("finished jobs should not resume: %d %v", n, err)
+	}
+	if res := jobResult(t, store, running.ID); res != "" {
+		t.Fatalf("valid entities should not be reported: %s", res)
+	}
+}
+
+func TestJobEvents(t *testing.T) {
+	q, _ := newTestQueue(t, 4)
+	mux := http.NewServeMux()
+	q.handle(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	j, err := q.Submit("xref", nil, strings.NewReader(jobEntities))
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	// Subscribe before the job runs, so that the stream waits for it.
+	resp, err := http.Get(srv.URL + "/jobs/" + j.ID + "/events")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("content type: %s", ct)
+	}
+	q.Start(1)
+
+	var events []string
+	var match xrefMatch
+	var final job
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if event, ok := strings.CutPrefix(scanner.Text(), "event: "); ok {
+			events = append(events, event)
+			continue
+		}
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		switch events[len(events)-1] {
+		case "match":
+			err = json.Unmarshal([]byte(data), &match)
+		case "done":
+			err = json.Unmarshal([]byte(data), &final)
+		}
+		if err != nil {
+			t.Fatalf("decode %s: %v", data, err)
+		}
+	}
+	if strings.Join(events, ",") != "match,done" {
+		t.Fatalf("events: %v", events)
+	}
+	if match.Left != "p1" || match.Right != "p2" || final.ID != j.ID || final.Records != 1 {
+		t.Fatalf("match %+v, job %+v", match, final)
+	}
+
+	resp, err = http.Get(srv.URL + "/jobs/missing/events")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("missing job status: %d", resp.StatusCode)
+	}
+}
@@ -2,21 +2,47 @@ package main
 
 import (
 	"bufio"
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
+	"iter"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/pedrohavay/followthemoney/ftm"
+	"gopkg.in/yaml.v3"
 )
 
-// Minimal CLI mirroring core Python commands: dump-model, validate, pretty, sign.
+// Minimal CLI mirroring core Python commands: dump-model, validate, pretty, sign, simplify-edges, verify-keys, partition, join, infer-mapping, serve, run, json-schema, typescript, accessors, parity, sort-statements, merge-statements, diff-statements, resolve, validate-statements, statement-stats, dedupe-statements.
+// Input on stdin and in files may be gzip or zstd compressed.
 // Usage:
-//   ftm dump-model
+//   ftm dump-model [-exclude hidden,abstract,generated,deprecated] [-lang de -translations <dir>]
 //   ftm validate < infile.jsonl > outfile.jsonl
 //   ftm pretty < infile.jsonl
 //   ftm sign -key <secret> < infile.jsonl > outfile.jsonl
+//   ftm simplify-edges [-schema Ownership] < infile.jsonl > outfile.jsonl
+//   ftm verify-keys [--fix] [-key sha1|sha256[+schema][+prop_type]] < statements.jsonl [> fixed.jsonl]
+//   ftm partition --by schema|country|dataset -out <dir> [-max-open 64] < infile.jsonl
+//   ftm join --on email --right extra.jsonl < infile.jsonl > outfile.jsonl
+//   ftm infer-mapping [-schema LegalEntity] [-rows 100] data.csv > mapping.yml
+//   ftm serve [-addr 127.0.0.1:8000] [-jobs <dir> | -redis <url> [-redis-prefix ftm]] [-workers 2] [-queue 1024]
+//   ftm run pipeline.yml
+//   ftm json-schema [-out <dir>]
+//   ftm typescript > model.ts
+//   ftm accessors [-package ftm] [-out file.go] Person Company ...
+//   ftm parity upstream-model.json
+//   ftm sort-statements [-format jsonl|csv] [-chunk 500000] [-tmp <dir>] [-compress gzip|zstd] < statements.jsonl > sorted.jsonl
+//   ftm merge-statements sorted-a.jsonl sorted-b.jsonl ... > merged.jsonl
+//   ftm diff-statements [-entities] old.jsonl new.jsonl > changes.jsonl
+//   ftm resolve -resolver resolver.ijson < statements.jsonl > resolved.jsonl
+//   ftm validate-statements < statements.jsonl > issues.jsonl
+//   ftm statement-stats < statements.jsonl > stats.json
+//   ftm dedupe-statements [-grouped] < statements.jsonl > deduped.jsonl
 
 func main() {
 	if len(os.Args) < 2 {
@@ -33,6 +59,45 @@ func main() {
 		pretty()
 	case "sign":
 		sign()
+	case "simplify-edges":
+		simplifyEdges()
+	case "verify-keys":
+		verifyKeys()
+	case "partition":
+		partition()
+	case "join":
+		join()
+	case "infer-mapping":
+		inferMapping()
+	case "serve":
+		serve()
+	case "run":
+		runPipeline()
+	case "json-schema":
+		jsonSchema()
+	case "typescript":
+		if err := ftm.WriteTypeScript(os.Stdout, ftm.Default()); err != nil {
+			fmt.Fprintf(os.Stderr, "error writing definitions: %v\n", err)
+			os.Exit(1)
+		}
+	case "accessors":
+		accessors()
+	case "parity":
+		parity()
+	case "sort-statements":
+		sortStatements()
+	case "merge-statements":
+		mergeStatements()
+	case "diff-statements":
+		diffStatements()
+	case "resolve":
+		resolve()
+	case "validate-statements":
+		validateStatements()
+	case "statement-stats":
+		statementStats()
+	case "dedupe-statements":
+		dedupeStatements()
 	case "help", "-h", "--help":
 		usage()
 	default:
@@ -43,36 +108,342 @@ func main() {
 }
 
 func usage() {
-	fmt.Fprintf(os.Stderr, "ftm commands: dump-model | validate | pretty | sign\n")
+	fmt.Fprintf(os.Stderr, "ftm commands: dump-model | validate | pretty | sign | simplify-edges | verify-keys | partition | join | infer-mapping | serve | run | json-schema | typescript | accessors | parity | sort-statements | merge-statements | diff-statements | resolve | validate-statements | statement-stats | dedupe-statements\n")
 }
 
+// stdin returns standard input, decompressed if it is gzip or zstd.
+func stdin() io.Reader {
+	r, err := ftm.Decompress(os.Stdin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading input: %v\n", err)
+		os.Exit(1)
+	}
+	return r
+}
+
+// dumpModel writes the model dump, optionally without the excluded kinds of
+// schemata and properties.
 func dumpModel() {
-	_ = ftm.Default() // ensure model loads
-	// Compact metadata: schemata names list and property qnames
-	out := map[string]any{"schemata": map[string]any{}, "types": []string{"string", "text", "name", "date", "number", "url", "country", "entity"}}
-	for name, sc := range ftm.Default().Schemata {
-		props := map[string]any{}
-		for n, p := range sc.Properties {
-			props[n] = map[string]any{"name": p.Name, "qname": p.QName, "type": p.Type.Name(), "label": p.Label}
+	fs := flag.NewFlagSet("dump-model", flag.ExitOnError)
+	exclude := fs.String("exclude", "", "comma-separated: hidden, abstract, generated, deprecated")
+	lang := fs.String("lang", "", "locale of labels, e.g. de")
+	catalogs := fs.String("translations", "", "directory of <locale>/LC_MESSAGES/*.po catalogs")
+	_ = fs.Parse(os.Args[2:])
+	m := ftm.Default()
+	if *exclude != "" {
+		opts := ftm.ViewOptions{IncludeHidden: true, IncludeAbstract: true, IncludeDeprecated: true}
+		for _, kind := range strings.Split(*exclude, ",") {
+			switch strings.TrimSpace(kind) {
+			case "hidden":
+				opts.IncludeHidden = false
+			case "abstract":
+				opts.IncludeAbstract = false
+			case "deprecated":
+				opts.IncludeDeprecated = false
+			case "generated":
+				opts.ExcludeGenerated = true
+			default:
+				fmt.Fprintf(os.Stderr, "unknown exclude filter: %s\n", kind)
+				os.Exit(2)
+			}
+		}
+		var err error
+		if m, err = m.View(opts); err != nil {
+			fmt.Fprintf(os.Stderr, "error filtering model: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	data := m.ToDict()
+	if *lang != "" && *catalogs != "" {
+		tr, err := ftm.LoadTranslations(os.DirFS(*catalogs))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error loading translations: %v\n", err)
+			os.Exit(1)
+		}
+		m.SetTranslations(tr)
+		data = m.ToDictIn(*lang)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(data)
+}
+
+// parity compares the model with an upstream model dump, and fails unless the
+// local model covers it.
+func parity() {
+	if len(os.Args) != 3 {
+		fmt.Fprintf(os.Stderr, "parity requires an upstream model dump\n")
+		os.Exit(2)
+	}
+	f, err := os.Open(os.Args[2])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error opening dump: %v\n", err)
+		os.Exit(2)
+	}
+	defer f.Close()
+	report, err := ftm.CheckParity(f, ftm.Default())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error checking parity: %v\n", err)
+		os.Exit(1)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(report)
+	if !report.Complete() {
+		f.Close()
+		os.Exit(1)
+	}
+}
+
+// sortStatements orders statements by canonical ID for aggregation, spilling
+// to temporary files for large inputs.
+func sortStatements() {
+	fs := flag.NewFlagSet("sort-statements", flag.ExitOnError)
+	format := fs.String("format", "jsonl", "statement format: jsonl or csv")
+	chunk := fs.Int("chunk", 500000, "statements sorted in memory per chunk")
+	tmp := fs.String("tmp", "", "directory for temporary chunks")
+	compress := fs.String("compress", "", "output compression: gzip or zstd")
+	_ = fs.Parse(os.Args[2:])
+	c, err := ftm.ParseCompression(*compress)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(2)
+	}
+	out, _ := ftm.Compress(os.Stdout, c)
+	opts := ftm.SortOptions{Format: *format, ChunkSize: *chunk, TempDir: *tmp}
+	if err := ftm.SortStatements(stdin(), out, opts); err != nil {
+		fmt.Fprintf(os.Stderr, "error sorting statements: %v\n", err)
+		os.Exit(1)
+	}
+	if err := out.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing statements: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// mergeStatements merges statement files sorted by sort-statements into one
+// sorted stream.
+func mergeStatements() {
+	fs := flag.NewFlagSet("merge-statements", flag.ExitOnError)
+	_ = fs.Parse(os.Args[2:])
+	if fs.NArg() == 0 {
+		fmt.Fprintf(os.Stderr, "merge-statements requires sorted statement files\n")
+		os.Exit(2)
+	}
+	var sources []iter.Seq2[ftm.Statement, error]
+	for _, name := range fs.Args() {
+		f, err := ftm.OpenStream(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error opening %s: %v\n", name, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		sources = append(sources, ftm.IterStatementsJSONL(f))
+	}
+	bw := bufio.NewWriter(os.Stdout)
+	defer bw.Flush()
+	for s, err := range ftm.MergeSortedStatements(sources...) {
+		if err == nil {
+			err = ftm.WriteStatementsJSONL(bw, []ftm.Statement{s})
+		}
+		if err != nil {
+			bw.Flush()
+			fmt.Fprintf(os.Stderr, "error merging statements: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// diffStatements writes the changes between two sorted statement files as JSON
+// lines, per statement or per entity.
+func diffStatements() {
+	fs := flag.NewFlagSet("diff-statements", flag.ExitOnError)
+	entities := fs.Bool("entities", false, "write one change per entity instead of per statement")
+	_ = fs.Parse(os.Args[2:])
+	if fs.NArg() != 2 {
+		fmt.Fprintf(os.Stderr, "diff-statements requires an old and a new statement file\n")
+		os.Exit(2)
+	}
+	var files [2]io.ReadCloser
+	for i, name := range fs.Args() {
+		f, err := ftm.OpenStream(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error opening %s: %v\n", name, err)
+			os.Exit(1)
 		}
-		out["schemata"].(map[string]any)[name] = map[string]any{
-			"label":      sc.Label,
-			"plural":     sc.Plural,
-			"extends":    schemaNames(sc.Extends),
-			"properties": props,
+		defer f.Close()
+		files[i] = f
+	}
+	a, b := ftm.IterStatementsJSONL(files[0]), ftm.IterStatementsJSONL(files[1])
+	bw := bufio.NewWriter(os.Stdout)
+	defer bw.Flush()
+	enc := json.NewEncoder(bw)
+	var err error
+	if *entities {
+		for ch, cerr := range ftm.DiffEntities(a, b) {
+			if err = cerr; err != nil {
+				break
+			}
+			_ = enc.Encode(ch)
+		}
+	} else {
+		for ch, cerr := range ftm.DiffStatements(a, b) {
+			if err = cerr; err != nil {
+				break
+			}
+			_ = enc.Encode(ch)
 		}
 	}
+	if err != nil {
+		bw.Flush()
+		fmt.Fprintf(os.Stderr, "error comparing statements: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// resolve rewrites the canonical IDs of statements with the merge decisions of
+// a nomenklatura resolver or linker file.
+func resolve() {
+	fs := flag.NewFlagSet("resolve", flag.ExitOnError)
+	path := fs.String("resolver", "", "resolver (JSON lines of edges) or linker (JSON object) file")
+	_ = fs.Parse(os.Args[2:])
+	if *path == "" {
+		fmt.Fprintf(os.Stderr, "resolve requires -resolver\n")
+		os.Exit(2)
+	}
+	f, err := ftm.OpenStream(*path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error opening %s: %v\n", *path, err)
+		os.Exit(1)
+	}
+	r, err := ftm.LoadResolver(f)
+	f.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error loading resolver: %v\n", err)
+		os.Exit(1)
+	}
+	bw := bufio.NewWriter(os.Stdout)
+	defer bw.Flush()
+	err = ftm.ReadStatementsJSONL(stdin(), func(s ftm.Statement) error {
+		return ftm.WriteStatementsJSONL(bw, []ftm.Statement{r.Apply(s)})
+	})
+	if err != nil {
+		bw.Flush()
+		fmt.Fprintf(os.Stderr, "error reading statements: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// validateStatements writes the issues found in a statement stream as JSON
+// lines and fails if there are any.
+func validateStatements() {
+	fs := flag.NewFlagSet("validate-statements", flag.ExitOnError)
+	_ = fs.Parse(os.Args[2:])
+	report, err := ftm.ValidateStatements(ftm.Default(), ftm.IterStatementsJSONL(stdin()))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading statements: %v\n", err)
+		os.Exit(1)
+	}
+	bw := bufio.NewWriter(os.Stdout)
+	enc := json.NewEncoder(bw)
+	for _, issue := range report.Issues {
+		_ = enc.Encode(issue)
+	}
+	bw.Flush()
+	fmt.Fprintf(os.Stderr, "%d statements, %d invalid\n", report.Statements, report.Invalid)
+	if !report.Valid() {
+		os.Exit(1)
+	}
+}
+
+// statementStats writes counts and date coverage of a statement stream as a
+// JSON report.
+func statementStats() {
+	fs := flag.NewFlagSet("statement-stats", flag.ExitOnError)
+	_ = fs.Parse(os.Args[2:])
+	stats, err := ftm.CollectStatementStats(ftm.Default(), ftm.IterStatementsJSONL(stdin()))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading statements: %v\n", err)
+		os.Exit(1)
+	}
 	enc := json.NewEncoder(os.Stdout)
 	enc.SetIndent("", "  ")
-	_ = enc.Encode(out)
+	_ = enc.Encode(stats)
 }
 
-func schemaNames(xs []*ftm.Schema) []string {
-	out := make([]string, 0, len(xs))
-	for _, s := range xs {
-		out = append(out, s.Name)
+// dedupeStatements drops repeated statements, merging their seen dates.
+func dedupeStatements() {
+	fs := flag.NewFlagSet("dedupe-statements", flag.ExitOnError)
+	grouped := fs.Bool("grouped", false, "input is sorted by canonical ID; hold one entity in memory at a time")
+	_ = fs.Parse(os.Args[2:])
+	bw := bufio.NewWriter(os.Stdout)
+	defer bw.Flush()
+	dw := ftm.NewDedupeWriter(func(s ftm.Statement) error {
+		return ftm.WriteStatementsJSONL(bw, []ftm.Statement{s})
+	})
+	dw.Grouped = *grouped
+	err := ftm.ReadStatementsJSONL(stdin(), dw.Write)
+	if err == nil {
+		err = dw.Close()
+	}
+	if err != nil {
+		bw.Flush()
+		fmt.Fprintf(os.Stderr, "error deduplicating statements: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "%d repeated statements dropped\n", dw.Dropped)
+}
+
+// accessors generates typed entity wrappers for the named schemata.
+func accessors() {
+	fs := flag.NewFlagSet("accessors", flag.ExitOnError)
+	pkg := fs.String("package", "ftm", "name of the generated package")
+	out := fs.String("out", "", "file to write, default stdout")
+	_ = fs.Parse(os.Args[2:])
+	if fs.NArg() == 0 {
+		fmt.Fprintf(os.Stderr, "accessors requires schema names\n")
+		os.Exit(2)
+	}
+	var buf bytes.Buffer
+	opts := ftm.AccessorOptions{Package: *pkg, Schemata: fs.Args()}
+	if err := ftm.WriteAccessors(&buf, ftm.Default(), opts); err != nil {
+		fmt.Fprintf(os.Stderr, "error generating accessors: %v\n", err)
+		os.Exit(1)
+	}
+	if *out == "" {
+		_, _ = os.Stdout.Write(buf.Bytes())
+		return
+	}
+	if err := os.WriteFile(*out, buf.Bytes(), 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing %s: %v\n", *out, err)
+		os.Exit(1)
+	}
+}
+
+// jsonSchema writes a JSON Schema document per concrete schema, either as files
+// <dir>/<Schema>.json or as one object keyed by schema name on stdout.
+func jsonSchema() {
+	fs := flag.NewFlagSet("json-schema", flag.ExitOnError)
+	out := fs.String("out", "", "directory to write one file per schema to")
+	_ = fs.Parse(os.Args[2:])
+	docs := ftm.Default().JSONSchemas()
+	if *out == "" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(docs)
+		return
+	}
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		fmt.Fprintf(os.Stderr, "error creating %s: %v\n", *out, err)
+		os.Exit(1)
+	}
+	for name, doc := range docs {
+		buf, _ := json.MarshalIndent(doc, "", "  ")
+		if err := os.WriteFile(filepath.Join(*out, name+".json"), append(buf, '\n'), 0o644); err != nil {
+			fmt.Fprintf(os.Stderr, "error writing schema %s: %v\n", name, err)
+			os.Exit(1)
+		}
 	}
-	return out
 }
 
 type entityJSON struct {
@@ -83,7 +454,7 @@ type entityJSON struct {
 
 func validate() {
 	m := ftm.Default()
-	br := bufio.NewReader(os.Stdin)
+	br := bufio.NewReader(stdin())
 	bw := bufio.NewWriter(os.Stdout)
 	defer bw.Flush()
 	dec := json.NewDecoder(br)
@@ -107,13 +478,15 @@ func validate() {
 			_ = proxy.Add(name, vals, false)
 		}
 		// revalidate and normalize: emit cleaned dict
-		_ = sc.Validate(proxy.ToDict()["properties"].(map[string][]string))
+		if err := sc.Validate(proxy.ToDict()["properties"].(map[string][]string)); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", e.ID, err)
+		}
 		_ = enc.Encode(proxy.ToDict())
 	}
 }
 
 func pretty() {
-	br := bufio.NewScanner(os.Stdin)
+	br := bufio.NewScanner(stdin())
 	for br.Scan() {
 		line := br.Text()
 		// best effort to pretty-print a single JSON object per line
@@ -134,7 +507,7 @@ func sign() {
 	_ = fs.Parse(os.Args[2:])
 	ns := ftm.NewNamespace(*key)
 	m := ftm.Default()
-	dec := json.NewDecoder(os.Stdin)
+	dec := json.NewDecoder(stdin())
 	enc := json.NewEncoder(os.Stdout)
 	for {
 		var e entityJSON
@@ -157,3 +530,282 @@ func sign() {
 		_ = enc.Encode(signed.ToDict())
 	}
 }
+
+func simplifyEdges() {
+	fs := flag.NewFlagSet("simplify-edges", flag.ExitOnError)
+	only := fs.String("schema", "", "comma-separated relationship schemata to collapse (default: all known)")
+	_ = fs.Parse(os.Args[2:])
+	rules := ftm.DefaultReifyRules
+	if *only != "" {
+		rules = nil
+		for _, name := range strings.Split(*only, ",") {
+			for _, r := range ftm.DefaultReifyRules {
+				if r.Schema == strings.TrimSpace(name) {
+					rules = append(rules, r)
+				}
+			}
+		}
+	}
+	// The schema of the holder decides which rule applies, so all entities
+	// are read before relationships are simplified.
+	m := ftm.Default()
+	dec := json.NewDecoder(stdin())
+	var proxies []*ftm.EntityProxy
+	schemata := map[string]*ftm.Schema{}
+	for {
+		var e entityJSON
+		if err := dec.Decode(&e); err != nil {
+			if err == io.EOF {
+				break
+			}
+			fmt.Fprintf(os.Stderr, "error decoding JSON: %v\n", err)
+			os.Exit(1)
+		}
+		sc := m.Get(e.Schema)
+		if sc == nil {
+			continue
+		}
+		proxy := ftm.NewEntityProxy(sc, e.ID)
+		for name, vals := range e.Properties {
+			_ = proxy.Add(name, vals, true)
+		}
+		proxies = append(proxies, proxy)
+		schemata[proxy.ID] = sc
+	}
+	resolve := func(id string) (*ftm.Schema, bool) {
+		sc, ok := schemata[id]
+		return sc, ok
+	}
+	enc := json.NewEncoder(os.Stdout)
+	for _, proxy := range proxies {
+		if simple, ok := ftm.SimplifyWith(proxy, rules, resolve); ok {
+			proxy = simple
+		}
+		_ = enc.Encode(proxy.ToDict())
+	}
+}
+
+func verifyKeys() {
+	fs := flag.NewFlagSet("verify-keys", flag.ExitOnError)
+	fix := fs.Bool("fix", false, "write all statements with recomputed IDs to stdout")
+	spec := fs.String("key", "sha1", "statement key recipe, e.g. sha256+schema+prop_type")
+	_ = fs.Parse(os.Args[2:])
+	alg, err := ftm.ParseStatementKeyAlgorithm(*spec)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(2)
+	}
+	bw := bufio.NewWriter(os.Stdout)
+	defer bw.Flush()
+	total, bad := 0, 0
+	err = ftm.ReadStatementsJSONLWith(stdin(), ftm.ReadOptions{KeyAlgorithm: &alg}, func(s ftm.Statement) error {
+		total++
+		want := alg.Key(s)
+		if s.ID != want {
+			bad++
+			fmt.Fprintf(os.Stderr, "mismatch at statement %d: id=%s expected=%s (entity=%s prop=%s)\n", total, s.ID, want, s.EntityID, s.Prop)
+			s.ID = want
+		}
+		if *fix {
+			return ftm.WriteStatementsJSONL(bw, []ftm.Statement{s})
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading statements: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "%d statements, %d mismatching IDs\n", total, bad)
+	if bad > 0 && !*fix {
+		bw.Flush()
+		os.Exit(1)
+	}
+}
+
+func partition() {
+	fs := flag.NewFlagSet("partition", flag.ExitOnError)
+	by := fs.String("by", ftm.PartitionBySchema, "partition field: schema, country or dataset")
+	dir := fs.String("out", "partitions", "output directory")
+	maxOpen := fs.Int("max-open", ftm.DefaultPartitionMaxOpen, "partition files kept open at once")
+	_ = fs.Parse(os.Args[2:])
+	pw, err := ftm.NewPartitionWriter(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error creating output: %v\n", err)
+		os.Exit(1)
+	}
+	pw.MaxOpen = *maxOpen
+	err = readEntities(ftm.Default(), stdin(), func(proxy *ftm.EntityProxy) error {
+		key, err := ftm.PartitionKey(proxy, *by)
+		if err != nil {
+			return err
+		}
+		return pw.Write(key, proxy)
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error partitioning: %v\n", err)
+		os.Exit(1)
+	}
+	if err := pw.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "error closing partitions: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// readEntities decodes a stream of entity JSON objects and calls fn for each
+// entity that can be loaded into the model.
+func readEntities(m *ftm.Model, r io.Reader, fn func(*ftm.EntityProxy) error) error {
+	dec := json.NewDecoder(bufio.NewReader(r))
+	for {
+		var data map[string]any
+		if err := dec.Decode(&data); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		proxy, err := ftm.EntityProxyFromDict(m, data, "")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "skipping entity: %v\n", err)
+			continue
+		}
+		if err := fn(proxy); err != nil {
+			return err
+		}
+	}
+}
+
+func join() {
+	fs := flag.NewFlagSet("join", flag.ExitOnError)
+	on := fs.String("on", "email", "pivot property type, e.g. email, phone or identifier")
+	right := fs.String("right", "", "JSON lines file with lookup entities")
+	_ = fs.Parse(os.Args[2:])
+	m := ftm.Default()
+	pivot := m.Registry().Get(*on)
+	if pivot == nil || *right == "" {
+		fmt.Fprintf(os.Stderr, "join requires --right and a valid --on type\n")
+		os.Exit(2)
+	}
+	idx := ftm.NewJoinIndex(pivot)
+	f, err := ftm.OpenStream(*right)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error opening %s: %v\n", *right, err)
+		os.Exit(1)
+	}
+	err = readEntities(m, f, func(e *ftm.EntityProxy) error { idx.Add(e); return nil })
+	f.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading %s: %v\n", *right, err)
+		os.Exit(1)
+	}
+	bw := bufio.NewWriter(os.Stdout)
+	defer bw.Flush()
+	enc := json.NewEncoder(bw)
+	joined := 0
+	err = readEntities(m, stdin(), func(e *ftm.EntityProxy) error {
+		if idx.Enrich(e) > 0 {
+			joined++
+		}
+		return enc.Encode(e)
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error decoding JSON: %v\n", err)
+		bw.Flush()
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "%d entities enriched\n", joined)
+}
+
+// mappingProperty and mappingEntity mirror the query mapping format of the Python
+// package, so the emitted draft can be refined and run there.
+type mappingProperty struct {
+	Column  string   `yaml:"column,omitempty"`
+	Columns []string `yaml:"columns,omitempty"`
+}
+
+type mappingEntity struct {
+	Schema     string                     `yaml:"schema"`
+	Keys       []string                   `yaml:"keys"`
+	Properties map[string]mappingProperty `yaml:"properties"`
+}
+
+func inferMapping() {
+	fs := flag.NewFlagSet("infer-mapping", flag.ExitOnError)
+	schemaName := fs.String("schema", "LegalEntity", "schema of the mapped entities")
+	maxRows := fs.Int("rows", 100, "number of rows to sample")
+	_ = fs.Parse(os.Args[2:])
+	if fs.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "infer-mapping requires a CSV file\n")
+		os.Exit(2)
+	}
+	schema := ftm.Default().Get(*schemaName)
+	if schema == nil {
+		fmt.Fprintf(os.Stderr, "unknown schema: %s\n", *schemaName)
+		os.Exit(2)
+	}
+	path := fs.Arg(0)
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error opening %s: %v\n", path, err)
+		os.Exit(1)
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading header: %v\n", err)
+		os.Exit(1)
+	}
+	var rows [][]string
+	for len(rows) < *maxRows {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error reading CSV: %v\n", err)
+			os.Exit(1)
+		}
+		rows = append(rows, row)
+	}
+
+	guesses := ftm.InferMapping(schema, header, rows)
+	ent := mappingEntity{Schema: schema.Name, Keys: ftm.InferKeys(schema, guesses), Properties: map[string]mappingProperty{}}
+	for _, g := range guesses {
+		if g.Property == "" {
+			if !slices.Contains(ent.Keys, g.Column) {
+				fmt.Fprintf(os.Stderr, "unmapped column: %s\n", g.Column)
+			}
+			continue
+		}
+		mp := ent.Properties[g.Property]
+		switch {
+		case mp.Column == "" && len(mp.Columns) == 0:
+			mp.Column = g.Column
+		case mp.Column != "":
+			mp.Columns, mp.Column = []string{mp.Column, g.Column}, ""
+		default:
+			mp.Columns = append(mp.Columns, g.Column)
+		}
+		ent.Properties[g.Property] = mp
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	doc := map[string]any{
+		"data": map[string]any{
+			"query": map[string]any{
+				"csv_url":  "file://" + abs,
+				"entities": map[string]mappingEntity{"entity": ent},
+			},
+		},
+	}
+	enc := yaml.NewEncoder(os.Stdout)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing mapping: %v\n", err)
+		os.Exit(1)
+	}
+	_ = enc.Close()
+}
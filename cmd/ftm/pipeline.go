@@ -0,0 +1,390 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pedrohavay/followthemoney/ftm"
+	"gopkg.in/yaml.v3"
+)
+
+// pipelineConfig describes a chain of stages run in one process, e.g.:
+//
+//	dataset: companies
+//	input: data.csv
+//	stages:
+//	  - stage: map
+//	    mapping: mapping.yml
+//	  - stage: validate
+//	    drop: true
+//	  - stage: sign
+//	    key: secret
+//	  - stage: canonicalize
+//	  - stage: aggregate
+//	  - stage: export
+//	    format: statements
+//	    output: statements.jsonl.gz
+//
+// Entities stream from stage to stage without being re-serialized; only
+// aggregate buffers its input. Relative paths are resolved against the
+// directory of the pipeline file. Compressed input is detected, and outputs
+// ending in .gz or .zst are compressed.
+type pipelineConfig struct {
+	Dataset string          `yaml:"dataset"`
+	Input   string          `yaml:"input"`  // default stdin
+	Format  string          `yaml:"format"` // entities (default), statements or csv
+	Stages  []pipelineStage `yaml:"stages"`
+}
+
+type pipelineStage struct {
+	Stage   string `yaml:"stage"`   // map, validate, sign, canonicalize, aggregate or export
+	Mapping string `yaml:"mapping"` // map: mapping file as written by infer-mapping
+	Drop    bool   `yaml:"drop"`    // validate: drop invalid entities instead of reporting them
+	Key     string `yaml:"key"`     // sign: HMAC signature key
+	Output  string `yaml:"output"`  // export: file, default stdout
+	Format  string `yaml:"format"`  // export: entities (default) or statements
+}
+
+type entityStream = iter.Seq2[*ftm.EntityProxy, error]
+
+func runPipeline() {
+	if len(os.Args) != 3 {
+		fmt.Fprintf(os.Stderr, "run requires a pipeline file\n")
+		os.Exit(2)
+	}
+	path := os.Args[2]
+	cfg, err := loadPipeline(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error loading pipeline: %v\n", err)
+		os.Exit(2)
+	}
+	n, err := cfg.run(ftm.Default(), filepath.Dir(path), os.Stdin, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "pipeline failed after %d entities: %v\n", n, err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "pipeline done: %d entities\n", n)
+}
+
+func loadPipeline(path string) (*pipelineConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg pipelineConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Dataset == "" {
+		cfg.Dataset = "default"
+	}
+	for i, st := range cfg.Stages {
+		switch st.Stage {
+		case "map":
+			if i != 0 || st.Mapping == "" || cfg.Format != "csv" {
+				return nil, fmt.Errorf("map must be the first stage, with a mapping and csv input")
+			}
+		case "validate", "sign", "canonicalize", "aggregate":
+		case "export":
+			if i != len(cfg.Stages)-1 {
+				return nil, fmt.Errorf("export must be the last stage")
+			}
+			if st.Format != "" && st.Format != "entities" && st.Format != "statements" {
+				return nil, fmt.Errorf("unknown export format: %s", st.Format)
+			}
+		default:
+			return nil, fmt.Errorf("unknown stage: %q", st.Stage)
+		}
+	}
+	if cfg.Format == "csv" && (len(cfg.Stages) == 0 || cfg.Stages[0].Stage != "map") {
+		return nil, fmt.Errorf("csv input requires a map stage")
+	}
+	return &cfg, nil
+}
+
+// run executes the pipeline and returns the number of entities leaving the last stage.
+func (cfg *pipelineConfig) run(m *ftm.Model, dir string, stdin io.Reader, stdout io.Writer) (int, error) {
+	resolve := func(p string) string {
+		if p == "" || p == "-" || filepath.IsAbs(p) {
+			return p
+		}
+		return filepath.Join(dir, p)
+	}
+	var in io.ReadCloser
+	if p := resolve(cfg.Input); p != "" && p != "-" {
+		f, err := ftm.OpenStream(p)
+		if err != nil {
+			return 0, err
+		}
+		in = f
+	} else {
+		r, err := ftm.Decompress(stdin)
+		if err != nil {
+			return 0, err
+		}
+		in = r
+	}
+	defer in.Close()
+
+	var stream entityStream
+	stages := cfg.Stages
+	switch cfg.Format {
+	case "csv":
+		mapping, err := loadMapping(resolve(stages[0].Mapping))
+		if err != nil {
+			return 0, err
+		}
+		stream = mapCSV(m, mapping, in)
+		stages = stages[1:]
+	case "statements":
+		stream = ftm.IterAggregate(m, ftm.IterStatementsJSONL(in))
+	case "", "entities":
+		stream = ftm.IterEntitiesJSONL(m, in)
+	default:
+		return 0, fmt.Errorf("unknown input format: %s", cfg.Format)
+	}
+
+	var closers []io.Closer
+	defer func() {
+		for _, c := range closers {
+			_ = c.Close()
+		}
+	}()
+	for _, st := range stages {
+		switch st.Stage {
+		case "validate":
+			stream = validateStage(stream, st.Drop)
+		case "sign":
+			stream = mapStage(stream, ftm.NewNamespace(st.Key).Apply)
+		case "canonicalize":
+			stream = mapStage(stream, canonicalize)
+		case "aggregate":
+			stream = aggregateStage(stream)
+		case "export":
+			w := stdout
+			if p := resolve(st.Output); p != "" && p != "-" {
+				f, err := ftm.CreateStream(p)
+				if err != nil {
+					return 0, err
+				}
+				closers = append(closers, f)
+				w = f
+			}
+			bw := bufio.NewWriter(w)
+			closers = append(closers, flushCloser{bw})
+			stream = exportStage(stream, bw, st.Format, cfg.Dataset)
+		}
+	}
+
+	n := 0
+	for _, err := range stream {
+		if err != nil {
+			return n, err
+		}
+		n++
+	}
+	for i := len(closers) - 1; i >= 0; i-- {
+		if err := closers[i].Close(); err != nil {
+			return n, err
+		}
+	}
+	closers = nil
+	return n, nil
+}
+
+type flushCloser struct{ w *bufio.Writer }
+
+func (f flushCloser) Close() error { return f.w.Flush() }
+
+// mapStage applies fn to every entity.
+func mapStage(src entityStream, fn func(*ftm.EntityProxy, bool) *ftm.EntityProxy) entityStream {
+	return func(yield func(*ftm.EntityProxy, error) bool) {
+		for e, err := range src {
+			if err == nil {
+				e = fn(e, false)
+			}
+			if !yield(e, err) {
+				return
+			}
+		}
+	}
+}
+
+// canonicalize collapses equivalent and near-duplicate values.
+func canonicalize(e *ftm.EntityProxy, _ bool) *ftm.EntityProxy {
+	ftm.DedupeValues(e)
+	ftm.ConsolidateText(e)
+	return e
+}
+
+func validateStage(src entityStream, drop bool) entityStream {
+	return func(yield func(*ftm.EntityProxy, error) bool) {
+		for e, err := range src {
+			if err == nil {
+				if errs := e.Validate(); len(errs) > 0 {
+					fmt.Fprintf(os.Stderr, "%s: %v\n", e.ID, &ftm.ValidationError{Schema: e.Schema.Name, Errors: errs})
+					if drop {
+						continue
+					}
+				}
+			}
+			if !yield(e, err) {
+				return
+			}
+		}
+	}
+}
+
+// aggregateStage merges entities sharing an ID. It consumes the whole input
+// before emitting entities in order of first appearance.
+func aggregateStage(src entityStream) entityStream {
+	return func(yield func(*ftm.EntityProxy, error) bool) {
+		var order []string
+		merged := map[string]*ftm.EntityProxy{}
+		for e, err := range src {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			prev, ok := merged[e.ID]
+			if !ok {
+				merged[e.ID] = e
+				order = append(order, e.ID)
+				continue
+			}
+			if _, err := prev.Merge(e); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", e.ID, err)
+			}
+		}
+		for _, id := range order {
+			if !yield(merged[id], nil) {
+				return
+			}
+		}
+	}
+}
+
+// exportStage writes entities (or their statements) to w and passes them on
+// for counting.
+func exportStage(src entityStream, w io.Writer, format, dataset string) entityStream {
+	enc := json.NewEncoder(w)
+	return func(yield func(*ftm.EntityProxy, error) bool) {
+		for e, err := range src {
+			if err == nil {
+				if format == "statements" {
+					err = ftm.WriteStatementsJSONL(w, ftm.StatementsFromEntity(e, dataset, "", "", false, ""))
+				} else {
+					err = enc.Encode(e.ToDict())
+				}
+			}
+			if !yield(e, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// loadMapping reads the entities of a mapping file as written by infer-mapping.
+func loadMapping(path string) (map[string]mappingEntity, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]struct {
+		Query struct {
+			Entities map[string]mappingEntity `yaml:"entities"`
+		} `yaml:"query"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	entities := map[string]mappingEntity{}
+	for _, d := range doc {
+		for name, ent := range d.Query.Entities {
+			entities[name] = ent
+		}
+	}
+	if len(entities) == 0 {
+		return nil, fmt.Errorf("%s: no entities mapped", path)
+	}
+	return entities, nil
+}
+
+// mapCSV turns each CSV row into one entity per mapped entity. IDs are hashed
+// from the key columns; rows without key values are skipped.
+func mapCSV(m *ftm.Model, mapping map[string]mappingEntity, r io.Reader) entityStream {
+	return func(yield func(*ftm.EntityProxy, error) bool) {
+		cr := csv.NewReader(r)
+		cr.FieldsPerRecord = -1
+		header, err := cr.Read()
+		if err != nil {
+			yield(nil, fmt.Errorf("reading CSV header: %w", err))
+			return
+		}
+		index := map[string]int{}
+		for i, h := range header {
+			index[h] = i
+		}
+		names := make([]string, 0, len(mapping))
+		for name := range mapping {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if m.Get(mapping[name].Schema) == nil {
+				yield(nil, fmt.Errorf("mapping %s: unknown schema %s", name, mapping[name].Schema))
+				return
+			}
+		}
+		for {
+			row, err := cr.Read()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(nil, fmt.Errorf("reading CSV: %w", err))
+				return
+			}
+			cell := func(col string) string {
+				if i, ok := index[col]; ok && i < len(row) {
+					return row[i]
+				}
+				return ""
+			}
+			for _, name := range names {
+				ent := mapping[name]
+				schema := m.Get(ent.Schema)
+				keys := make([]string, len(ent.Keys))
+				for i, k := range ent.Keys {
+					keys[i] = cell(k)
+				}
+				id, ok := ftm.IDRecipe{}.Make(schema, keys...)
+				if !ok {
+					continue
+				}
+				e := ftm.NewEntityProxy(schema, id)
+				for prop, mp := range ent.Properties {
+					cols := mp.Columns
+					if mp.Column != "" {
+						cols = append([]string{mp.Column}, cols...)
+					}
+					for _, col := range cols {
+						if v := cell(col); v != "" {
+							_ = e.Add(prop, []string{v}, true)
+						}
+					}
+				}
+				if !yield(e, nil) {
+					return
+				}
+			}
+		}
+	}
+}
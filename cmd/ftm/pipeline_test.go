@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pedrohavay/followthemoney/ftm"
+)
+
+func writePipeline(t *testing.T, dir, config string) string {
+	t.Helper()
+	path := filepath.Join(dir, "pipeline.yml")
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	return path
+}
+
+func TestLoadPipeline(t *testing.T) {
+	dir := t.TempDir()
+	for _, bad := range []string{
+		"stages: [{stage: export}, {stage: validate}]",
+		"stages: [{stage: export}, {stage: export}]",
+		"stages: [{stage: export, format: csv}]",
+		"stages: [{stage: frobnicate}]",
+		"format: csv\nstages: [{stage: validate}]",
+		"stages: [{stage: validate}, {stage: map, mapping: m.yml}]",
+	} {
+		if _, err := loadPipeline(writePipeline(t, dir, bad)); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+	cfg, err := loadPipeline(writePipeline(t, dir, "stages: [{stage: validate}, {stage: export}]"))
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Dataset != "default" || len(cfg.Stages) != 2 {
+		t.Fatalf("config: %+v", cfg)
+	}
+}
+
+func TestPipelineRun(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := loadPipeline(writePipeline(t, dir, `
+dataset: people
+stages:
+  - stage: aggregate
+  - stage: validate
+    drop: true
+  - stage: export
+    format: statements
+`))
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	in := strings.NewReader(`{"id":"p1","schema":"Person","properties":{"name":["Jane Doe"]}}
+{"id":"p1","schema":"Person","properties":{"nationality":["fr"]}}
+{"id":"p2","schema":"Person","properties":{"nationality":["de"]}}
+`)
+	var out bytes.Buffer
+	n, err := cfg.run(ftm.Default(), dir, in, &out)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("expected one entity, got %d", n)
+	}
+	props := map[string]string{}
+	for s, err := range ftm.IterStatementsJSONL(&out) {
+		if err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		if s.EntityID != "p1" || s.Dataset != "people" {
+			t.Fatalf("statement: %+v", s)
+		}
+		props[s.Prop] = s.Value
+	}
+	if props["name"] != "Jane Doe" || props["nationality"] != "fr" {
+		t.Fatalf("statements: %v", props)
+	}
+}
+
+func TestPipelineRunCSV(t *testing.T) {
+	dir := t.TempDir()
+	mapping := `
+people:
+  query:
+    entities:
+      person:
+        schema: Person
+        keys: [id]
+        properties:
+          name:
+            column: name
+`
+	if err := os.WriteFile(filepath.Join(dir, "mapping.yml"), []byte(mapping), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := loadPipeline(writePipeline(t, dir, `
+format: csv
+stages:
+  - stage: map
+    mapping: mapping.yml
+  - stage: export
+    output: out.jsonl
+`))
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	n, err := cfg.run(ftm.Default(), dir, strings.NewReader("id,name\n1,Jane Doe\n,No Key\n2,John Doe\n"), &bytes.Buffer{})
+	if err != nil || n != 2 {
+		t.Fatalf("run: %d %v", n, err)
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, "out.jsonl"))
+	if err != nil {
+		t.Fatalf("output: %v", err)
+	}
+	var names []string
+	for e, err := range ftm.IterEntitiesJSONL(ftm.Default(), bytes.NewReader(raw)) {
+		if err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		names = append(names, e.Get("name")...)
+	}
+	if strings.Join(names, ",") != "Jane Doe,John Doe" {
+		t.Fatalf("names: %v", names)
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/pedrohavay/followthemoney/ftm"
+	"github.com/redis/go-redis/v9"
+)
+
+// serve runs an HTTP server exposing batch operations. Results are streamed as
+// server-sent events while they are produced:
+//
+//	POST /aggregate/stream   statements JSONL (sorted by entity) -> "entity" events
+//
+// Long-running operations can also be submitted as asynchronous jobs (see jobQueue.handle).
+func serve() {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", "127.0.0.1:8000", "listen address")
+	jobsDir := fs.String("jobs", filepath.Join(os.TempDir(), "ftm-jobs"), "job storage directory")
+	redisURL := fs.String("redis", "", "keep jobs in Redis at this URL (e.g. redis://localhost:6379/0) instead of -jobs")
+	redisPrefix := fs.String("redis-prefix", "ftm", "key prefix of jobs in Redis")
+	workers := fs.Int("workers", 2, "number of job workers")
+	queueSize := fs.Int("queue", 1024, "number of jobs waiting for a worker before submissions are rejected")
+	_ = fs.Parse(os.Args[2:])
+	m := ftm.Default()
+	var store jobStore
+	if *redisURL != "" {
+		opts, err := redis.ParseURL(*redisURL)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error parsing redis URL: %v\n", err)
+			os.Exit(2)
+		}
+		store = newRedisJobStore(context.Background(), redis.NewClient(opts), *redisPrefix)
+	} else {
+		disk, err := newDiskJobStore(*jobsDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error creating job store: %v\n", err)
+			os.Exit(1)
+		}
+		store = disk
+	}
+	mux := newServeMux(m)
+	queue := newJobQueue(m, store, *queueSize)
+	if n, err := queue.Resume(); err != nil {
+		fmt.Fprintf(os.Stderr, "error resuming jobs: %v\n", err)
+		os.Exit(1)
+	} else if n > 0 {
+		log.Printf("resuming %d jobs", n)
+	}
+	queue.Start(*workers)
+	queue.handle(mux)
+	log.Printf("listening on %s", *addr)
+	if err := http.ListenAndServe(*addr, mux); err != nil {
+		fmt.Fprintf(os.Stderr, "error serving: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func newServeMux(m *ftm.Model) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /aggregate/stream", func(w http.ResponseWriter, r *http.Request) {
+		// Events are sent while the statements are still being read; without
+		// full duplex, HTTP/1.1 closes the body once the headers are flushed.
+		_ = http.NewResponseController(w).EnableFullDuplex()
+		sse, ok := newSSEWriter(w)
+		if !ok {
+			http.Error(w, "streaming not supported", http.StatusInternalServerError)
+			return
+		}
+		count := 0
+		for proxy, err := range ftm.IterAggregate(m, ftm.IterStatementsJSONL(r.Body)) {
+			if err != nil {
+				_ = sse.Send("error", map[string]string{"error": err.Error()})
+				return
+			}
+			if err := sse.Send("entity", proxy); err != nil {
+				return // client went away
+			}
+			count++
+		}
+		_ = sse.Send("done", map[string]int{"entities": count})
+	})
+	return mux
+}
+
+// sseWriter writes JSON-encoded server-sent events and flushes each one.
+type sseWriter struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+func newSSEWriter(w http.ResponseWriter) (*sseWriter, bool) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, false
+	}
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush() // let clients see the stream open before the first event
+	return &sseWriter{w: w, flusher: flusher}, true
+}
+
+// Send writes one event with v encoded as JSON on a single data line.
+func (s *sseWriter) Send(event string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event, b); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
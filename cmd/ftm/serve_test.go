@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pedrohavay/followthemoney/ftm"
+)
+
+const streamStatements = `{"entity_id":"p1","schema":"Person","prop":"name","value":"John Smith","dataset":"d"}
+{"entity_id":"p1","schema":"Person","prop":"nationality","value":"gb","dataset":"d"}
+{"entity_id":"p2","schema":"Person","prop":"name","value":"Maria Garcia","dataset":"d"}
+`
+
+func TestAggregateStream(t *testing.T) {
+	srv := httptest.NewServer(newServeMux(ftm.Default()))
+	defer srv.Close()
+
+	// Send the body through a pipe, so it is still being read after the
+	// stream has opened.
+	pr, pw := io.Pipe()
+	go func() {
+		for _, line := range strings.SplitAfter(streamStatements, "\n") {
+			if _, err := io.WriteString(pw, line); err != nil {
+				return
+			}
+		}
+		pw.Close()
+	}()
+	resp, err := http.Post(srv.URL+"/aggregate/stream", "application/x-ndjson", pr)
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var events, ids []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if event, ok := strings.CutPrefix(scanner.Text(), "event: "); ok {
+			events = append(events, event)
+			continue
+		}
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		switch events[len(events)-1] {
+		case "entity":
+			var e struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal([]byte(data), &e); err != nil {
+				t.Fatalf("decode %s: %v", data, err)
+			}
+			ids = append(ids, e.ID)
+		case "error":
+			t.Fatalf("stream error: %s", data)
+		}
+	}
+	if strings.Join(events, ",") != "entity,entity,done" || strings.Join(ids, ",") != "p1,p2" {
+		t.Fatalf("events %v, entities %v", events, ids)
+	}
+}
@@ -0,0 +1,140 @@
+// Package fixtures provides a small, stable set of valid entities and statements
+// for the major FtM schemata, built from the embedded model. It is intended for
+// integration tests in downstream projects.
+package fixtures
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/pedrohavay/followthemoney/ftm"
+)
+
+// Dataset is the dataset name used for fixture statements.
+const Dataset = "ftm_fixtures"
+
+// Seen is the first_seen/last_seen timestamp used for fixture statements.
+const Seen = "2024-01-01T00:00:00"
+
+// fixture is the raw definition of one entity.
+type fixture struct {
+	id     string
+	schema string
+	props  map[string][]string
+}
+
+// fixtures is ordered so that referenced entities come before relationships.
+var fixtures = []fixture{
+	{"fx-person-1", "Person", map[string][]string{
+		"name": {"Jane Doe"}, "nationality": {"gb"}, "birthDate": {"1975-04-12"},
+		"email": {"jane.doe@example.com"}, "phone": {"+442079460000"}, "topics": {"role.pep"},
+	}},
+	{"fx-person-2", "Person", map[string][]string{
+		"name": {"John Doe"}, "nationality": {"gb"}, "birthDate": {"1973-09-30"},
+	}},
+	{"fx-company-1", "Company", map[string][]string{
+		"name": {"Doe Holdings Ltd"}, "jurisdiction": {"gb"}, "registrationNumber": {"01234567"},
+		"incorporationDate": {"2001-06-01"}, "topics": {"sanction"},
+	}},
+	{"fx-organization-1", "Organization", map[string][]string{
+		"name": {"Doe Family Foundation"}, "country": {"ch"}, "legalForm": {"Foundation"},
+	}},
+	{"fx-address-1", "Address", map[string][]string{
+		"full": {"1 Example Street, London"}, "city": {"London"}, "street": {"1 Example Street"}, "country": {"gb"},
+	}},
+	{"fx-bankaccount-1", "BankAccount", map[string][]string{
+		"accountNumber": {"DE44500105175407324931"}, "iban": {"DE44500105175407324931"}, "bankName": {"Example Bank AG"},
+	}},
+	{"fx-vessel-1", "Vessel", map[string][]string{
+		"name": {"Sea Doe"}, "imoNumber": {"IMO 9074729"}, "flag": {"pa"},
+	}},
+	{"fx-passport-1", "Passport", map[string][]string{
+		"holder": {"fx-person-1"}, "number": {"123456789"}, "country": {"gb"},
+	}},
+	{"fx-ownership-1", "Ownership", map[string][]string{
+		"owner": {"fx-person-1"}, "asset": {"fx-company-1"}, "percentage": {"75"}, "startDate": {"2001-06-01"},
+	}},
+	{"fx-directorship-1", "Directorship", map[string][]string{
+		"director": {"fx-person-2"}, "organization": {"fx-company-1"}, "role": {"Director"},
+	}},
+	{"fx-family-1", "Family", map[string][]string{
+		"person": {"fx-person-1"}, "relative": {"fx-person-2"}, "relationship": {"Spouse"},
+	}},
+	{"fx-sanction-1", "Sanction", map[string][]string{
+		"entity": {"fx-company-1"}, "authority": {"Example Sanctions Authority"}, "program": {"EX-2024"},
+	}},
+	{"fx-payment-1", "Payment", map[string][]string{
+		"payer": {"fx-company-1"}, "beneficiary": {"fx-organization-1"}, "amount": {"100000"}, "date": {"2020-02-02"},
+	}},
+}
+
+// Entities returns fresh copies of all fixture entities, built against the default model.
+func Entities() []*ftm.EntityProxy {
+	out, err := EntitiesFor(ftm.Default())
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+// EntitiesFor builds the fixture entities against the given model.
+func EntitiesFor(m *ftm.Model) ([]*ftm.EntityProxy, error) {
+	out := make([]*ftm.EntityProxy, 0, len(fixtures))
+	for _, f := range fixtures {
+		schema := m.Get(f.schema)
+		if schema == nil {
+			return nil, fmt.Errorf("fixture %s: schema not found: %s", f.id, f.schema)
+		}
+		e := ftm.NewEntityProxy(schema, f.id)
+		names := make([]string, 0, len(f.props))
+		for name := range f.props {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if err := e.Add(name, f.props[name], false); err != nil {
+				return nil, fmt.Errorf("fixture %s: %w", f.id, err)
+			}
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// Entity returns the first fixture entity of the given schema, or nil.
+func Entity(schema string) *ftm.EntityProxy {
+	for _, e := range Entities() {
+		if e.Schema.Name == schema {
+			return e
+		}
+	}
+	return nil
+}
+
+// Schemata lists the schema names covered by the fixtures.
+func Schemata() []string {
+	seen := map[string]struct{}{}
+	var out []string
+	for _, f := range fixtures {
+		if _, ok := seen[f.schema]; !ok {
+			seen[f.schema] = struct{}{}
+			out = append(out, f.schema)
+		}
+	}
+	return out
+}
+
+// Statements returns the statements of all fixture entities, sorted by entity
+// ID, property and value.
+func Statements() []ftm.Statement {
+	var out []ftm.Statement
+	for _, e := range Entities() {
+		out = append(out, ftm.StatementsFromEntity(e, Dataset, Seen, Seen, false, "fixtures")...)
+	}
+	slices.SortFunc(out, func(a, b ftm.Statement) int {
+		return cmp.Or(cmp.Compare(a.EntityID, b.EntityID), cmp.Compare(a.Prop, b.Prop), cmp.Compare(a.Value, b.Value))
+	})
+	return out
+}
@@ -0,0 +1,49 @@
+package fixtures
+
+import (
+	"testing"
+
+	"github.com/pedrohavay/followthemoney/ftm"
+)
+
+func TestFixturesAreValid(t *testing.T) {
+	m, err := ftm.NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	es, err := EntitiesFor(m)
+	if err != nil {
+		t.Fatalf("EntitiesFor: %v", err)
+	}
+	for i, e := range es {
+		if errs := e.Validate(); len(errs) > 0 {
+			t.Errorf("fixture %s invalid: %v", e.ID, errs)
+		}
+		for name, vals := range fixtures[i].props {
+			if len(e.Get(name)) != len(vals) {
+				t.Errorf("fixture %s: property %s lost values in cleaning: %v", e.ID, name, e.Get(name))
+			}
+		}
+	}
+}
+
+func TestStatementsSorted(t *testing.T) {
+	st := Statements()
+	if len(st) == 0 {
+		t.Fatalf("no statements")
+	}
+	for i := 1; i < len(st); i++ {
+		a, b := st[i-1], st[i]
+		key := func(s ftm.Statement) [3]string { return [3]string{s.EntityID, s.Prop, s.Value} }
+		ka, kb := key(a), key(b)
+		if ka[0] > kb[0] || ka[0] == kb[0] && (ka[1] > kb[1] || ka[1] == kb[1] && ka[2] > kb[2]) {
+			t.Fatalf("statements out of order at %d: %v before %v", i, ka, kb)
+		}
+	}
+	again := Statements()
+	for i := range st {
+		if st[i].ID != again[i].ID {
+			t.Fatalf("statement order is not stable at %d", i)
+		}
+	}
+}
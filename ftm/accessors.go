@@ -0,0 +1,126 @@
+package ftm
+
+//go:generate go run ../cmd/ftm accessors -package ftm -out accessors_gen.go LegalEntity Person Organization Company Address Ownership Directorship Sanction
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// AccessorOptions configures WriteAccessors.
+type AccessorOptions struct {
+	// Package is the name of the generated package. Outside package ftm, the
+	// generated code imports and qualifies this package.
+	Package string
+	// Schemata lists the schemata to generate wrappers for.
+	Schemata []string
+}
+
+// WriteAccessors generates typed wrappers around EntityProxy for the given
+// schemata, e.g. a PersonProxy with BirthDate, AddBirthDate and SetBirthDate
+// methods, so the most common schemata can be used with compile-time checked
+// property names. The wrappers embed *EntityProxy, which remains available as
+// the dynamic fallback. A property whose method names would collide with an
+// EntityProxy member gets a "Prop" suffix (e.g. CaptionProp).
+func WriteAccessors(w io.Writer, m *Model, opts AccessorOptions) error {
+	if opts.Package == "" {
+		opts.Package = "ftm"
+	}
+	q := "ftm."
+	if opts.Package == "ftm" {
+		q = ""
+	}
+	reserved := entityProxyMembers()
+
+	var buf bytes.Buffer
+	p := func(format string, args ...any) { fmt.Fprintf(&buf, format, args...) }
+	p("// Code generated by ftm accessors. DO NOT EDIT.\n\n")
+	p("package %s\n\n", opts.Package)
+	if q != "" {
+		p("import ftm \"github.com/pedrohavay/followthemoney/ftm\"\n\n")
+	}
+	for _, name := range opts.Schemata {
+		s := m.Get(name)
+		if s == nil {
+			return fmt.Errorf("unknown schema: %s", name)
+		}
+		typ := name + "Proxy"
+		p("// %s is a typed wrapper of an entity of schema %s (%s).\n", typ, name, s.Label)
+		p("type %s struct{ *%sEntityProxy }\n\n", typ, q)
+		if !s.Abstract {
+			p("// New%s creates an empty %s entity in model m.\n", name, name)
+			p("func New%s(m *%sModel, id string) %s {\n", name, q, typ)
+			p("return %s{%sNewEntityProxy(m.Get(%q), id)}\n}\n\n", typ, q, name)
+		}
+		p("// As%s wraps e if its schema is or extends %s.\n", name, name)
+		p("func As%s(e *%sEntityProxy) (%s, bool) {\n", name, q, typ)
+		p("if e == nil || e.Schema == nil || !e.Schema.IsA(%q) {\nreturn %s{}, false\n}\n", name, typ)
+		p("return %s{e}, true\n}\n", typ)
+
+		for _, prop := range s.SortedProperties() {
+			method := goIdentifier(prop.Name)
+			if reserved[method] || reserved["Add"+method] || reserved["Set"+method] {
+				method += "Prop"
+			}
+			label := strings.TrimSpace(prop.Label)
+			p("\n// %s returns the values of %s (%s).\n", method, prop.QName, label)
+			if prop.Deprecated {
+				p("//\n// Deprecated: %s is deprecated in the model.\n", prop.QName)
+			}
+			p("func (e %s) %s() []string { return e.Get(%q) }\n", typ, method, prop.Name)
+			if prop.Stub {
+				continue // reverse properties are set on the forward side
+			}
+			p("\n// Add%s adds values to %s.\n", method, prop.QName)
+			p("func (e %s) Add%s(values ...string) error { return e.Add(%q, values, false) }\n", typ, method, prop.Name)
+			p("\n// Set%s replaces the values of %s.\n", method, prop.QName)
+			p("func (e %s) Set%s(values ...string) error { return e.Set(%q, values, false) }\n", typ, method, prop.Name)
+		}
+		p("\n")
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("formatting accessors: %w", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// entityProxyMembers lists the exported fields and methods of EntityProxy,
+// which generated methods must not shadow.
+func entityProxyMembers() map[string]bool {
+	out := map[string]bool{}
+	t := reflect.TypeOf(&EntityProxy{})
+	for i := 0; i < t.NumMethod(); i++ {
+		out[t.Method(i).Name] = true
+	}
+	for i := 0; i < t.Elem().NumField(); i++ {
+		out[t.Elem().Field(i).Name] = true
+	}
+	out["EntityProxy"] = true
+	return out
+}
+
+// goIdentifier turns a property name into an exported Go identifier.
+func goIdentifier(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,4031 @@
+// Code generated by ftm accessors. DO NOT EDIT.
+
+package ftm
+
+// LegalEntityProxy is a typed wrapper of an entity of schema LegalEntity (Legal entity).
+type LegalEntityProxy struct{ *EntityProxy }
+
+// NewLegalEntity creates an empty LegalEntity entity in model m.
+func NewLegalEntity(m *Model, id string) LegalEntityProxy {
+	return LegalEntityProxy{NewEntityProxy(m.Get("LegalEntity"), id)}
+}
+
+// AsLegalEntity wraps e if its schema is or extends LegalEntity.
+func AsLegalEntity(e *EntityProxy) (LegalEntityProxy, bool) {
+	if e == nil || e.Schema == nil || !e.Schema.IsA("LegalEntity") {
+		return LegalEntityProxy{}, false
+	}
+	return LegalEntityProxy{e}, true
+}
+
+// Name returns the values of Thing:name (Name).
+func (e LegalEntityProxy) Name() []string { return e.Get("name") }
+
+// AddName adds values to Thing:name.
+func (e LegalEntityProxy) AddName(values ...string) error { return e.Add("name", values, false) }
+
+// SetName replaces the values of Thing:name.
+func (e LegalEntityProxy) SetName(values ...string) error { return e.Set("name", values, false) }
+
+// Email returns the values of LegalEntity:email (E-Mail).
+func (e LegalEntityProxy) Email() []string { return e.Get("email") }
+
+// AddEmail adds values to LegalEntity:email.
+func (e LegalEntityProxy) AddEmail(values ...string) error { return e.Add("email", values, false) }
+
+// SetEmail replaces the values of LegalEntity:email.
+func (e LegalEntityProxy) SetEmail(values ...string) error { return e.Set("email", values, false) }
+
+// Phone returns the values of LegalEntity:phone (Phone).
+func (e LegalEntityProxy) Phone() []string { return e.Get("phone") }
+
+// AddPhone adds values to LegalEntity:phone.
+func (e LegalEntityProxy) AddPhone(values ...string) error { return e.Add("phone", values, false) }
+
+// SetPhone replaces the values of LegalEntity:phone.
+func (e LegalEntityProxy) SetPhone(values ...string) error { return e.Set("phone", values, false) }
+
+// RegistrationNumber returns the values of LegalEntity:registrationNumber (Registration number).
+func (e LegalEntityProxy) RegistrationNumber() []string { return e.Get("registrationNumber") }
+
+// AddRegistrationNumber adds values to LegalEntity:registrationNumber.
+func (e LegalEntityProxy) AddRegistrationNumber(values ...string) error {
+	return e.Add("registrationNumber", values, false)
+}
+
+// SetRegistrationNumber replaces the values of LegalEntity:registrationNumber.
+func (e LegalEntityProxy) SetRegistrationNumber(values ...string) error {
+	return e.Set("registrationNumber", values, false)
+}
+
+// Country returns the values of Thing:country (Country).
+func (e LegalEntityProxy) Country() []string { return e.Get("country") }
+
+// AddCountry adds values to Thing:country.
+func (e LegalEntityProxy) AddCountry(values ...string) error { return e.Add("country", values, false) }
+
+// SetCountry replaces the values of Thing:country.
+func (e LegalEntityProxy) SetCountry(values ...string) error { return e.Set("country", values, false) }
+
+// LegalForm returns the values of LegalEntity:legalForm (Legal form).
+func (e LegalEntityProxy) LegalForm() []string { return e.Get("legalForm") }
+
+// AddLegalForm adds values to LegalEntity:legalForm.
+func (e LegalEntityProxy) AddLegalForm(values ...string) error {
+	return e.Add("legalForm", values, false)
+}
+
+// SetLegalForm replaces the values of LegalEntity:legalForm.
+func (e LegalEntityProxy) SetLegalForm(values ...string) error {
+	return e.Set("legalForm", values, false)
+}
+
+// Status returns the values of LegalEntity:status (Status).
+func (e LegalEntityProxy) Status() []string { return e.Get("status") }
+
+// AddStatus adds values to LegalEntity:status.
+func (e LegalEntityProxy) AddStatus(values ...string) error { return e.Add("status", values, false) }
+
+// SetStatus replaces the values of LegalEntity:status.
+func (e LegalEntityProxy) SetStatus(values ...string) error { return e.Set("status", values, false) }
+
+// Address returns the values of Thing:address (Address).
+func (e LegalEntityProxy) Address() []string { return e.Get("address") }
+
+// AddAddress adds values to Thing:address.
+func (e LegalEntityProxy) AddAddress(values ...string) error { return e.Add("address", values, false) }
+
+// SetAddress replaces the values of Thing:address.
+func (e LegalEntityProxy) SetAddress(values ...string) error { return e.Set("address", values, false) }
+
+// AddressEntity returns the values of Thing:addressEntity (Address).
+func (e LegalEntityProxy) AddressEntity() []string { return e.Get("addressEntity") }
+
+// AddAddressEntity adds values to Thing:addressEntity.
+func (e LegalEntityProxy) AddAddressEntity(values ...string) error {
+	return e.Add("addressEntity", values, false)
+}
+
+// SetAddressEntity replaces the values of Thing:addressEntity.
+func (e LegalEntityProxy) SetAddressEntity(values ...string) error {
+	return e.Set("addressEntity", values, false)
+}
+
+// BvdId returns the values of LegalEntity:bvdId (Bureau van Dijk ID).
+func (e LegalEntityProxy) BvdId() []string { return e.Get("bvdId") }
+
+// AddBvdId adds values to LegalEntity:bvdId.
+func (e LegalEntityProxy) AddBvdId(values ...string) error { return e.Add("bvdId", values, false) }
+
+// SetBvdId replaces the values of LegalEntity:bvdId.
+func (e LegalEntityProxy) SetBvdId(values ...string) error { return e.Set("bvdId", values, false) }
+
+// Classification returns the values of LegalEntity:classification (Classification).
+func (e LegalEntityProxy) Classification() []string { return e.Get("classification") }
+
+// AddClassification adds values to LegalEntity:classification.
+func (e LegalEntityProxy) AddClassification(values ...string) error {
+	return e.Add("classification", values, false)
+}
+
+// SetClassification replaces the values of LegalEntity:classification.
+func (e LegalEntityProxy) SetClassification(values ...string) error {
+	return e.Set("classification", values, false)
+}
+
+// MainCountry returns the values of LegalEntity:mainCountry (Country of origin).
+func (e LegalEntityProxy) MainCountry() []string { return e.Get("mainCountry") }
+
+// AddMainCountry adds values to LegalEntity:mainCountry.
+func (e LegalEntityProxy) AddMainCountry(values ...string) error {
+	return e.Add("mainCountry", values, false)
+}
+
+// SetMainCountry replaces the values of LegalEntity:mainCountry.
+func (e LegalEntityProxy) SetMainCountry(values ...string) error {
+	return e.Set("mainCountry", values, false)
+}
+
+// CreatedAt returns the values of Thing:createdAt (Created at).
+func (e LegalEntityProxy) CreatedAt() []string { return e.Get("createdAt") }
+
+// AddCreatedAt adds values to Thing:createdAt.
+func (e LegalEntityProxy) AddCreatedAt(values ...string) error {
+	return e.Add("createdAt", values, false)
+}
+
+// SetCreatedAt replaces the values of Thing:createdAt.
+func (e LegalEntityProxy) SetCreatedAt(values ...string) error {
+	return e.Set("createdAt", values, false)
+}
+
+// DunsCode returns the values of LegalEntity:dunsCode (DUNS).
+func (e LegalEntityProxy) DunsCode() []string { return e.Get("dunsCode") }
+
+// AddDunsCode adds values to LegalEntity:dunsCode.
+func (e LegalEntityProxy) AddDunsCode(values ...string) error {
+	return e.Add("dunsCode", values, false)
+}
+
+// SetDunsCode replaces the values of LegalEntity:dunsCode.
+func (e LegalEntityProxy) SetDunsCode(values ...string) error {
+	return e.Set("dunsCode", values, false)
+}
+
+// Description returns the values of Thing:description (Description).
+func (e LegalEntityProxy) Description() []string { return e.Get("description") }
+
+// AddDescription adds values to Thing:description.
+func (e LegalEntityProxy) AddDescription(values ...string) error {
+	return e.Add("description", values, false)
+}
+
+// SetDescription replaces the values of Thing:description.
+func (e LegalEntityProxy) SetDescription(values ...string) error {
+	return e.Set("description", values, false)
+}
+
+// DissolutionDate returns the values of LegalEntity:dissolutionDate (Dissolution date).
+func (e LegalEntityProxy) DissolutionDate() []string { return e.Get("dissolutionDate") }
+
+// AddDissolutionDate adds values to LegalEntity:dissolutionDate.
+func (e LegalEntityProxy) AddDissolutionDate(values ...string) error {
+	return e.Add("dissolutionDate", values, false)
+}
+
+// SetDissolutionDate replaces the values of LegalEntity:dissolutionDate.
+func (e LegalEntityProxy) SetDissolutionDate(values ...string) error {
+	return e.Set("dissolutionDate", values, false)
+}
+
+// IcijId returns the values of LegalEntity:icijId (ICIJ ID).
+func (e LegalEntityProxy) IcijId() []string { return e.Get("icijId") }
+
+// AddIcijId adds values to LegalEntity:icijId.
+func (e LegalEntityProxy) AddIcijId(values ...string) error { return e.Add("icijId", values, false) }
+
+// SetIcijId replaces the values of LegalEntity:icijId.
+func (e LegalEntityProxy) SetIcijId(values ...string) error { return e.Set("icijId", values, false) }
+
+// IdNumber returns the values of LegalEntity:idNumber (ID Number).
+func (e LegalEntityProxy) IdNumber() []string { return e.Get("idNumber") }
+
+// AddIdNumber adds values to LegalEntity:idNumber.
+func (e LegalEntityProxy) AddIdNumber(values ...string) error {
+	return e.Add("idNumber", values, false)
+}
+
+// SetIdNumber replaces the values of LegalEntity:idNumber.
+func (e LegalEntityProxy) SetIdNumber(values ...string) error {
+	return e.Set("idNumber", values, false)
+}
+
+// InnCode returns the values of LegalEntity:innCode (INN).
+func (e LegalEntityProxy) InnCode() []string { return e.Get("innCode") }
+
+// AddInnCode adds values to LegalEntity:innCode.
+func (e LegalEntityProxy) AddInnCode(values ...string) error { return e.Add("innCode", values, false) }
+
+// SetInnCode replaces the values of LegalEntity:innCode.
+func (e LegalEntityProxy) SetInnCode(values ...string) error { return e.Set("innCode", values, false) }
+
+// IncorporationDate returns the values of LegalEntity:incorporationDate (Incorporation date).
+func (e LegalEntityProxy) IncorporationDate() []string { return e.Get("incorporationDate") }
+
+// AddIncorporationDate adds values to LegalEntity:incorporationDate.
+func (e LegalEntityProxy) AddIncorporationDate(values ...string) error {
+	return e.Add("incorporationDate", values, false)
+}
+
+// SetIncorporationDate replaces the values of LegalEntity:incorporationDate.
+func (e LegalEntityProxy) SetIncorporationDate(values ...string) error {
+	return e.Set("incorporationDate", values, false)
+}
+
+// Jurisdiction returns the values of LegalEntity:jurisdiction (Jurisdiction).
+func (e LegalEntityProxy) Jurisdiction() []string { return e.Get("jurisdiction") }
+
+// AddJurisdiction adds values to LegalEntity:jurisdiction.
+func (e LegalEntityProxy) AddJurisdiction(values ...string) error {
+	return e.Add("jurisdiction", values, false)
+}
+
+// SetJurisdiction replaces the values of LegalEntity:jurisdiction.
+func (e LegalEntityProxy) SetJurisdiction(values ...string) error {
+	return e.Set("jurisdiction", values, false)
+}
+
+// Keywords returns the values of Thing:keywords (Keywords).
+func (e LegalEntityProxy) Keywords() []string { return e.Get("keywords") }
+
+// AddKeywords adds values to Thing:keywords.
+func (e LegalEntityProxy) AddKeywords(values ...string) error {
+	return e.Add("keywords", values, false)
+}
+
+// SetKeywords replaces the values of Thing:keywords.
+func (e LegalEntityProxy) SetKeywords(values ...string) error {
+	return e.Set("keywords", values, false)
+}
+
+// LeiCode returns the values of LegalEntity:leiCode (LEI).
+func (e LegalEntityProxy) LeiCode() []string { return e.Get("leiCode") }
+
+// AddLeiCode adds values to LegalEntity:leiCode.
+func (e LegalEntityProxy) AddLeiCode(values ...string) error { return e.Add("leiCode", values, false) }
+
+// SetLeiCode replaces the values of LegalEntity:leiCode.
+func (e LegalEntityProxy) SetLeiCode(values ...string) error { return e.Set("leiCode", values, false) }
+
+// LicenseNumber returns the values of LegalEntity:licenseNumber (License Number).
+func (e LegalEntityProxy) LicenseNumber() []string { return e.Get("licenseNumber") }
+
+// AddLicenseNumber adds values to LegalEntity:licenseNumber.
+func (e LegalEntityProxy) AddLicenseNumber(values ...string) error {
+	return e.Add("licenseNumber", values, false)
+}
+
+// SetLicenseNumber replaces the values of LegalEntity:licenseNumber.
+func (e LegalEntityProxy) SetLicenseNumber(values ...string) error {
+	return e.Set("licenseNumber", values, false)
+}
+
+// ModifiedAt returns the values of Thing:modifiedAt (Modified on).
+func (e LegalEntityProxy) ModifiedAt() []string { return e.Get("modifiedAt") }
+
+// AddModifiedAt adds values to Thing:modifiedAt.
+func (e LegalEntityProxy) AddModifiedAt(values ...string) error {
+	return e.Add("modifiedAt", values, false)
+}
+
+// SetModifiedAt replaces the values of Thing:modifiedAt.
+func (e LegalEntityProxy) SetModifiedAt(values ...string) error {
+	return e.Set("modifiedAt", values, false)
+}
+
+// NpiCode returns the values of LegalEntity:npiCode (NPI).
+func (e LegalEntityProxy) NpiCode() []string { return e.Get("npiCode") }
+
+// AddNpiCode adds values to LegalEntity:npiCode.
+func (e LegalEntityProxy) AddNpiCode(values ...string) error { return e.Add("npiCode", values, false) }
+
+// SetNpiCode replaces the values of LegalEntity:npiCode.
+func (e LegalEntityProxy) SetNpiCode(values ...string) error { return e.Set("npiCode", values, false) }
+
+// Notes returns the values of Thing:notes (Notes).
+func (e LegalEntityProxy) Notes() []string { return e.Get("notes") }
+
+// AddNotes adds values to Thing:notes.
+func (e LegalEntityProxy) AddNotes(values ...string) error { return e.Add("notes", values, false) }
+
+// SetNotes replaces the values of Thing:notes.
+func (e LegalEntityProxy) SetNotes(values ...string) error { return e.Set("notes", values, false) }
+
+// OgrnCode returns the values of LegalEntity:ogrnCode (OGRN).
+func (e LegalEntityProxy) OgrnCode() []string { return e.Get("ogrnCode") }
+
+// AddOgrnCode adds values to LegalEntity:ogrnCode.
+func (e LegalEntityProxy) AddOgrnCode(values ...string) error {
+	return e.Add("ogrnCode", values, false)
+}
+
+// SetOgrnCode replaces the values of LegalEntity:ogrnCode.
+func (e LegalEntityProxy) SetOgrnCode(values ...string) error {
+	return e.Set("ogrnCode", values, false)
+}
+
+// OkpoCode returns the values of LegalEntity:okpoCode (OKPO).
+func (e LegalEntityProxy) OkpoCode() []string { return e.Get("okpoCode") }
+
+// AddOkpoCode adds values to LegalEntity:okpoCode.
+func (e LegalEntityProxy) AddOkpoCode(values ...string) error {
+	return e.Add("okpoCode", values, false)
+}
+
+// SetOkpoCode replaces the values of LegalEntity:okpoCode.
+func (e LegalEntityProxy) SetOkpoCode(values ...string) error {
+	return e.Set("okpoCode", values, false)
+}
+
+// OpencorporatesUrl returns the values of LegalEntity:opencorporatesUrl (OpenCorporates URL).
+func (e LegalEntityProxy) OpencorporatesUrl() []string { return e.Get("opencorporatesUrl") }
+
+// AddOpencorporatesUrl adds values to LegalEntity:opencorporatesUrl.
+func (e LegalEntityProxy) AddOpencorporatesUrl(values ...string) error {
+	return e.Add("opencorporatesUrl", values, false)
+}
+
+// SetOpencorporatesUrl replaces the values of LegalEntity:opencorporatesUrl.
+func (e LegalEntityProxy) SetOpencorporatesUrl(values ...string) error {
+	return e.Set("opencorporatesUrl", values, false)
+}
+
+// Alias returns the values of Thing:alias (Other name).
+func (e LegalEntityProxy) Alias() []string { return e.Get("alias") }
+
+// AddAlias adds values to Thing:alias.
+func (e LegalEntityProxy) AddAlias(values ...string) error { return e.Add("alias", values, false) }
+
+// SetAlias replaces the values of Thing:alias.
+func (e LegalEntityProxy) SetAlias(values ...string) error { return e.Set("alias", values, false) }
+
+// Parent returns the values of LegalEntity:parent (Parent company).
+//
+// Deprecated: LegalEntity:parent is deprecated in the model.
+func (e LegalEntityProxy) Parent() []string { return e.Get("parent") }
+
+// AddParent adds values to LegalEntity:parent.
+func (e LegalEntityProxy) AddParent(values ...string) error { return e.Add("parent", values, false) }
+
+// SetParent replaces the values of LegalEntity:parent.
+func (e LegalEntityProxy) SetParent(values ...string) error { return e.Set("parent", values, false) }
+
+// PreviousName returns the values of Thing:previousName (Previous name).
+func (e LegalEntityProxy) PreviousName() []string { return e.Get("previousName") }
+
+// AddPreviousName adds values to Thing:previousName.
+func (e LegalEntityProxy) AddPreviousName(values ...string) error {
+	return e.Add("previousName", values, false)
+}
+
+// SetPreviousName replaces the values of Thing:previousName.
+func (e LegalEntityProxy) SetPreviousName(values ...string) error {
+	return e.Set("previousName", values, false)
+}
+
+// Program returns the values of Thing:program (Program).
+func (e LegalEntityProxy) Program() []string { return e.Get("program") }
+
+// AddProgram adds values to Thing:program.
+func (e LegalEntityProxy) AddProgram(values ...string) error { return e.Add("program", values, false) }
+
+// SetProgram replaces the values of Thing:program.
+func (e LegalEntityProxy) SetProgram(values ...string) error { return e.Set("program", values, false) }
+
+// Publisher returns the values of Thing:publisher (Publishing source).
+func (e LegalEntityProxy) Publisher() []string { return e.Get("publisher") }
+
+// AddPublisher adds values to Thing:publisher.
+func (e LegalEntityProxy) AddPublisher(values ...string) error {
+	return e.Add("publisher", values, false)
+}
+
+// SetPublisher replaces the values of Thing:publisher.
+func (e LegalEntityProxy) SetPublisher(values ...string) error {
+	return e.Set("publisher", values, false)
+}
+
+// PublisherUrl returns the values of Thing:publisherUrl (Publishing source URL).
+func (e LegalEntityProxy) PublisherUrl() []string { return e.Get("publisherUrl") }
+
+// AddPublisherUrl adds values to Thing:publisherUrl.
+func (e LegalEntityProxy) AddPublisherUrl(values ...string) error {
+	return e.Add("publisherUrl", values, false)
+}
+
+// SetPublisherUrl replaces the values of Thing:publisherUrl.
+func (e LegalEntityProxy) SetPublisherUrl(values ...string) error {
+	return e.Set("publisherUrl", values, false)
+}
+
+// RetrievedAt returns the values of Thing:retrievedAt (Retrieved on).
+func (e LegalEntityProxy) RetrievedAt() []string { return e.Get("retrievedAt") }
+
+// AddRetrievedAt adds values to Thing:retrievedAt.
+func (e LegalEntityProxy) AddRetrievedAt(values ...string) error {
+	return e.Add("retrievedAt", values, false)
+}
+
+// SetRetrievedAt replaces the values of Thing:retrievedAt.
+func (e LegalEntityProxy) SetRetrievedAt(values ...string) error {
+	return e.Set("retrievedAt", values, false)
+}
+
+// SwiftBic returns the values of LegalEntity:swiftBic (SWIFT/BIC).
+func (e LegalEntityProxy) SwiftBic() []string { return e.Get("swiftBic") }
+
+// AddSwiftBic adds values to LegalEntity:swiftBic.
+func (e LegalEntityProxy) AddSwiftBic(values ...string) error {
+	return e.Add("swiftBic", values, false)
+}
+
+// SetSwiftBic replaces the values of LegalEntity:swiftBic.
+func (e LegalEntityProxy) SetSwiftBic(values ...string) error {
+	return e.Set("swiftBic", values, false)
+}
+
+// Sector returns the values of LegalEntity:sector (Sector).
+func (e LegalEntityProxy) Sector() []string { return e.Get("sector") }
+
+// AddSector adds values to LegalEntity:sector.
+func (e LegalEntityProxy) AddSector(values ...string) error { return e.Add("sector", values, false) }
+
+// SetSector replaces the values of LegalEntity:sector.
+func (e LegalEntityProxy) SetSector(values ...string) error { return e.Set("sector", values, false) }
+
+// Proof returns the values of Thing:proof (Source document).
+func (e LegalEntityProxy) Proof() []string { return e.Get("proof") }
+
+// AddProof adds values to Thing:proof.
+func (e LegalEntityProxy) AddProof(values ...string) error { return e.Add("proof", values, false) }
+
+// SetProof replaces the values of Thing:proof.
+func (e LegalEntityProxy) SetProof(values ...string) error { return e.Set("proof", values, false) }
+
+// SourceUrl returns the values of Thing:sourceUrl (Source link).
+func (e LegalEntityProxy) SourceUrl() []string { return e.Get("sourceUrl") }
+
+// AddSourceUrl adds values to Thing:sourceUrl.
+func (e LegalEntityProxy) AddSourceUrl(values ...string) error {
+	return e.Add("sourceUrl", values, false)
+}
+
+// SetSourceUrl replaces the values of Thing:sourceUrl.
+func (e LegalEntityProxy) SetSourceUrl(values ...string) error {
+	return e.Set("sourceUrl", values, false)
+}
+
+// Summary returns the values of Thing:summary (Summary).
+func (e LegalEntityProxy) Summary() []string { return e.Get("summary") }
+
+// AddSummary adds values to Thing:summary.
+func (e LegalEntityProxy) AddSummary(values ...string) error { return e.Add("summary", values, false) }
+
+// SetSummary replaces the values of Thing:summary.
+func (e LegalEntityProxy) SetSummary(values ...string) error { return e.Set("summary", values, false) }
+
+// TaxNumber returns the values of LegalEntity:taxNumber (Tax Number).
+func (e LegalEntityProxy) TaxNumber() []string { return e.Get("taxNumber") }
+
+// AddTaxNumber adds values to LegalEntity:taxNumber.
+func (e LegalEntityProxy) AddTaxNumber(values ...string) error {
+	return e.Add("taxNumber", values, false)
+}
+
+// SetTaxNumber replaces the values of LegalEntity:taxNumber.
+func (e LegalEntityProxy) SetTaxNumber(values ...string) error {
+	return e.Set("taxNumber", values, false)
+}
+
+// TaxStatus returns the values of LegalEntity:taxStatus (Tax status).
+func (e LegalEntityProxy) TaxStatus() []string { return e.Get("taxStatus") }
+
+// AddTaxStatus adds values to LegalEntity:taxStatus.
+func (e LegalEntityProxy) AddTaxStatus(values ...string) error {
+	return e.Add("taxStatus", values, false)
+}
+
+// SetTaxStatus replaces the values of LegalEntity:taxStatus.
+func (e LegalEntityProxy) SetTaxStatus(values ...string) error {
+	return e.Set("taxStatus", values, false)
+}
+
+// Topics returns the values of Thing:topics (Topics).
+func (e LegalEntityProxy) Topics() []string { return e.Get("topics") }
+
+// AddTopics adds values to Thing:topics.
+func (e LegalEntityProxy) AddTopics(values ...string) error { return e.Add("topics", values, false) }
+
+// SetTopics replaces the values of Thing:topics.
+func (e LegalEntityProxy) SetTopics(values ...string) error { return e.Set("topics", values, false) }
+
+// UscCode returns the values of LegalEntity:uscCode (USCC).
+func (e LegalEntityProxy) UscCode() []string { return e.Get("uscCode") }
+
+// AddUscCode adds values to LegalEntity:uscCode.
+func (e LegalEntityProxy) AddUscCode(values ...string) error { return e.Add("uscCode", values, false) }
+
+// SetUscCode replaces the values of LegalEntity:uscCode.
+func (e LegalEntityProxy) SetUscCode(values ...string) error { return e.Set("uscCode", values, false) }
+
+// UniqueEntityId returns the values of LegalEntity:uniqueEntityId (Unique Entity ID).
+func (e LegalEntityProxy) UniqueEntityId() []string { return e.Get("uniqueEntityId") }
+
+// AddUniqueEntityId adds values to LegalEntity:uniqueEntityId.
+func (e LegalEntityProxy) AddUniqueEntityId(values ...string) error {
+	return e.Add("uniqueEntityId", values, false)
+}
+
+// SetUniqueEntityId replaces the values of LegalEntity:uniqueEntityId.
+func (e LegalEntityProxy) SetUniqueEntityId(values ...string) error {
+	return e.Set("uniqueEntityId", values, false)
+}
+
+// VatCode returns the values of LegalEntity:vatCode (V.A.T. Identifier).
+func (e LegalEntityProxy) VatCode() []string { return e.Get("vatCode") }
+
+// AddVatCode adds values to LegalEntity:vatCode.
+func (e LegalEntityProxy) AddVatCode(values ...string) error { return e.Add("vatCode", values, false) }
+
+// SetVatCode replaces the values of LegalEntity:vatCode.
+func (e LegalEntityProxy) SetVatCode(values ...string) error { return e.Set("vatCode", values, false) }
+
+// WeakAlias returns the values of Thing:weakAlias (Weak alias).
+func (e LegalEntityProxy) WeakAlias() []string { return e.Get("weakAlias") }
+
+// AddWeakAlias adds values to Thing:weakAlias.
+func (e LegalEntityProxy) AddWeakAlias(values ...string) error {
+	return e.Add("weakAlias", values, false)
+}
+
+// SetWeakAlias replaces the values of Thing:weakAlias.
+func (e LegalEntityProxy) SetWeakAlias(values ...string) error {
+	return e.Set("weakAlias", values, false)
+}
+
+// Website returns the values of LegalEntity:website (Website).
+func (e LegalEntityProxy) Website() []string { return e.Get("website") }
+
+// AddWebsite adds values to LegalEntity:website.
+func (e LegalEntityProxy) AddWebsite(values ...string) error { return e.Add("website", values, false) }
+
+// SetWebsite replaces the values of LegalEntity:website.
+func (e LegalEntityProxy) SetWebsite(values ...string) error { return e.Set("website", values, false) }
+
+// WikidataId returns the values of Thing:wikidataId (Wikidata ID).
+func (e LegalEntityProxy) WikidataId() []string { return e.Get("wikidataId") }
+
+// AddWikidataId adds values to Thing:wikidataId.
+func (e LegalEntityProxy) AddWikidataId(values ...string) error {
+	return e.Add("wikidataId", values, false)
+}
+
+// SetWikidataId replaces the values of Thing:wikidataId.
+func (e LegalEntityProxy) SetWikidataId(values ...string) error {
+	return e.Set("wikidataId", values, false)
+}
+
+// WikipediaUrl returns the values of Thing:wikipediaUrl (Wikipedia Article).
+func (e LegalEntityProxy) WikipediaUrl() []string { return e.Get("wikipediaUrl") }
+
+// AddWikipediaUrl adds values to Thing:wikipediaUrl.
+func (e LegalEntityProxy) AddWikipediaUrl(values ...string) error {
+	return e.Add("wikipediaUrl", values, false)
+}
+
+// SetWikipediaUrl replaces the values of Thing:wikipediaUrl.
+func (e LegalEntityProxy) SetWikipediaUrl(values ...string) error {
+	return e.Set("wikipediaUrl", values, false)
+}
+
+// PersonProxy is a typed wrapper of an entity of schema Person (Person).
+type PersonProxy struct{ *EntityProxy }
+
+// NewPerson creates an empty Person entity in model m.
+func NewPerson(m *Model, id string) PersonProxy {
+	return PersonProxy{NewEntityProxy(m.Get("Person"), id)}
+}
+
+// AsPerson wraps e if its schema is or extends Person.
+func AsPerson(e *EntityProxy) (PersonProxy, bool) {
+	if e == nil || e.Schema == nil || !e.Schema.IsA("Person") {
+		return PersonProxy{}, false
+	}
+	return PersonProxy{e}, true
+}
+
+// Name returns the values of Thing:name (Name).
+func (e PersonProxy) Name() []string { return e.Get("name") }
+
+// AddName adds values to Thing:name.
+func (e PersonProxy) AddName(values ...string) error { return e.Add("name", values, false) }
+
+// SetName replaces the values of Thing:name.
+func (e PersonProxy) SetName(values ...string) error { return e.Set("name", values, false) }
+
+// LastName returns the values of Person:lastName (Last name).
+func (e PersonProxy) LastName() []string { return e.Get("lastName") }
+
+// AddLastName adds values to Person:lastName.
+func (e PersonProxy) AddLastName(values ...string) error { return e.Add("lastName", values, false) }
+
+// SetLastName replaces the values of Person:lastName.
+func (e PersonProxy) SetLastName(values ...string) error { return e.Set("lastName", values, false) }
+
+// Email returns the values of LegalEntity:email (E-Mail).
+func (e PersonProxy) Email() []string { return e.Get("email") }
+
+// AddEmail adds values to LegalEntity:email.
+func (e PersonProxy) AddEmail(values ...string) error { return e.Add("email", values, false) }
+
+// SetEmail replaces the values of LegalEntity:email.
+func (e PersonProxy) SetEmail(values ...string) error { return e.Set("email", values, false) }
+
+// Phone returns the values of LegalEntity:phone (Phone).
+func (e PersonProxy) Phone() []string { return e.Get("phone") }
+
+// AddPhone adds values to LegalEntity:phone.
+func (e PersonProxy) AddPhone(values ...string) error { return e.Add("phone", values, false) }
+
+// SetPhone replaces the values of LegalEntity:phone.
+func (e PersonProxy) SetPhone(values ...string) error { return e.Set("phone", values, false) }
+
+// Nationality returns the values of Person:nationality (Nationality).
+func (e PersonProxy) Nationality() []string { return e.Get("nationality") }
+
+// AddNationality adds values to Person:nationality.
+func (e PersonProxy) AddNationality(values ...string) error {
+	return e.Add("nationality", values, false)
+}
+
+// SetNationality replaces the values of Person:nationality.
+func (e PersonProxy) SetNationality(values ...string) error {
+	return e.Set("nationality", values, false)
+}
+
+// BirthDate returns the values of Person:birthDate (Birth date).
+func (e PersonProxy) BirthDate() []string { return e.Get("birthDate") }
+
+// AddBirthDate adds values to Person:birthDate.
+func (e PersonProxy) AddBirthDate(values ...string) error { return e.Add("birthDate", values, false) }
+
+// SetBirthDate replaces the values of Person:birthDate.
+func (e PersonProxy) SetBirthDate(values ...string) error { return e.Set("birthDate", values, false) }
+
+// Address returns the values of Thing:address (Address).
+func (e PersonProxy) Address() []string { return e.Get("address") }
+
+// AddAddress adds values to Thing:address.
+func (e PersonProxy) AddAddress(values ...string) error { return e.Add("address", values, false) }
+
+// SetAddress replaces the values of Thing:address.
+func (e PersonProxy) SetAddress(values ...string) error { return e.Set("address", values, false) }
+
+// AddressEntity returns the values of Thing:addressEntity (Address).
+func (e PersonProxy) AddressEntity() []string { return e.Get("addressEntity") }
+
+// AddAddressEntity adds values to Thing:addressEntity.
+func (e PersonProxy) AddAddressEntity(values ...string) error {
+	return e.Add("addressEntity", values, false)
+}
+
+// SetAddressEntity replaces the values of Thing:addressEntity.
+func (e PersonProxy) SetAddressEntity(values ...string) error {
+	return e.Set("addressEntity", values, false)
+}
+
+// BvdId returns the values of LegalEntity:bvdId (Bureau van Dijk ID).
+func (e PersonProxy) BvdId() []string { return e.Get("bvdId") }
+
+// AddBvdId adds values to LegalEntity:bvdId.
+func (e PersonProxy) AddBvdId(values ...string) error { return e.Add("bvdId", values, false) }
+
+// SetBvdId replaces the values of LegalEntity:bvdId.
+func (e PersonProxy) SetBvdId(values ...string) error { return e.Set("bvdId", values, false) }
+
+// Citizenship returns the values of Person:citizenship (Citizenship).
+func (e PersonProxy) Citizenship() []string { return e.Get("citizenship") }
+
+// AddCitizenship adds values to Person:citizenship.
+func (e PersonProxy) AddCitizenship(values ...string) error {
+	return e.Add("citizenship", values, false)
+}
+
+// SetCitizenship replaces the values of Person:citizenship.
+func (e PersonProxy) SetCitizenship(values ...string) error {
+	return e.Set("citizenship", values, false)
+}
+
+// Classification returns the values of LegalEntity:classification (Classification).
+func (e PersonProxy) Classification() []string { return e.Get("classification") }
+
+// AddClassification adds values to LegalEntity:classification.
+func (e PersonProxy) AddClassification(values ...string) error {
+	return e.Add("classification", values, false)
+}
+
+// SetClassification replaces the values of LegalEntity:classification.
+func (e PersonProxy) SetClassification(values ...string) error {
+	return e.Set("classification", values, false)
+}
+
+// Country returns the values of Thing:country (Country).
+func (e PersonProxy) Country() []string { return e.Get("country") }
+
+// AddCountry adds values to Thing:country.
+func (e PersonProxy) AddCountry(values ...string) error { return e.Add("country", values, false) }
+
+// SetCountry replaces the values of Thing:country.
+func (e PersonProxy) SetCountry(values ...string) error { return e.Set("country", values, false) }
+
+// BirthCountry returns the values of Person:birthCountry (Country of birth).
+func (e PersonProxy) BirthCountry() []string { return e.Get("birthCountry") }
+
+// AddBirthCountry adds values to Person:birthCountry.
+func (e PersonProxy) AddBirthCountry(values ...string) error {
+	return e.Add("birthCountry", values, false)
+}
+
+// SetBirthCountry replaces the values of Person:birthCountry.
+func (e PersonProxy) SetBirthCountry(values ...string) error {
+	return e.Set("birthCountry", values, false)
+}
+
+// MainCountry returns the values of LegalEntity:mainCountry (Country of origin).
+func (e PersonProxy) MainCountry() []string { return e.Get("mainCountry") }
+
+// AddMainCountry adds values to LegalEntity:mainCountry.
+func (e PersonProxy) AddMainCountry(values ...string) error {
+	return e.Add("mainCountry", values, false)
+}
+
+// SetMainCountry replaces the values of LegalEntity:mainCountry.
+func (e PersonProxy) SetMainCountry(values ...string) error {
+	return e.Set("mainCountry", values, false)
+}
+
+// CreatedAt returns the values of Thing:createdAt (Created at).
+func (e PersonProxy) CreatedAt() []string { return e.Get("createdAt") }
+
+// AddCreatedAt adds values to Thing:createdAt.
+func (e PersonProxy) AddCreatedAt(values ...string) error { return e.Add("createdAt", values, false) }
+
+// SetCreatedAt replaces the values of Thing:createdAt.
+func (e PersonProxy) SetCreatedAt(values ...string) error { return e.Set("createdAt", values, false) }
+
+// DunsCode returns the values of LegalEntity:dunsCode (DUNS).
+func (e PersonProxy) DunsCode() []string { return e.Get("dunsCode") }
+
+// AddDunsCode adds values to LegalEntity:dunsCode.
+func (e PersonProxy) AddDunsCode(values ...string) error { return e.Add("dunsCode", values, false) }
+
+// SetDunsCode replaces the values of LegalEntity:dunsCode.
+func (e PersonProxy) SetDunsCode(values ...string) error { return e.Set("dunsCode", values, false) }
+
+// DeathDate returns the values of Person:deathDate (Death date).
+func (e PersonProxy) DeathDate() []string { return e.Get("deathDate") }
+
+// AddDeathDate adds values to Person:deathDate.
+func (e PersonProxy) AddDeathDate(values ...string) error { return e.Add("deathDate", values, false) }
+
+// SetDeathDate replaces the values of Person:deathDate.
+func (e PersonProxy) SetDeathDate(values ...string) error { return e.Set("deathDate", values, false) }
+
+// Description returns the values of Thing:description (Description).
+func (e PersonProxy) Description() []string { return e.Get("description") }
+
+// AddDescription adds values to Thing:description.
+func (e PersonProxy) AddDescription(values ...string) error {
+	return e.Add("description", values, false)
+}
+
+// SetDescription replaces the values of Thing:description.
+func (e PersonProxy) SetDescription(values ...string) error {
+	return e.Set("description", values, false)
+}
+
+// DissolutionDate returns the values of LegalEntity:dissolutionDate (Dissolution date).
+func (e PersonProxy) DissolutionDate() []string { return e.Get("dissolutionDate") }
+
+// AddDissolutionDate adds values to LegalEntity:dissolutionDate.
+func (e PersonProxy) AddDissolutionDate(values ...string) error {
+	return e.Add("dissolutionDate", values, false)
+}
+
+// SetDissolutionDate replaces the values of LegalEntity:dissolutionDate.
+func (e PersonProxy) SetDissolutionDate(values ...string) error {
+	return e.Set("dissolutionDate", values, false)
+}
+
+// Education returns the values of Person:education (Education).
+func (e PersonProxy) Education() []string { return e.Get("education") }
+
+// AddEducation adds values to Person:education.
+func (e PersonProxy) AddEducation(values ...string) error { return e.Add("education", values, false) }
+
+// SetEducation replaces the values of Person:education.
+func (e PersonProxy) SetEducation(values ...string) error { return e.Set("education", values, false) }
+
+// Ethnicity returns the values of Person:ethnicity (Ethnicity).
+func (e PersonProxy) Ethnicity() []string { return e.Get("ethnicity") }
+
+// AddEthnicity adds values to Person:ethnicity.
+func (e PersonProxy) AddEthnicity(values ...string) error { return e.Add("ethnicity", values, false) }
+
+// SetEthnicity replaces the values of Person:ethnicity.
+func (e PersonProxy) SetEthnicity(values ...string) error { return e.Set("ethnicity", values, false) }
+
+// EyeColor returns the values of Person:eyeColor (Eye color).
+func (e PersonProxy) EyeColor() []string { return e.Get("eyeColor") }
+
+// AddEyeColor adds values to Person:eyeColor.
+func (e PersonProxy) AddEyeColor(values ...string) error { return e.Add("eyeColor", values, false) }
+
+// SetEyeColor replaces the values of Person:eyeColor.
+func (e PersonProxy) SetEyeColor(values ...string) error { return e.Set("eyeColor", values, false) }
+
+// FirstName returns the values of Person:firstName (First name).
+func (e PersonProxy) FirstName() []string { return e.Get("firstName") }
+
+// AddFirstName adds values to Person:firstName.
+func (e PersonProxy) AddFirstName(values ...string) error { return e.Add("firstName", values, false) }
+
+// SetFirstName replaces the values of Person:firstName.
+func (e PersonProxy) SetFirstName(values ...string) error { return e.Set("firstName", values, false) }
+
+// Gender returns the values of Person:gender (Gender).
+func (e PersonProxy) Gender() []string { return e.Get("gender") }
+
+// AddGender adds values to Person:gender.
+func (e PersonProxy) AddGender(values ...string) error { return e.Add("gender", values, false) }
+
+// SetGender replaces the values of Person:gender.
+func (e PersonProxy) SetGender(values ...string) error { return e.Set("gender", values, false) }
+
+// HairColor returns the values of Person:hairColor (Hair color).
+func (e PersonProxy) HairColor() []string { return e.Get("hairColor") }
+
+// AddHairColor adds values to Person:hairColor.
+func (e PersonProxy) AddHairColor(values ...string) error { return e.Add("hairColor", values, false) }
+
+// SetHairColor replaces the values of Person:hairColor.
+func (e PersonProxy) SetHairColor(values ...string) error { return e.Set("hairColor", values, false) }
+
+// Height returns the values of Person:height (Height).
+func (e PersonProxy) Height() []string { return e.Get("height") }
+
+// AddHeight adds values to Person:height.
+func (e PersonProxy) AddHeight(values ...string) error { return e.Add("height", values, false) }
+
+// SetHeight replaces the values of Person:height.
+func (e PersonProxy) SetHeight(values ...string) error { return e.Set("height", values, false) }
+
+// IcijId returns the values of LegalEntity:icijId (ICIJ ID).
+func (e PersonProxy) IcijId() []string { return e.Get("icijId") }
+
+// AddIcijId adds values to LegalEntity:icijId.
+func (e PersonProxy) AddIcijId(values ...string) error { return e.Add("icijId", values, false) }
+
+// SetIcijId replaces the values of LegalEntity:icijId.
+func (e PersonProxy) SetIcijId(values ...string) error { return e.Set("icijId", values, false) }
+
+// IdNumber returns the values of LegalEntity:idNumber (ID Number).
+func (e PersonProxy) IdNumber() []string { return e.Get("idNumber") }
+
+// AddIdNumber adds values to LegalEntity:idNumber.
+func (e PersonProxy) AddIdNumber(values ...string) error { return e.Add("idNumber", values, false) }
+
+// SetIdNumber replaces the values of LegalEntity:idNumber.
+func (e PersonProxy) SetIdNumber(values ...string) error { return e.Set("idNumber", values, false) }
+
+// InnCode returns the values of LegalEntity:innCode (INN).
+func (e PersonProxy) InnCode() []string { return e.Get("innCode") }
+
+// AddInnCode adds values to LegalEntity:innCode.
+func (e PersonProxy) AddInnCode(values ...string) error { return e.Add("innCode", values, false) }
+
+// SetInnCode replaces the values of LegalEntity:innCode.
+func (e PersonProxy) SetInnCode(values ...string) error { return e.Set("innCode", values, false) }
+
+// IncorporationDate returns the values of LegalEntity:incorporationDate (Incorporation date).
+func (e PersonProxy) IncorporationDate() []string { return e.Get("incorporationDate") }
+
+// AddIncorporationDate adds values to LegalEntity:incorporationDate.
+func (e PersonProxy) AddIncorporationDate(values ...string) error {
+	return e.Add("incorporationDate", values, false)
+}
+
+// SetIncorporationDate replaces the values of LegalEntity:incorporationDate.
+func (e PersonProxy) SetIncorporationDate(values ...string) error {
+	return e.Set("incorporationDate", values, false)
+}
+
+// Jurisdiction returns the values of LegalEntity:jurisdiction (Jurisdiction).
+func (e PersonProxy) Jurisdiction() []string { return e.Get("jurisdiction") }
+
+// AddJurisdiction adds values to LegalEntity:jurisdiction.
+func (e PersonProxy) AddJurisdiction(values ...string) error {
+	return e.Add("jurisdiction", values, false)
+}
+
+// SetJurisdiction replaces the values of LegalEntity:jurisdiction.
+func (e PersonProxy) SetJurisdiction(values ...string) error {
+	return e.Set("jurisdiction", values, false)
+}
+
+// Keywords returns the values of Thing:keywords (Keywords).
+func (e PersonProxy) Keywords() []string { return e.Get("keywords") }
+
+// AddKeywords adds values to Thing:keywords.
+func (e PersonProxy) AddKeywords(values ...string) error { return e.Add("keywords", values, false) }
+
+// SetKeywords replaces the values of Thing:keywords.
+func (e PersonProxy) SetKeywords(values ...string) error { return e.Set("keywords", values, false) }
+
+// LeiCode returns the values of LegalEntity:leiCode (LEI).
+func (e PersonProxy) LeiCode() []string { return e.Get("leiCode") }
+
+// AddLeiCode adds values to LegalEntity:leiCode.
+func (e PersonProxy) AddLeiCode(values ...string) error { return e.Add("leiCode", values, false) }
+
+// SetLeiCode replaces the values of LegalEntity:leiCode.
+func (e PersonProxy) SetLeiCode(values ...string) error { return e.Set("leiCode", values, false) }
+
+// LegalForm returns the values of LegalEntity:legalForm (Legal form).
+func (e PersonProxy) LegalForm() []string { return e.Get("legalForm") }
+
+// AddLegalForm adds values to LegalEntity:legalForm.
+func (e PersonProxy) AddLegalForm(values ...string) error { return e.Add("legalForm", values, false) }
+
+// SetLegalForm replaces the values of LegalEntity:legalForm.
+func (e PersonProxy) SetLegalForm(values ...string) error { return e.Set("legalForm", values, false) }
+
+// LicenseNumber returns the values of LegalEntity:licenseNumber (License Number).
+func (e PersonProxy) LicenseNumber() []string { return e.Get("licenseNumber") }
+
+// AddLicenseNumber adds values to LegalEntity:licenseNumber.
+func (e PersonProxy) AddLicenseNumber(values ...string) error {
+	return e.Add("licenseNumber", values, false)
+}
+
+// SetLicenseNumber replaces the values of LegalEntity:licenseNumber.
+func (e PersonProxy) SetLicenseNumber(values ...string) error {
+	return e.Set("licenseNumber", values, false)
+}
+
+// MotherName returns the values of Person:motherName (Matronymic).
+func (e PersonProxy) MotherName() []string { return e.Get("motherName") }
+
+// AddMotherName adds values to Person:motherName.
+func (e PersonProxy) AddMotherName(values ...string) error { return e.Add("motherName", values, false) }
+
+// SetMotherName replaces the values of Person:motherName.
+func (e PersonProxy) SetMotherName(values ...string) error { return e.Set("motherName", values, false) }
+
+// MiddleName returns the values of Person:middleName (Middle name).
+func (e PersonProxy) MiddleName() []string { return e.Get("middleName") }
+
+// AddMiddleName adds values to Person:middleName.
+func (e PersonProxy) AddMiddleName(values ...string) error { return e.Add("middleName", values, false) }
+
+// SetMiddleName replaces the values of Person:middleName.
+func (e PersonProxy) SetMiddleName(values ...string) error { return e.Set("middleName", values, false) }
+
+// ModifiedAt returns the values of Thing:modifiedAt (Modified on).
+func (e PersonProxy) ModifiedAt() []string { return e.Get("modifiedAt") }
+
+// AddModifiedAt adds values to Thing:modifiedAt.
+func (e PersonProxy) AddModifiedAt(values ...string) error { return e.Add("modifiedAt", values, false) }
+
+// SetModifiedAt replaces the values of Thing:modifiedAt.
+func (e PersonProxy) SetModifiedAt(values ...string) error { return e.Set("modifiedAt", values, false) }
+
+// NpiCode returns the values of LegalEntity:npiCode (NPI).
+func (e PersonProxy) NpiCode() []string { return e.Get("npiCode") }
+
+// AddNpiCode adds values to LegalEntity:npiCode.
+func (e PersonProxy) AddNpiCode(values ...string) error { return e.Add("npiCode", values, false) }
+
+// SetNpiCode replaces the values of LegalEntity:npiCode.
+func (e PersonProxy) SetNpiCode(values ...string) error { return e.Set("npiCode", values, false) }
+
+// NameSuffix returns the values of Person:nameSuffix (Name suffix).
+func (e PersonProxy) NameSuffix() []string { return e.Get("nameSuffix") }
+
+// AddNameSuffix adds values to Person:nameSuffix.
+func (e PersonProxy) AddNameSuffix(values ...string) error { return e.Add("nameSuffix", values, false) }
+
+// SetNameSuffix replaces the values of Person:nameSuffix.
+func (e PersonProxy) SetNameSuffix(values ...string) error { return e.Set("nameSuffix", values, false) }
+
+// Notes returns the values of Thing:notes (Notes).
+func (e PersonProxy) Notes() []string { return e.Get("notes") }
+
+// AddNotes adds values to Thing:notes.
+func (e PersonProxy) AddNotes(values ...string) error { return e.Add("notes", values, false) }
+
+// SetNotes replaces the values of Thing:notes.
+func (e PersonProxy) SetNotes(values ...string) error { return e.Set("notes", values, false) }
+
+// OgrnCode returns the values of LegalEntity:ogrnCode (OGRN).
+func (e PersonProxy) OgrnCode() []string { return e.Get("ogrnCode") }
+
+// AddOgrnCode adds values to LegalEntity:ogrnCode.
+func (e PersonProxy) AddOgrnCode(values ...string) error { return e.Add("ogrnCode", values, false) }
+
+// SetOgrnCode replaces the values of LegalEntity:ogrnCode.
+func (e PersonProxy) SetOgrnCode(values ...string) error { return e.Set("ogrnCode", values, false) }
+
+// OkpoCode returns the values of LegalEntity:okpoCode (OKPO).
+func (e PersonProxy) OkpoCode() []string { return e.Get("okpoCode") }
+
+// AddOkpoCode adds values to LegalEntity:okpoCode.
+func (e PersonProxy) AddOkpoCode(values ...string) error { return e.Add("okpoCode", values, false) }
+
+// SetOkpoCode replaces the values of LegalEntity:okpoCode.
+func (e PersonProxy) SetOkpoCode(values ...string) error { return e.Set("okpoCode", values, false) }
+
+// OpencorporatesUrl returns the values of LegalEntity:opencorporatesUrl (OpenCorporates URL).
+func (e PersonProxy) OpencorporatesUrl() []string { return e.Get("opencorporatesUrl") }
+
+// AddOpencorporatesUrl adds values to LegalEntity:opencorporatesUrl.
+func (e PersonProxy) AddOpencorporatesUrl(values ...string) error {
+	return e.Add("opencorporatesUrl", values, false)
+}
+
+// SetOpencorporatesUrl replaces the values of LegalEntity:opencorporatesUrl.
+func (e PersonProxy) SetOpencorporatesUrl(values ...string) error {
+	return e.Set("opencorporatesUrl", values, false)
+}
+
+// Alias returns the values of Thing:alias (Other name).
+func (e PersonProxy) Alias() []string { return e.Get("alias") }
+
+// AddAlias adds values to Thing:alias.
+func (e PersonProxy) AddAlias(values ...string) error { return e.Add("alias", values, false) }
+
+// SetAlias replaces the values of Thing:alias.
+func (e PersonProxy) SetAlias(values ...string) error { return e.Set("alias", values, false) }
+
+// Parent returns the values of LegalEntity:parent (Parent company).
+//
+// Deprecated: LegalEntity:parent is deprecated in the model.
+func (e PersonProxy) Parent() []string { return e.Get("parent") }
+
+// AddParent adds values to LegalEntity:parent.
+func (e PersonProxy) AddParent(values ...string) error { return e.Add("parent", values, false) }
+
+// SetParent replaces the values of LegalEntity:parent.
+func (e PersonProxy) SetParent(values ...string) error { return e.Set("parent", values, false) }
+
+// PassportNumber returns the values of Person:passportNumber (Passport number).
+func (e PersonProxy) PassportNumber() []string { return e.Get("passportNumber") }
+
+// AddPassportNumber adds values to Person:passportNumber.
+func (e PersonProxy) AddPassportNumber(values ...string) error {
+	return e.Add("passportNumber", values, false)
+}
+
+// SetPassportNumber replaces the values of Person:passportNumber.
+func (e PersonProxy) SetPassportNumber(values ...string) error {
+	return e.Set("passportNumber", values, false)
+}
+
+// FatherName returns the values of Person:fatherName (Patronymic).
+func (e PersonProxy) FatherName() []string { return e.Get("fatherName") }
+
+// AddFatherName adds values to Person:fatherName.
+func (e PersonProxy) AddFatherName(values ...string) error { return e.Add("fatherName", values, false) }
+
+// SetFatherName replaces the values of Person:fatherName.
+func (e PersonProxy) SetFatherName(values ...string) error { return e.Set("fatherName", values, false) }
+
+// Appearance returns the values of Person:appearance (Physical appearance).
+func (e PersonProxy) Appearance() []string { return e.Get("appearance") }
+
+// AddAppearance adds values to Person:appearance.
+func (e PersonProxy) AddAppearance(values ...string) error { return e.Add("appearance", values, false) }
+
+// SetAppearance replaces the values of Person:appearance.
+func (e PersonProxy) SetAppearance(values ...string) error { return e.Set("appearance", values, false) }
+
+// BirthPlace returns the values of Person:birthPlace (Place of birth).
+func (e PersonProxy) BirthPlace() []string { return e.Get("birthPlace") }
+
+// AddBirthPlace adds values to Person:birthPlace.
+func (e PersonProxy) AddBirthPlace(values ...string) error { return e.Add("birthPlace", values, false) }
+
+// SetBirthPlace replaces the values of Person:birthPlace.
+func (e PersonProxy) SetBirthPlace(values ...string) error { return e.Set("birthPlace", values, false) }
+
+// Political returns the values of Person:political (Political association).
+func (e PersonProxy) Political() []string { return e.Get("political") }
+
+// AddPolitical adds values to Person:political.
+func (e PersonProxy) AddPolitical(values ...string) error { return e.Add("political", values, false) }
+
+// SetPolitical replaces the values of Person:political.
+func (e PersonProxy) SetPolitical(values ...string) error { return e.Set("political", values, false) }
+
+// Position returns the values of Person:position (Position).
+func (e PersonProxy) Position() []string { return e.Get("position") }
+
+// AddPosition adds values to Person:position.
+func (e PersonProxy) AddPosition(values ...string) error { return e.Add("position", values, false) }
+
+// SetPosition replaces the values of Person:position.
+func (e PersonProxy) SetPosition(values ...string) error { return e.Set("position", values, false) }
+
+// PreviousName returns the values of Thing:previousName (Previous name).
+func (e PersonProxy) PreviousName() []string { return e.Get("previousName") }
+
+// AddPreviousName adds values to Thing:previousName.
+func (e PersonProxy) AddPreviousName(values ...string) error {
+	return e.Add("previousName", values, false)
+}
+
+// SetPreviousName replaces the values of Thing:previousName.
+func (e PersonProxy) SetPreviousName(values ...string) error {
+	return e.Set("previousName", values, false)
+}
+
+// Program returns the values of Thing:program (Program).
+func (e PersonProxy) Program() []string { return e.Get("program") }
+
+// AddProgram adds values to Thing:program.
+func (e PersonProxy) AddProgram(values ...string) error { return e.Add("program", values, false) }
+
+// SetProgram replaces the values of Thing:program.
+func (e PersonProxy) SetProgram(values ...string) error { return e.Set("program", values, false) }
+
+// Publisher returns the values of Thing:publisher (Publishing source).
+func (e PersonProxy) Publisher() []string { return e.Get("publisher") }
+
+// AddPublisher adds values to Thing:publisher.
+func (e PersonProxy) AddPublisher(values ...string) error { return e.Add("publisher", values, false) }
+
+// SetPublisher replaces the values of Thing:publisher.
+func (e PersonProxy) SetPublisher(values ...string) error { return e.Set("publisher", values, false) }
+
+// PublisherUrl returns the values of Thing:publisherUrl (Publishing source URL).
+func (e PersonProxy) PublisherUrl() []string { return e.Get("publisherUrl") }
+
+// AddPublisherUrl adds values to Thing:publisherUrl.
+func (e PersonProxy) AddPublisherUrl(values ...string) error {
+	return e.Add("publisherUrl", values, false)
+}
+
+// SetPublisherUrl replaces the values of Thing:publisherUrl.
+func (e PersonProxy) SetPublisherUrl(values ...string) error {
+	return e.Set("publisherUrl", values, false)
+}
+
+// RegistrationNumber returns the values of LegalEntity:registrationNumber (Registration number).
+func (e PersonProxy) RegistrationNumber() []string { return e.Get("registrationNumber") }
+
+// AddRegistrationNumber adds values to LegalEntity:registrationNumber.
+func (e PersonProxy) AddRegistrationNumber(values ...string) error {
+	return e.Add("registrationNumber", values, false)
+}
+
+// SetRegistrationNumber replaces the values of LegalEntity:registrationNumber.
+func (e PersonProxy) SetRegistrationNumber(values ...string) error {
+	return e.Set("registrationNumber", values, false)
+}
+
+// Religion returns the values of Person:religion (Religion).
+func (e PersonProxy) Religion() []string { return e.Get("religion") }
+
+// AddReligion adds values to Person:religion.
+func (e PersonProxy) AddReligion(values ...string) error { return e.Add("religion", values, false) }
+
+// SetReligion replaces the values of Person:religion.
+func (e PersonProxy) SetReligion(values ...string) error { return e.Set("religion", values, false) }
+
+// RetrievedAt returns the values of Thing:retrievedAt (Retrieved on).
+func (e PersonProxy) RetrievedAt() []string { return e.Get("retrievedAt") }
+
+// AddRetrievedAt adds values to Thing:retrievedAt.
+func (e PersonProxy) AddRetrievedAt(values ...string) error {
+	return e.Add("retrievedAt", values, false)
+}
+
+// SetRetrievedAt replaces the values of Thing:retrievedAt.
+func (e PersonProxy) SetRetrievedAt(values ...string) error {
+	return e.Set("retrievedAt", values, false)
+}
+
+// SwiftBic returns the values of LegalEntity:swiftBic (SWIFT/BIC).
+func (e PersonProxy) SwiftBic() []string { return e.Get("swiftBic") }
+
+// AddSwiftBic adds values to LegalEntity:swiftBic.
+func (e PersonProxy) AddSwiftBic(values ...string) error { return e.Add("swiftBic", values, false) }
+
+// SetSwiftBic replaces the values of LegalEntity:swiftBic.
+func (e PersonProxy) SetSwiftBic(values ...string) error { return e.Set("swiftBic", values, false) }
+
+// SecondName returns the values of Person:secondName (Second name).
+func (e PersonProxy) SecondName() []string { return e.Get("secondName") }
+
+// AddSecondName adds values to Person:secondName.
+func (e PersonProxy) AddSecondName(values ...string) error { return e.Add("secondName", values, false) }
+
+// SetSecondName replaces the values of Person:secondName.
+func (e PersonProxy) SetSecondName(values ...string) error { return e.Set("secondName", values, false) }
+
+// Sector returns the values of LegalEntity:sector (Sector).
+func (e PersonProxy) Sector() []string { return e.Get("sector") }
+
+// AddSector adds values to LegalEntity:sector.
+func (e PersonProxy) AddSector(values ...string) error { return e.Add("sector", values, false) }
+
+// SetSector replaces the values of LegalEntity:sector.
+func (e PersonProxy) SetSector(values ...string) error { return e.Set("sector", values, false) }
+
+// SocialSecurityNumber returns the values of Person:socialSecurityNumber (Social security number).
+func (e PersonProxy) SocialSecurityNumber() []string { return e.Get("socialSecurityNumber") }
+
+// AddSocialSecurityNumber adds values to Person:socialSecurityNumber.
+func (e PersonProxy) AddSocialSecurityNumber(values ...string) error {
+	return e.Add("socialSecurityNumber", values, false)
+}
+
+// SetSocialSecurityNumber replaces the values of Person:socialSecurityNumber.
+func (e PersonProxy) SetSocialSecurityNumber(values ...string) error {
+	return e.Set("socialSecurityNumber", values, false)
+}
+
+// Proof returns the values of Thing:proof (Source document).
+func (e PersonProxy) Proof() []string { return e.Get("proof") }
+
+// AddProof adds values to Thing:proof.
+func (e PersonProxy) AddProof(values ...string) error { return e.Add("proof", values, false) }
+
+// SetProof replaces the values of Thing:proof.
+func (e PersonProxy) SetProof(values ...string) error { return e.Set("proof", values, false) }
+
+// SourceUrl returns the values of Thing:sourceUrl (Source link).
+func (e PersonProxy) SourceUrl() []string { return e.Get("sourceUrl") }
+
+// AddSourceUrl adds values to Thing:sourceUrl.
+func (e PersonProxy) AddSourceUrl(values ...string) error { return e.Add("sourceUrl", values, false) }
+
+// SetSourceUrl replaces the values of Thing:sourceUrl.
+func (e PersonProxy) SetSourceUrl(values ...string) error { return e.Set("sourceUrl", values, false) }
+
+// SpokenLanguage returns the values of Person:spokenLanguage (Spoken language).
+func (e PersonProxy) SpokenLanguage() []string { return e.Get("spokenLanguage") }
+
+// AddSpokenLanguage adds values to Person:spokenLanguage.
+func (e PersonProxy) AddSpokenLanguage(values ...string) error {
+	return e.Add("spokenLanguage", values, false)
+}
+
+// SetSpokenLanguage replaces the values of Person:spokenLanguage.
+func (e PersonProxy) SetSpokenLanguage(values ...string) error {
+	return e.Set("spokenLanguage", values, false)
+}
+
+// Status returns the values of LegalEntity:status (Status).
+func (e PersonProxy) Status() []string { return e.Get("status") }
+
+// AddStatus adds values to LegalEntity:status.
+func (e PersonProxy) AddStatus(values ...string) error { return e.Add("status", values, false) }
+
+// SetStatus replaces the values of LegalEntity:status.
+func (e PersonProxy) SetStatus(values ...string) error { return e.Set("status", values, false) }
+
+// Summary returns the values of Thing:summary (Summary).
+func (e PersonProxy) Summary() []string { return e.Get("summary") }
+
+// AddSummary adds values to Thing:summary.
+func (e PersonProxy) AddSummary(values ...string) error { return e.Add("summary", values, false) }
+
+// SetSummary replaces the values of Thing:summary.
+func (e PersonProxy) SetSummary(values ...string) error { return e.Set("summary", values, false) }
+
+// TaxNumber returns the values of LegalEntity:taxNumber (Tax Number).
+func (e PersonProxy) TaxNumber() []string { return e.Get("taxNumber") }
+
+// AddTaxNumber adds values to LegalEntity:taxNumber.
+func (e PersonProxy) AddTaxNumber(values ...string) error { return e.Add("taxNumber", values, false) }
+
+// SetTaxNumber replaces the values of LegalEntity:taxNumber.
+func (e PersonProxy) SetTaxNumber(values ...string) error { return e.Set("taxNumber", values, false) }
+
+// TaxStatus returns the values of LegalEntity:taxStatus (Tax status).
+func (e PersonProxy) TaxStatus() []string { return e.Get("taxStatus") }
+
+// AddTaxStatus adds values to LegalEntity:taxStatus.
+func (e PersonProxy) AddTaxStatus(values ...string) error { return e.Add("taxStatus", values, false) }
+
+// SetTaxStatus replaces the values of LegalEntity:taxStatus.
+func (e PersonProxy) SetTaxStatus(values ...string) error { return e.Set("taxStatus", values, false) }
+
+// Title returns the values of Person:title (Title).
+func (e PersonProxy) Title() []string { return e.Get("title") }
+
+// AddTitle adds values to Person:title.
+func (e PersonProxy) AddTitle(values ...string) error { return e.Add("title", values, false) }
+
+// SetTitle replaces the values of Person:title.
+func (e PersonProxy) SetTitle(values ...string) error { return e.Set("title", values, false) }
+
+// Topics returns the values of Thing:topics (Topics).
+func (e PersonProxy) Topics() []string { return e.Get("topics") }
+
+// AddTopics adds values to Thing:topics.
+func (e PersonProxy) AddTopics(values ...string) error { return e.Add("topics", values, false) }
+
+// SetTopics replaces the values of Thing:topics.
+func (e PersonProxy) SetTopics(values ...string) error { return e.Set("topics", values, false) }
+
+// UscCode returns the values of LegalEntity:uscCode (USCC).
+func (e PersonProxy) UscCode() []string { return e.Get("uscCode") }
+
+// AddUscCode adds values to LegalEntity:uscCode.
+func (e PersonProxy) AddUscCode(values ...string) error { return e.Add("uscCode", values, false) }
+
+// SetUscCode replaces the values of LegalEntity:uscCode.
+func (e PersonProxy) SetUscCode(values ...string) error { return e.Set("uscCode", values, false) }
+
+// UniqueEntityId returns the values of LegalEntity:uniqueEntityId (Unique Entity ID).
+func (e PersonProxy) UniqueEntityId() []string { return e.Get("uniqueEntityId") }
+
+// AddUniqueEntityId adds values to LegalEntity:uniqueEntityId.
+func (e PersonProxy) AddUniqueEntityId(values ...string) error {
+	return e.Add("uniqueEntityId", values, false)
+}
+
+// SetUniqueEntityId replaces the values of LegalEntity:uniqueEntityId.
+func (e PersonProxy) SetUniqueEntityId(values ...string) error {
+	return e.Set("uniqueEntityId", values, false)
+}
+
+// VatCode returns the values of LegalEntity:vatCode (V.A.T. Identifier).
+func (e PersonProxy) VatCode() []string { return e.Get("vatCode") }
+
+// AddVatCode adds values to LegalEntity:vatCode.
+func (e PersonProxy) AddVatCode(values ...string) error { return e.Add("vatCode", values, false) }
+
+// SetVatCode replaces the values of LegalEntity:vatCode.
+func (e PersonProxy) SetVatCode(values ...string) error { return e.Set("vatCode", values, false) }
+
+// WeakAlias returns the values of Thing:weakAlias (Weak alias).
+func (e PersonProxy) WeakAlias() []string { return e.Get("weakAlias") }
+
+// AddWeakAlias adds values to Thing:weakAlias.
+func (e PersonProxy) AddWeakAlias(values ...string) error { return e.Add("weakAlias", values, false) }
+
+// SetWeakAlias replaces the values of Thing:weakAlias.
+func (e PersonProxy) SetWeakAlias(values ...string) error { return e.Set("weakAlias", values, false) }
+
+// Website returns the values of LegalEntity:website (Website).
+func (e PersonProxy) Website() []string { return e.Get("website") }
+
+// AddWebsite adds values to LegalEntity:website.
+func (e PersonProxy) AddWebsite(values ...string) error { return e.Add("website", values, false) }
+
+// SetWebsite replaces the values of LegalEntity:website.
+func (e PersonProxy) SetWebsite(values ...string) error { return e.Set("website", values, false) }
+
+// Weight returns the values of Person:weight (Weight).
+func (e PersonProxy) Weight() []string { return e.Get("weight") }
+
+// AddWeight adds values to Person:weight.
+func (e PersonProxy) AddWeight(values ...string) error { return e.Add("weight", values, false) }
+
+// SetWeight replaces the values of Person:weight.
+func (e PersonProxy) SetWeight(values ...string) error { return e.Set("weight", values, false) }
+
+// WikidataId returns the values of Thing:wikidataId (Wikidata ID).
+func (e PersonProxy) WikidataId() []string { return e.Get("wikidataId") }
+
+// AddWikidataId adds values to Thing:wikidataId.
+func (e PersonProxy) AddWikidataId(values ...string) error { return e.Add("wikidataId", values, false) }
+
+// SetWikidataId replaces the values of Thing:wikidataId.
+func (e PersonProxy) SetWikidataId(values ...string) error { return e.Set("wikidataId", values, false) }
+
+// WikipediaUrl returns the values of Thing:wikipediaUrl (Wikipedia Article).
+func (e PersonProxy) WikipediaUrl() []string { return e.Get("wikipediaUrl") }
+
+// AddWikipediaUrl adds values to Thing:wikipediaUrl.
+func (e PersonProxy) AddWikipediaUrl(values ...string) error {
+	return e.Add("wikipediaUrl", values, false)
+}
+
+// SetWikipediaUrl replaces the values of Thing:wikipediaUrl.
+func (e PersonProxy) SetWikipediaUrl(values ...string) error {
+	return e.Set("wikipediaUrl", values, false)
+}
+
+// OrganizationProxy is a typed wrapper of an entity of schema Organization (Organization).
+type OrganizationProxy struct{ *EntityProxy }
+
+// NewOrganization creates an empty Organization entity in model m.
+func NewOrganization(m *Model, id string) OrganizationProxy {
+	return OrganizationProxy{NewEntityProxy(m.Get("Organization"), id)}
+}
+
+// AsOrganization wraps e if its schema is or extends Organization.
+func AsOrganization(e *EntityProxy) (OrganizationProxy, bool) {
+	if e == nil || e.Schema == nil || !e.Schema.IsA("Organization") {
+		return OrganizationProxy{}, false
+	}
+	return OrganizationProxy{e}, true
+}
+
+// Name returns the values of Thing:name (Name).
+func (e OrganizationProxy) Name() []string { return e.Get("name") }
+
+// AddName adds values to Thing:name.
+func (e OrganizationProxy) AddName(values ...string) error { return e.Add("name", values, false) }
+
+// SetName replaces the values of Thing:name.
+func (e OrganizationProxy) SetName(values ...string) error { return e.Set("name", values, false) }
+
+// Country returns the values of Thing:country (Country).
+func (e OrganizationProxy) Country() []string { return e.Get("country") }
+
+// AddCountry adds values to Thing:country.
+func (e OrganizationProxy) AddCountry(values ...string) error { return e.Add("country", values, false) }
+
+// SetCountry replaces the values of Thing:country.
+func (e OrganizationProxy) SetCountry(values ...string) error { return e.Set("country", values, false) }
+
+// LegalForm returns the values of LegalEntity:legalForm (Legal form).
+func (e OrganizationProxy) LegalForm() []string { return e.Get("legalForm") }
+
+// AddLegalForm adds values to LegalEntity:legalForm.
+func (e OrganizationProxy) AddLegalForm(values ...string) error {
+	return e.Add("legalForm", values, false)
+}
+
+// SetLegalForm replaces the values of LegalEntity:legalForm.
+func (e OrganizationProxy) SetLegalForm(values ...string) error {
+	return e.Set("legalForm", values, false)
+}
+
+// Status returns the values of LegalEntity:status (Status).
+func (e OrganizationProxy) Status() []string { return e.Get("status") }
+
+// AddStatus adds values to LegalEntity:status.
+func (e OrganizationProxy) AddStatus(values ...string) error { return e.Add("status", values, false) }
+
+// SetStatus replaces the values of LegalEntity:status.
+func (e OrganizationProxy) SetStatus(values ...string) error { return e.Set("status", values, false) }
+
+// Address returns the values of Thing:address (Address).
+func (e OrganizationProxy) Address() []string { return e.Get("address") }
+
+// AddAddress adds values to Thing:address.
+func (e OrganizationProxy) AddAddress(values ...string) error { return e.Add("address", values, false) }
+
+// SetAddress replaces the values of Thing:address.
+func (e OrganizationProxy) SetAddress(values ...string) error { return e.Set("address", values, false) }
+
+// AddressEntity returns the values of Thing:addressEntity (Address).
+func (e OrganizationProxy) AddressEntity() []string { return e.Get("addressEntity") }
+
+// AddAddressEntity adds values to Thing:addressEntity.
+func (e OrganizationProxy) AddAddressEntity(values ...string) error {
+	return e.Add("addressEntity", values, false)
+}
+
+// SetAddressEntity replaces the values of Thing:addressEntity.
+func (e OrganizationProxy) SetAddressEntity(values ...string) error {
+	return e.Set("addressEntity", values, false)
+}
+
+// BvdId returns the values of LegalEntity:bvdId (Bureau van Dijk ID).
+func (e OrganizationProxy) BvdId() []string { return e.Get("bvdId") }
+
+// AddBvdId adds values to LegalEntity:bvdId.
+func (e OrganizationProxy) AddBvdId(values ...string) error { return e.Add("bvdId", values, false) }
+
+// SetBvdId replaces the values of LegalEntity:bvdId.
+func (e OrganizationProxy) SetBvdId(values ...string) error { return e.Set("bvdId", values, false) }
+
+// CageCode returns the values of Organization:cageCode (CAGE).
+func (e OrganizationProxy) CageCode() []string { return e.Get("cageCode") }
+
+// AddCageCode adds values to Organization:cageCode.
+func (e OrganizationProxy) AddCageCode(values ...string) error {
+	return e.Add("cageCode", values, false)
+}
+
+// SetCageCode replaces the values of Organization:cageCode.
+func (e OrganizationProxy) SetCageCode(values ...string) error {
+	return e.Set("cageCode", values, false)
+}
+
+// Classification returns the values of LegalEntity:classification (Classification).
+func (e OrganizationProxy) Classification() []string { return e.Get("classification") }
+
+// AddClassification adds values to LegalEntity:classification.
+func (e OrganizationProxy) AddClassification(values ...string) error {
+	return e.Add("classification", values, false)
+}
+
+// SetClassification replaces the values of LegalEntity:classification.
+func (e OrganizationProxy) SetClassification(values ...string) error {
+	return e.Set("classification", values, false)
+}
+
+// MainCountry returns the values of LegalEntity:mainCountry (Country of origin).
+func (e OrganizationProxy) MainCountry() []string { return e.Get("mainCountry") }
+
+// AddMainCountry adds values to LegalEntity:mainCountry.
+func (e OrganizationProxy) AddMainCountry(values ...string) error {
+	return e.Add("mainCountry", values, false)
+}
+
+// SetMainCountry replaces the values of LegalEntity:mainCountry.
+func (e OrganizationProxy) SetMainCountry(values ...string) error {
+	return e.Set("mainCountry", values, false)
+}
+
+// CreatedAt returns the values of Thing:createdAt (Created at).
+func (e OrganizationProxy) CreatedAt() []string { return e.Get("createdAt") }
+
+// AddCreatedAt adds values to Thing:createdAt.
+func (e OrganizationProxy) AddCreatedAt(values ...string) error {
+	return e.Add("createdAt", values, false)
+}
+
+// SetCreatedAt replaces the values of Thing:createdAt.
+func (e OrganizationProxy) SetCreatedAt(values ...string) error {
+	return e.Set("createdAt", values, false)
+}
+
+// DunsCode returns the values of LegalEntity:dunsCode (DUNS).
+func (e OrganizationProxy) DunsCode() []string { return e.Get("dunsCode") }
+
+// AddDunsCode adds values to LegalEntity:dunsCode.
+func (e OrganizationProxy) AddDunsCode(values ...string) error {
+	return e.Add("dunsCode", values, false)
+}
+
+// SetDunsCode replaces the values of LegalEntity:dunsCode.
+func (e OrganizationProxy) SetDunsCode(values ...string) error {
+	return e.Set("dunsCode", values, false)
+}
+
+// Description returns the values of Thing:description (Description).
+func (e OrganizationProxy) Description() []string { return e.Get("description") }
+
+// AddDescription adds values to Thing:description.
+func (e OrganizationProxy) AddDescription(values ...string) error {
+	return e.Add("description", values, false)
+}
+
+// SetDescription replaces the values of Thing:description.
+func (e OrganizationProxy) SetDescription(values ...string) error {
+	return e.Set("description", values, false)
+}
+
+// DissolutionDate returns the values of LegalEntity:dissolutionDate (Dissolution date).
+func (e OrganizationProxy) DissolutionDate() []string { return e.Get("dissolutionDate") }
+
+// AddDissolutionDate adds values to LegalEntity:dissolutionDate.
+func (e OrganizationProxy) AddDissolutionDate(values ...string) error {
+	return e.Add("dissolutionDate", values, false)
+}
+
+// SetDissolutionDate replaces the values of LegalEntity:dissolutionDate.
+func (e OrganizationProxy) SetDissolutionDate(values ...string) error {
+	return e.Set("dissolutionDate", values, false)
+}
+
+// Email returns the values of LegalEntity:email (E-Mail).
+func (e OrganizationProxy) Email() []string { return e.Get("email") }
+
+// AddEmail adds values to LegalEntity:email.
+func (e OrganizationProxy) AddEmail(values ...string) error { return e.Add("email", values, false) }
+
+// SetEmail replaces the values of LegalEntity:email.
+func (e OrganizationProxy) SetEmail(values ...string) error { return e.Set("email", values, false) }
+
+// GiiNumber returns the values of Organization:giiNumber (GIIN).
+func (e OrganizationProxy) GiiNumber() []string { return e.Get("giiNumber") }
+
+// AddGiiNumber adds values to Organization:giiNumber.
+func (e OrganizationProxy) AddGiiNumber(values ...string) error {
+	return e.Add("giiNumber", values, false)
+}
+
+// SetGiiNumber replaces the values of Organization:giiNumber.
+func (e OrganizationProxy) SetGiiNumber(values ...string) error {
+	return e.Set("giiNumber", values, false)
+}
+
+// IcijId returns the values of LegalEntity:icijId (ICIJ ID).
+func (e OrganizationProxy) IcijId() []string { return e.Get("icijId") }
+
+// AddIcijId adds values to LegalEntity:icijId.
+func (e OrganizationProxy) AddIcijId(values ...string) error { return e.Add("icijId", values, false) }
+
+// SetIcijId replaces the values of LegalEntity:icijId.
+func (e OrganizationProxy) SetIcijId(values ...string) error { return e.Set("icijId", values, false) }
+
+// IdNumber returns the values of LegalEntity:idNumber (ID Number).
+func (e OrganizationProxy) IdNumber() []string { return e.Get("idNumber") }
+
+// AddIdNumber adds values to LegalEntity:idNumber.
+func (e OrganizationProxy) AddIdNumber(values ...string) error {
+	return e.Add("idNumber", values, false)
+}
+
+// SetIdNumber replaces the values of LegalEntity:idNumber.
+func (e OrganizationProxy) SetIdNumber(values ...string) error {
+	return e.Set("idNumber", values, false)
+}
+
+// ImoNumber returns the values of Organization:imoNumber (IMO Number).
+func (e OrganizationProxy) ImoNumber() []string { return e.Get("imoNumber") }
+
+// AddImoNumber adds values to Organization:imoNumber.
+func (e OrganizationProxy) AddImoNumber(values ...string) error {
+	return e.Add("imoNumber", values, false)
+}
+
+// SetImoNumber replaces the values of Organization:imoNumber.
+func (e OrganizationProxy) SetImoNumber(values ...string) error {
+	return e.Set("imoNumber", values, false)
+}
+
+// InnCode returns the values of LegalEntity:innCode (INN).
+func (e OrganizationProxy) InnCode() []string { return e.Get("innCode") }
+
+// AddInnCode adds values to LegalEntity:innCode.
+func (e OrganizationProxy) AddInnCode(values ...string) error { return e.Add("innCode", values, false) }
+
+// SetInnCode replaces the values of LegalEntity:innCode.
+func (e OrganizationProxy) SetInnCode(values ...string) error { return e.Set("innCode", values, false) }
+
+// IncorporationDate returns the values of LegalEntity:incorporationDate (Incorporation date).
+func (e OrganizationProxy) IncorporationDate() []string { return e.Get("incorporationDate") }
+
+// AddIncorporationDate adds values to LegalEntity:incorporationDate.
+func (e OrganizationProxy) AddIncorporationDate(values ...string) error {
+	return e.Add("incorporationDate", values, false)
+}
+
+// SetIncorporationDate replaces the values of LegalEntity:incorporationDate.
+func (e OrganizationProxy) SetIncorporationDate(values ...string) error {
+	return e.Set("incorporationDate", values, false)
+}
+
+// Jurisdiction returns the values of LegalEntity:jurisdiction (Jurisdiction).
+func (e OrganizationProxy) Jurisdiction() []string { return e.Get("jurisdiction") }
+
+// AddJurisdiction adds values to LegalEntity:jurisdiction.
+func (e OrganizationProxy) AddJurisdiction(values ...string) error {
+	return e.Add("jurisdiction", values, false)
+}
+
+// SetJurisdiction replaces the values of LegalEntity:jurisdiction.
+func (e OrganizationProxy) SetJurisdiction(values ...string) error {
+	return e.Set("jurisdiction", values, false)
+}
+
+// Keywords returns the values of Thing:keywords (Keywords).
+func (e OrganizationProxy) Keywords() []string { return e.Get("keywords") }
+
+// AddKeywords adds values to Thing:keywords.
+func (e OrganizationProxy) AddKeywords(values ...string) error {
+	return e.Add("keywords", values, false)
+}
+
+// SetKeywords replaces the values of Thing:keywords.
+func (e OrganizationProxy) SetKeywords(values ...string) error {
+	return e.Set("keywords", values, false)
+}
+
+// LeiCode returns the values of LegalEntity:leiCode (LEI).
+func (e OrganizationProxy) LeiCode() []string { return e.Get("leiCode") }
+
+// AddLeiCode adds values to LegalEntity:leiCode.
+func (e OrganizationProxy) AddLeiCode(values ...string) error { return e.Add("leiCode", values, false) }
+
+// SetLeiCode replaces the values of LegalEntity:leiCode.
+func (e OrganizationProxy) SetLeiCode(values ...string) error { return e.Set("leiCode", values, false) }
+
+// LicenseNumber returns the values of LegalEntity:licenseNumber (License Number).
+func (e OrganizationProxy) LicenseNumber() []string { return e.Get("licenseNumber") }
+
+// AddLicenseNumber adds values to LegalEntity:licenseNumber.
+func (e OrganizationProxy) AddLicenseNumber(values ...string) error {
+	return e.Add("licenseNumber", values, false)
+}
+
+// SetLicenseNumber replaces the values of LegalEntity:licenseNumber.
+func (e OrganizationProxy) SetLicenseNumber(values ...string) error {
+	return e.Set("licenseNumber", values, false)
+}
+
+// ModifiedAt returns the values of Thing:modifiedAt (Modified on).
+func (e OrganizationProxy) ModifiedAt() []string { return e.Get("modifiedAt") }
+
+// AddModifiedAt adds values to Thing:modifiedAt.
+func (e OrganizationProxy) AddModifiedAt(values ...string) error {
+	return e.Add("modifiedAt", values, false)
+}
+
+// SetModifiedAt replaces the values of Thing:modifiedAt.
+func (e OrganizationProxy) SetModifiedAt(values ...string) error {
+	return e.Set("modifiedAt", values, false)
+}
+
+// NpiCode returns the values of LegalEntity:npiCode (NPI).
+func (e OrganizationProxy) NpiCode() []string { return e.Get("npiCode") }
+
+// AddNpiCode adds values to LegalEntity:npiCode.
+func (e OrganizationProxy) AddNpiCode(values ...string) error { return e.Add("npiCode", values, false) }
+
+// SetNpiCode replaces the values of LegalEntity:npiCode.
+func (e OrganizationProxy) SetNpiCode(values ...string) error { return e.Set("npiCode", values, false) }
+
+// Notes returns the values of Thing:notes (Notes).
+func (e OrganizationProxy) Notes() []string { return e.Get("notes") }
+
+// AddNotes adds values to Thing:notes.
+func (e OrganizationProxy) AddNotes(values ...string) error { return e.Add("notes", values, false) }
+
+// SetNotes replaces the values of Thing:notes.
+func (e OrganizationProxy) SetNotes(values ...string) error { return e.Set("notes", values, false) }
+
+// OgrnCode returns the values of LegalEntity:ogrnCode (OGRN).
+func (e OrganizationProxy) OgrnCode() []string { return e.Get("ogrnCode") }
+
+// AddOgrnCode adds values to LegalEntity:ogrnCode.
+func (e OrganizationProxy) AddOgrnCode(values ...string) error {
+	return e.Add("ogrnCode", values, false)
+}
+
+// SetOgrnCode replaces the values of LegalEntity:ogrnCode.
+func (e OrganizationProxy) SetOgrnCode(values ...string) error {
+	return e.Set("ogrnCode", values, false)
+}
+
+// OkpoCode returns the values of LegalEntity:okpoCode (OKPO).
+func (e OrganizationProxy) OkpoCode() []string { return e.Get("okpoCode") }
+
+// AddOkpoCode adds values to LegalEntity:okpoCode.
+func (e OrganizationProxy) AddOkpoCode(values ...string) error {
+	return e.Add("okpoCode", values, false)
+}
+
+// SetOkpoCode replaces the values of LegalEntity:okpoCode.
+func (e OrganizationProxy) SetOkpoCode(values ...string) error {
+	return e.Set("okpoCode", values, false)
+}
+
+// OpencorporatesUrl returns the values of LegalEntity:opencorporatesUrl (OpenCorporates URL).
+func (e OrganizationProxy) OpencorporatesUrl() []string { return e.Get("opencorporatesUrl") }
+
+// AddOpencorporatesUrl adds values to LegalEntity:opencorporatesUrl.
+func (e OrganizationProxy) AddOpencorporatesUrl(values ...string) error {
+	return e.Add("opencorporatesUrl", values, false)
+}
+
+// SetOpencorporatesUrl replaces the values of LegalEntity:opencorporatesUrl.
+func (e OrganizationProxy) SetOpencorporatesUrl(values ...string) error {
+	return e.Set("opencorporatesUrl", values, false)
+}
+
+// Alias returns the values of Thing:alias (Other name).
+func (e OrganizationProxy) Alias() []string { return e.Get("alias") }
+
+// AddAlias adds values to Thing:alias.
+func (e OrganizationProxy) AddAlias(values ...string) error { return e.Add("alias", values, false) }
+
+// SetAlias replaces the values of Thing:alias.
+func (e OrganizationProxy) SetAlias(values ...string) error { return e.Set("alias", values, false) }
+
+// Parent returns the values of LegalEntity:parent (Parent company).
+//
+// Deprecated: LegalEntity:parent is deprecated in the model.
+func (e OrganizationProxy) Parent() []string { return e.Get("parent") }
+
+// AddParent adds values to LegalEntity:parent.
+func (e OrganizationProxy) AddParent(values ...string) error { return e.Add("parent", values, false) }
+
+// SetParent replaces the values of LegalEntity:parent.
+func (e OrganizationProxy) SetParent(values ...string) error { return e.Set("parent", values, false) }
+
+// PermId returns the values of Organization:permId (PermID).
+func (e OrganizationProxy) PermId() []string { return e.Get("permId") }
+
+// AddPermId adds values to Organization:permId.
+func (e OrganizationProxy) AddPermId(values ...string) error { return e.Add("permId", values, false) }
+
+// SetPermId replaces the values of Organization:permId.
+func (e OrganizationProxy) SetPermId(values ...string) error { return e.Set("permId", values, false) }
+
+// Phone returns the values of LegalEntity:phone (Phone).
+func (e OrganizationProxy) Phone() []string { return e.Get("phone") }
+
+// AddPhone adds values to LegalEntity:phone.
+func (e OrganizationProxy) AddPhone(values ...string) error { return e.Add("phone", values, false) }
+
+// SetPhone replaces the values of LegalEntity:phone.
+func (e OrganizationProxy) SetPhone(values ...string) error { return e.Set("phone", values, false) }
+
+// PreviousName returns the values of Thing:previousName (Previous name).
+func (e OrganizationProxy) PreviousName() []string { return e.Get("previousName") }
+
+// AddPreviousName adds values to Thing:previousName.
+func (e OrganizationProxy) AddPreviousName(values ...string) error {
+	return e.Add("previousName", values, false)
+}
+
+// SetPreviousName replaces the values of Thing:previousName.
+func (e OrganizationProxy) SetPreviousName(values ...string) error {
+	return e.Set("previousName", values, false)
+}
+
+// Program returns the values of Thing:program (Program).
+func (e OrganizationProxy) Program() []string { return e.Get("program") }
+
+// AddProgram adds values to Thing:program.
+func (e OrganizationProxy) AddProgram(values ...string) error { return e.Add("program", values, false) }
+
+// SetProgram replaces the values of Thing:program.
+func (e OrganizationProxy) SetProgram(values ...string) error { return e.Set("program", values, false) }
+
+// Publisher returns the values of Thing:publisher (Publishing source).
+func (e OrganizationProxy) Publisher() []string { return e.Get("publisher") }
+
+// AddPublisher adds values to Thing:publisher.
+func (e OrganizationProxy) AddPublisher(values ...string) error {
+	return e.Add("publisher", values, false)
+}
+
+// SetPublisher replaces the values of Thing:publisher.
+func (e OrganizationProxy) SetPublisher(values ...string) error {
+	return e.Set("publisher", values, false)
+}
+
+// PublisherUrl returns the values of Thing:publisherUrl (Publishing source URL).
+func (e OrganizationProxy) PublisherUrl() []string { return e.Get("publisherUrl") }
+
+// AddPublisherUrl adds values to Thing:publisherUrl.
+func (e OrganizationProxy) AddPublisherUrl(values ...string) error {
+	return e.Add("publisherUrl", values, false)
+}
+
+// SetPublisherUrl replaces the values of Thing:publisherUrl.
+func (e OrganizationProxy) SetPublisherUrl(values ...string) error {
+	return e.Set("publisherUrl", values, false)
+}
+
+// RegistrationNumber returns the values of LegalEntity:registrationNumber (Registration number).
+func (e OrganizationProxy) RegistrationNumber() []string { return e.Get("registrationNumber") }
+
+// AddRegistrationNumber adds values to LegalEntity:registrationNumber.
+func (e OrganizationProxy) AddRegistrationNumber(values ...string) error {
+	return e.Add("registrationNumber", values, false)
+}
+
+// SetRegistrationNumber replaces the values of LegalEntity:registrationNumber.
+func (e OrganizationProxy) SetRegistrationNumber(values ...string) error {
+	return e.Set("registrationNumber", values, false)
+}
+
+// RetrievedAt returns the values of Thing:retrievedAt (Retrieved on).
+func (e OrganizationProxy) RetrievedAt() []string { return e.Get("retrievedAt") }
+
+// AddRetrievedAt adds values to Thing:retrievedAt.
+func (e OrganizationProxy) AddRetrievedAt(values ...string) error {
+	return e.Add("retrievedAt", values, false)
+}
+
+// SetRetrievedAt replaces the values of Thing:retrievedAt.
+func (e OrganizationProxy) SetRetrievedAt(values ...string) error {
+	return e.Set("retrievedAt", values, false)
+}
+
+// SwiftBic returns the values of LegalEntity:swiftBic (SWIFT/BIC).
+func (e OrganizationProxy) SwiftBic() []string { return e.Get("swiftBic") }
+
+// AddSwiftBic adds values to LegalEntity:swiftBic.
+func (e OrganizationProxy) AddSwiftBic(values ...string) error {
+	return e.Add("swiftBic", values, false)
+}
+
+// SetSwiftBic replaces the values of LegalEntity:swiftBic.
+func (e OrganizationProxy) SetSwiftBic(values ...string) error {
+	return e.Set("swiftBic", values, false)
+}
+
+// Sector returns the values of LegalEntity:sector (Sector).
+func (e OrganizationProxy) Sector() []string { return e.Get("sector") }
+
+// AddSector adds values to LegalEntity:sector.
+func (e OrganizationProxy) AddSector(values ...string) error { return e.Add("sector", values, false) }
+
+// SetSector replaces the values of LegalEntity:sector.
+func (e OrganizationProxy) SetSector(values ...string) error { return e.Set("sector", values, false) }
+
+// Proof returns the values of Thing:proof (Source document).
+func (e OrganizationProxy) Proof() []string { return e.Get("proof") }
+
+// AddProof adds values to Thing:proof.
+func (e OrganizationProxy) AddProof(values ...string) error { return e.Add("proof", values, false) }
+
+// SetProof replaces the values of Thing:proof.
+func (e OrganizationProxy) SetProof(values ...string) error { return e.Set("proof", values, false) }
+
+// SourceUrl returns the values of Thing:sourceUrl (Source link).
+func (e OrganizationProxy) SourceUrl() []string { return e.Get("sourceUrl") }
+
+// AddSourceUrl adds values to Thing:sourceUrl.
+func (e OrganizationProxy) AddSourceUrl(values ...string) error {
+	return e.Add("sourceUrl", values, false)
+}
+
+// SetSourceUrl replaces the values of Thing:sourceUrl.
+func (e OrganizationProxy) SetSourceUrl(values ...string) error {
+	return e.Set("sourceUrl", values, false)
+}
+
+// Summary returns the values of Thing:summary (Summary).
+func (e OrganizationProxy) Summary() []string { return e.Get("summary") }
+
+// AddSummary adds values to Thing:summary.
+func (e OrganizationProxy) AddSummary(values ...string) error { return e.Add("summary", values, false) }
+
+// SetSummary replaces the values of Thing:summary.
+func (e OrganizationProxy) SetSummary(values ...string) error { return e.Set("summary", values, false) }
+
+// TaxNumber returns the values of LegalEntity:taxNumber (Tax Number).
+func (e OrganizationProxy) TaxNumber() []string { return e.Get("taxNumber") }
+
+// AddTaxNumber adds values to LegalEntity:taxNumber.
+func (e OrganizationProxy) AddTaxNumber(values ...string) error {
+	return e.Add("taxNumber", values, false)
+}
+
+// SetTaxNumber replaces the values of LegalEntity:taxNumber.
+func (e OrganizationProxy) SetTaxNumber(values ...string) error {
+	return e.Set("taxNumber", values, false)
+}
+
+// TaxStatus returns the values of LegalEntity:taxStatus (Tax status).
+func (e OrganizationProxy) TaxStatus() []string { return e.Get("taxStatus") }
+
+// AddTaxStatus adds values to LegalEntity:taxStatus.
+func (e OrganizationProxy) AddTaxStatus(values ...string) error {
+	return e.Add("taxStatus", values, false)
+}
+
+// SetTaxStatus replaces the values of LegalEntity:taxStatus.
+func (e OrganizationProxy) SetTaxStatus(values ...string) error {
+	return e.Set("taxStatus", values, false)
+}
+
+// Topics returns the values of Thing:topics (Topics).
+func (e OrganizationProxy) Topics() []string { return e.Get("topics") }
+
+// AddTopics adds values to Thing:topics.
+func (e OrganizationProxy) AddTopics(values ...string) error { return e.Add("topics", values, false) }
+
+// SetTopics replaces the values of Thing:topics.
+func (e OrganizationProxy) SetTopics(values ...string) error { return e.Set("topics", values, false) }
+
+// UscCode returns the values of LegalEntity:uscCode (USCC).
+func (e OrganizationProxy) UscCode() []string { return e.Get("uscCode") }
+
+// AddUscCode adds values to LegalEntity:uscCode.
+func (e OrganizationProxy) AddUscCode(values ...string) error { return e.Add("uscCode", values, false) }
+
+// SetUscCode replaces the values of LegalEntity:uscCode.
+func (e OrganizationProxy) SetUscCode(values ...string) error { return e.Set("uscCode", values, false) }
+
+// UniqueEntityId returns the values of LegalEntity:uniqueEntityId (Unique Entity ID).
+func (e OrganizationProxy) UniqueEntityId() []string { return e.Get("uniqueEntityId") }
+
+// AddUniqueEntityId adds values to LegalEntity:uniqueEntityId.
+func (e OrganizationProxy) AddUniqueEntityId(values ...string) error {
+	return e.Add("uniqueEntityId", values, false)
+}
+
+// SetUniqueEntityId replaces the values of LegalEntity:uniqueEntityId.
+func (e OrganizationProxy) SetUniqueEntityId(values ...string) error {
+	return e.Set("uniqueEntityId", values, false)
+}
+
+// VatCode returns the values of LegalEntity:vatCode (V.A.T. Identifier).
+func (e OrganizationProxy) VatCode() []string { return e.Get("vatCode") }
+
+// AddVatCode adds values to LegalEntity:vatCode.
+func (e OrganizationProxy) AddVatCode(values ...string) error { return e.Add("vatCode", values, false) }
+
+// SetVatCode replaces the values of LegalEntity:vatCode.
+func (e OrganizationProxy) SetVatCode(values ...string) error { return e.Set("vatCode", values, false) }
+
+// WeakAlias returns the values of Thing:weakAlias (Weak alias).
+func (e OrganizationProxy) WeakAlias() []string { return e.Get("weakAlias") }
+
+// AddWeakAlias adds values to Thing:weakAlias.
+func (e OrganizationProxy) AddWeakAlias(values ...string) error {
+	return e.Add("weakAlias", values, false)
+}
+
+// SetWeakAlias replaces the values of Thing:weakAlias.
+func (e OrganizationProxy) SetWeakAlias(values ...string) error {
+	return e.Set("weakAlias", values, false)
+}
+
+// Website returns the values of LegalEntity:website (Website).
+func (e OrganizationProxy) Website() []string { return e.Get("website") }
+
+// AddWebsite adds values to LegalEntity:website.
+func (e OrganizationProxy) AddWebsite(values ...string) error { return e.Add("website", values, false) }
+
+// SetWebsite replaces the values of LegalEntity:website.
+func (e OrganizationProxy) SetWebsite(values ...string) error { return e.Set("website", values, false) }
+
+// WikidataId returns the values of Thing:wikidataId (Wikidata ID).
+func (e OrganizationProxy) WikidataId() []string { return e.Get("wikidataId") }
+
+// AddWikidataId adds values to Thing:wikidataId.
+func (e OrganizationProxy) AddWikidataId(values ...string) error {
+	return e.Add("wikidataId", values, false)
+}
+
+// SetWikidataId replaces the values of Thing:wikidataId.
+func (e OrganizationProxy) SetWikidataId(values ...string) error {
+	return e.Set("wikidataId", values, false)
+}
+
+// WikipediaUrl returns the values of Thing:wikipediaUrl (Wikipedia Article).
+func (e OrganizationProxy) WikipediaUrl() []string { return e.Get("wikipediaUrl") }
+
+// AddWikipediaUrl adds values to Thing:wikipediaUrl.
+func (e OrganizationProxy) AddWikipediaUrl(values ...string) error {
+	return e.Add("wikipediaUrl", values, false)
+}
+
+// SetWikipediaUrl replaces the values of Thing:wikipediaUrl.
+func (e OrganizationProxy) SetWikipediaUrl(values ...string) error {
+	return e.Set("wikipediaUrl", values, false)
+}
+
+// CompanyProxy is a typed wrapper of an entity of schema Company (Company).
+type CompanyProxy struct{ *EntityProxy }
+
+// NewCompany creates an empty Company entity in model m.
+func NewCompany(m *Model, id string) CompanyProxy {
+	return CompanyProxy{NewEntityProxy(m.Get("Company"), id)}
+}
+
+// AsCompany wraps e if its schema is or extends Company.
+func AsCompany(e *EntityProxy) (CompanyProxy, bool) {
+	if e == nil || e.Schema == nil || !e.Schema.IsA("Company") {
+		return CompanyProxy{}, false
+	}
+	return CompanyProxy{e}, true
+}
+
+// Name returns the values of Thing:name (Name).
+func (e CompanyProxy) Name() []string { return e.Get("name") }
+
+// AddName adds values to Thing:name.
+func (e CompanyProxy) AddName(values ...string) error { return e.Add("name", values, false) }
+
+// SetName replaces the values of Thing:name.
+func (e CompanyProxy) SetName(values ...string) error { return e.Set("name", values, false) }
+
+// Jurisdiction returns the values of Company:jurisdiction (Jurisdiction).
+func (e CompanyProxy) Jurisdiction() []string { return e.Get("jurisdiction") }
+
+// AddJurisdiction adds values to Company:jurisdiction.
+func (e CompanyProxy) AddJurisdiction(values ...string) error {
+	return e.Add("jurisdiction", values, false)
+}
+
+// SetJurisdiction replaces the values of Company:jurisdiction.
+func (e CompanyProxy) SetJurisdiction(values ...string) error {
+	return e.Set("jurisdiction", values, false)
+}
+
+// RegistrationNumber returns the values of Company:registrationNumber (Registration number).
+func (e CompanyProxy) RegistrationNumber() []string { return e.Get("registrationNumber") }
+
+// AddRegistrationNumber adds values to Company:registrationNumber.
+func (e CompanyProxy) AddRegistrationNumber(values ...string) error {
+	return e.Add("registrationNumber", values, false)
+}
+
+// SetRegistrationNumber replaces the values of Company:registrationNumber.
+func (e CompanyProxy) SetRegistrationNumber(values ...string) error {
+	return e.Set("registrationNumber", values, false)
+}
+
+// IncorporationDate returns the values of LegalEntity:incorporationDate (Incorporation date).
+func (e CompanyProxy) IncorporationDate() []string { return e.Get("incorporationDate") }
+
+// AddIncorporationDate adds values to LegalEntity:incorporationDate.
+func (e CompanyProxy) AddIncorporationDate(values ...string) error {
+	return e.Add("incorporationDate", values, false)
+}
+
+// SetIncorporationDate replaces the values of LegalEntity:incorporationDate.
+func (e CompanyProxy) SetIncorporationDate(values ...string) error {
+	return e.Set("incorporationDate", values, false)
+}
+
+// Address returns the values of Thing:address (Address).
+func (e CompanyProxy) Address() []string { return e.Get("address") }
+
+// AddAddress adds values to Thing:address.
+func (e CompanyProxy) AddAddress(values ...string) error { return e.Add("address", values, false) }
+
+// SetAddress replaces the values of Thing:address.
+func (e CompanyProxy) SetAddress(values ...string) error { return e.Set("address", values, false) }
+
+// AddressEntity returns the values of Thing:addressEntity (Address).
+func (e CompanyProxy) AddressEntity() []string { return e.Get("addressEntity") }
+
+// AddAddressEntity adds values to Thing:addressEntity.
+func (e CompanyProxy) AddAddressEntity(values ...string) error {
+	return e.Add("addressEntity", values, false)
+}
+
+// SetAddressEntity replaces the values of Thing:addressEntity.
+func (e CompanyProxy) SetAddressEntity(values ...string) error {
+	return e.Set("addressEntity", values, false)
+}
+
+// Amount returns the values of Value:amount (Amount).
+func (e CompanyProxy) Amount() []string { return e.Get("amount") }
+
+// AddAmount adds values to Value:amount.
+func (e CompanyProxy) AddAmount(values ...string) error { return e.Add("amount", values, false) }
+
+// SetAmount replaces the values of Value:amount.
+func (e CompanyProxy) SetAmount(values ...string) error { return e.Set("amount", values, false) }
+
+// AmountEur returns the values of Value:amountEur (Amount in EUR).
+func (e CompanyProxy) AmountEur() []string { return e.Get("amountEur") }
+
+// AddAmountEur adds values to Value:amountEur.
+func (e CompanyProxy) AddAmountEur(values ...string) error { return e.Add("amountEur", values, false) }
+
+// SetAmountEur replaces the values of Value:amountEur.
+func (e CompanyProxy) SetAmountEur(values ...string) error { return e.Set("amountEur", values, false) }
+
+// AmountUsd returns the values of Value:amountUsd (Amount in USD).
+func (e CompanyProxy) AmountUsd() []string { return e.Get("amountUsd") }
+
+// AddAmountUsd adds values to Value:amountUsd.
+func (e CompanyProxy) AddAmountUsd(values ...string) error { return e.Add("amountUsd", values, false) }
+
+// SetAmountUsd replaces the values of Value:amountUsd.
+func (e CompanyProxy) SetAmountUsd(values ...string) error { return e.Set("amountUsd", values, false) }
+
+// BikCode returns the values of Company:bikCode (BIK).
+func (e CompanyProxy) BikCode() []string { return e.Get("bikCode") }
+
+// AddBikCode adds values to Company:bikCode.
+func (e CompanyProxy) AddBikCode(values ...string) error { return e.Add("bikCode", values, false) }
+
+// SetBikCode replaces the values of Company:bikCode.
+func (e CompanyProxy) SetBikCode(values ...string) error { return e.Set("bikCode", values, false) }
+
+// BvdId returns the values of LegalEntity:bvdId (Bureau van Dijk ID).
+func (e CompanyProxy) BvdId() []string { return e.Get("bvdId") }
+
+// AddBvdId adds values to LegalEntity:bvdId.
+func (e CompanyProxy) AddBvdId(values ...string) error { return e.Add("bvdId", values, false) }
+
+// SetBvdId replaces the values of LegalEntity:bvdId.
+func (e CompanyProxy) SetBvdId(values ...string) error { return e.Set("bvdId", values, false) }
+
+// CageCode returns the values of Organization:cageCode (CAGE).
+func (e CompanyProxy) CageCode() []string { return e.Get("cageCode") }
+
+// AddCageCode adds values to Organization:cageCode.
+func (e CompanyProxy) AddCageCode(values ...string) error { return e.Add("cageCode", values, false) }
+
+// SetCageCode replaces the values of Organization:cageCode.
+func (e CompanyProxy) SetCageCode(values ...string) error { return e.Set("cageCode", values, false) }
+
+// CoatoCode returns the values of Company:coatoCode (COATO / SOATO / OKATO).
+func (e CompanyProxy) CoatoCode() []string { return e.Get("coatoCode") }
+
+// AddCoatoCode adds values to Company:coatoCode.
+func (e CompanyProxy) AddCoatoCode(values ...string) error { return e.Add("coatoCode", values, false) }
+
+// SetCoatoCode replaces the values of Company:coatoCode.
+func (e CompanyProxy) SetCoatoCode(values ...string) error { return e.Set("coatoCode", values, false) }
+
+// CaemCode returns the values of Company:caemCode (COD CAEM).
+func (e CompanyProxy) CaemCode() []string { return e.Get("caemCode") }
+
+// AddCaemCode adds values to Company:caemCode.
+func (e CompanyProxy) AddCaemCode(values ...string) error { return e.Add("caemCode", values, false) }
+
+// SetCaemCode replaces the values of Company:caemCode.
+func (e CompanyProxy) SetCaemCode(values ...string) error { return e.Set("caemCode", values, false) }
+
+// Capital returns the values of Company:capital (Capital).
+func (e CompanyProxy) Capital() []string { return e.Get("capital") }
+
+// AddCapital adds values to Company:capital.
+func (e CompanyProxy) AddCapital(values ...string) error { return e.Add("capital", values, false) }
+
+// SetCapital replaces the values of Company:capital.
+func (e CompanyProxy) SetCapital(values ...string) error { return e.Set("capital", values, false) }
+
+// Classification returns the values of LegalEntity:classification (Classification).
+func (e CompanyProxy) Classification() []string { return e.Get("classification") }
+
+// AddClassification adds values to LegalEntity:classification.
+func (e CompanyProxy) AddClassification(values ...string) error {
+	return e.Add("classification", values, false)
+}
+
+// SetClassification replaces the values of LegalEntity:classification.
+func (e CompanyProxy) SetClassification(values ...string) error {
+	return e.Set("classification", values, false)
+}
+
+// Country returns the values of Thing:country (Country).
+func (e CompanyProxy) Country() []string { return e.Get("country") }
+
+// AddCountry adds values to Thing:country.
+func (e CompanyProxy) AddCountry(values ...string) error { return e.Add("country", values, false) }
+
+// SetCountry replaces the values of Thing:country.
+func (e CompanyProxy) SetCountry(values ...string) error { return e.Set("country", values, false) }
+
+// MainCountry returns the values of LegalEntity:mainCountry (Country of origin).
+func (e CompanyProxy) MainCountry() []string { return e.Get("mainCountry") }
+
+// AddMainCountry adds values to LegalEntity:mainCountry.
+func (e CompanyProxy) AddMainCountry(values ...string) error {
+	return e.Add("mainCountry", values, false)
+}
+
+// SetMainCountry replaces the values of LegalEntity:mainCountry.
+func (e CompanyProxy) SetMainCountry(values ...string) error {
+	return e.Set("mainCountry", values, false)
+}
+
+// CreatedAt returns the values of Thing:createdAt (Created at).
+func (e CompanyProxy) CreatedAt() []string { return e.Get("createdAt") }
+
+// AddCreatedAt adds values to Thing:createdAt.
+func (e CompanyProxy) AddCreatedAt(values ...string) error { return e.Add("createdAt", values, false) }
+
+// SetCreatedAt replaces the values of Thing:createdAt.
+func (e CompanyProxy) SetCreatedAt(values ...string) error { return e.Set("createdAt", values, false) }
+
+// Currency returns the values of Value:currency (Currency).
+func (e CompanyProxy) Currency() []string { return e.Get("currency") }
+
+// AddCurrency adds values to Value:currency.
+func (e CompanyProxy) AddCurrency(values ...string) error { return e.Add("currency", values, false) }
+
+// SetCurrency replaces the values of Value:currency.
+func (e CompanyProxy) SetCurrency(values ...string) error { return e.Set("currency", values, false) }
+
+// DunsCode returns the values of LegalEntity:dunsCode (DUNS).
+func (e CompanyProxy) DunsCode() []string { return e.Get("dunsCode") }
+
+// AddDunsCode adds values to LegalEntity:dunsCode.
+func (e CompanyProxy) AddDunsCode(values ...string) error { return e.Add("dunsCode", values, false) }
+
+// SetDunsCode replaces the values of LegalEntity:dunsCode.
+func (e CompanyProxy) SetDunsCode(values ...string) error { return e.Set("dunsCode", values, false) }
+
+// Description returns the values of Thing:description (Description).
+func (e CompanyProxy) Description() []string { return e.Get("description") }
+
+// AddDescription adds values to Thing:description.
+func (e CompanyProxy) AddDescription(values ...string) error {
+	return e.Add("description", values, false)
+}
+
+// SetDescription replaces the values of Thing:description.
+func (e CompanyProxy) SetDescription(values ...string) error {
+	return e.Set("description", values, false)
+}
+
+// DissolutionDate returns the values of LegalEntity:dissolutionDate (Dissolution date).
+func (e CompanyProxy) DissolutionDate() []string { return e.Get("dissolutionDate") }
+
+// AddDissolutionDate adds values to LegalEntity:dissolutionDate.
+func (e CompanyProxy) AddDissolutionDate(values ...string) error {
+	return e.Add("dissolutionDate", values, false)
+}
+
+// SetDissolutionDate replaces the values of LegalEntity:dissolutionDate.
+func (e CompanyProxy) SetDissolutionDate(values ...string) error {
+	return e.Set("dissolutionDate", values, false)
+}
+
+// Email returns the values of LegalEntity:email (E-Mail).
+func (e CompanyProxy) Email() []string { return e.Get("email") }
+
+// AddEmail adds values to LegalEntity:email.
+func (e CompanyProxy) AddEmail(values ...string) error { return e.Add("email", values, false) }
+
+// SetEmail replaces the values of LegalEntity:email.
+func (e CompanyProxy) SetEmail(values ...string) error { return e.Set("email", values, false) }
+
+// FssCode returns the values of Company:fssCode (FSS).
+func (e CompanyProxy) FssCode() []string { return e.Get("fssCode") }
+
+// AddFssCode adds values to Company:fssCode.
+func (e CompanyProxy) AddFssCode(values ...string) error { return e.Add("fssCode", values, false) }
+
+// SetFssCode replaces the values of Company:fssCode.
+func (e CompanyProxy) SetFssCode(values ...string) error { return e.Set("fssCode", values, false) }
+
+// FnsCode returns the values of Company:fnsCode (Federal tax service code).
+func (e CompanyProxy) FnsCode() []string { return e.Get("fnsCode") }
+
+// AddFnsCode adds values to Company:fnsCode.
+func (e CompanyProxy) AddFnsCode(values ...string) error { return e.Add("fnsCode", values, false) }
+
+// SetFnsCode replaces the values of Company:fnsCode.
+func (e CompanyProxy) SetFnsCode(values ...string) error { return e.Set("fnsCode", values, false) }
+
+// GiiNumber returns the values of Organization:giiNumber (GIIN).
+func (e CompanyProxy) GiiNumber() []string { return e.Get("giiNumber") }
+
+// AddGiiNumber adds values to Organization:giiNumber.
+func (e CompanyProxy) AddGiiNumber(values ...string) error { return e.Add("giiNumber", values, false) }
+
+// SetGiiNumber replaces the values of Organization:giiNumber.
+func (e CompanyProxy) SetGiiNumber(values ...string) error { return e.Set("giiNumber", values, false) }
+
+// IcijId returns the values of LegalEntity:icijId (ICIJ ID).
+func (e CompanyProxy) IcijId() []string { return e.Get("icijId") }
+
+// AddIcijId adds values to LegalEntity:icijId.
+func (e CompanyProxy) AddIcijId(values ...string) error { return e.Add("icijId", values, false) }
+
+// SetIcijId replaces the values of LegalEntity:icijId.
+func (e CompanyProxy) SetIcijId(values ...string) error { return e.Set("icijId", values, false) }
+
+// IdNumber returns the values of LegalEntity:idNumber (ID Number).
+func (e CompanyProxy) IdNumber() []string { return e.Get("idNumber") }
+
+// AddIdNumber adds values to LegalEntity:idNumber.
+func (e CompanyProxy) AddIdNumber(values ...string) error { return e.Add("idNumber", values, false) }
+
+// SetIdNumber replaces the values of LegalEntity:idNumber.
+func (e CompanyProxy) SetIdNumber(values ...string) error { return e.Set("idNumber", values, false) }
+
+// ImoNumber returns the values of Organization:imoNumber (IMO Number).
+func (e CompanyProxy) ImoNumber() []string { return e.Get("imoNumber") }
+
+// AddImoNumber adds values to Organization:imoNumber.
+func (e CompanyProxy) AddImoNumber(values ...string) error { return e.Add("imoNumber", values, false) }
+
+// SetImoNumber replaces the values of Organization:imoNumber.
+func (e CompanyProxy) SetImoNumber(values ...string) error { return e.Set("imoNumber", values, false) }
+
+// InnCode returns the values of LegalEntity:innCode (INN).
+func (e CompanyProxy) InnCode() []string { return e.Get("innCode") }
+
+// AddInnCode adds values to LegalEntity:innCode.
+func (e CompanyProxy) AddInnCode(values ...string) error { return e.Add("innCode", values, false) }
+
+// SetInnCode replaces the values of LegalEntity:innCode.
+func (e CompanyProxy) SetInnCode(values ...string) error { return e.Set("innCode", values, false) }
+
+// IpoCode returns the values of Company:ipoCode (IPO).
+func (e CompanyProxy) IpoCode() []string { return e.Get("ipoCode") }
+
+// AddIpoCode adds values to Company:ipoCode.
+func (e CompanyProxy) AddIpoCode(values ...string) error { return e.Add("ipoCode", values, false) }
+
+// SetIpoCode replaces the values of Company:ipoCode.
+func (e CompanyProxy) SetIpoCode(values ...string) error { return e.Set("ipoCode", values, false) }
+
+// IrsCode returns the values of Company:irsCode (IRS Number).
+func (e CompanyProxy) IrsCode() []string { return e.Get("irsCode") }
+
+// AddIrsCode adds values to Company:irsCode.
+func (e CompanyProxy) AddIrsCode(values ...string) error { return e.Add("irsCode", values, false) }
+
+// SetIrsCode replaces the values of Company:irsCode.
+func (e CompanyProxy) SetIrsCode(values ...string) error { return e.Set("irsCode", values, false) }
+
+// IsinCode returns the values of Company:isinCode (ISIN).
+func (e CompanyProxy) IsinCode() []string { return e.Get("isinCode") }
+
+// AddIsinCode adds values to Company:isinCode.
+func (e CompanyProxy) AddIsinCode(values ...string) error { return e.Add("isinCode", values, false) }
+
+// SetIsinCode replaces the values of Company:isinCode.
+func (e CompanyProxy) SetIsinCode(values ...string) error { return e.Set("isinCode", values, false) }
+
+// JibCode returns the values of Company:jibCode (JIB).
+func (e CompanyProxy) JibCode() []string { return e.Get("jibCode") }
+
+// AddJibCode adds values to Company:jibCode.
+func (e CompanyProxy) AddJibCode(values ...string) error { return e.Add("jibCode", values, false) }
+
+// SetJibCode replaces the values of Company:jibCode.
+func (e CompanyProxy) SetJibCode(values ...string) error { return e.Set("jibCode", values, false) }
+
+// KppCode returns the values of Company:kppCode (KPP).
+func (e CompanyProxy) KppCode() []string { return e.Get("kppCode") }
+
+// AddKppCode adds values to Company:kppCode.
+func (e CompanyProxy) AddKppCode(values ...string) error { return e.Add("kppCode", values, false) }
+
+// SetKppCode replaces the values of Company:kppCode.
+func (e CompanyProxy) SetKppCode(values ...string) error { return e.Set("kppCode", values, false) }
+
+// Keywords returns the values of Thing:keywords (Keywords).
+func (e CompanyProxy) Keywords() []string { return e.Get("keywords") }
+
+// AddKeywords adds values to Thing:keywords.
+func (e CompanyProxy) AddKeywords(values ...string) error { return e.Add("keywords", values, false) }
+
+// SetKeywords replaces the values of Thing:keywords.
+func (e CompanyProxy) SetKeywords(values ...string) error { return e.Set("keywords", values, false) }
+
+// LeiCode returns the values of LegalEntity:leiCode (LEI).
+func (e CompanyProxy) LeiCode() []string { return e.Get("leiCode") }
+
+// AddLeiCode adds values to LegalEntity:leiCode.
+func (e CompanyProxy) AddLeiCode(values ...string) error { return e.Add("leiCode", values, false) }
+
+// SetLeiCode replaces the values of LegalEntity:leiCode.
+func (e CompanyProxy) SetLeiCode(values ...string) error { return e.Set("leiCode", values, false) }
+
+// LegalForm returns the values of LegalEntity:legalForm (Legal form).
+func (e CompanyProxy) LegalForm() []string { return e.Get("legalForm") }
+
+// AddLegalForm adds values to LegalEntity:legalForm.
+func (e CompanyProxy) AddLegalForm(values ...string) error { return e.Add("legalForm", values, false) }
+
+// SetLegalForm replaces the values of LegalEntity:legalForm.
+func (e CompanyProxy) SetLegalForm(values ...string) error { return e.Set("legalForm", values, false) }
+
+// LicenseNumber returns the values of LegalEntity:licenseNumber (License Number).
+func (e CompanyProxy) LicenseNumber() []string { return e.Get("licenseNumber") }
+
+// AddLicenseNumber adds values to LegalEntity:licenseNumber.
+func (e CompanyProxy) AddLicenseNumber(values ...string) error {
+	return e.Add("licenseNumber", values, false)
+}
+
+// SetLicenseNumber replaces the values of LegalEntity:licenseNumber.
+func (e CompanyProxy) SetLicenseNumber(values ...string) error {
+	return e.Set("licenseNumber", values, false)
+}
+
+// MbsCode returns the values of Company:mbsCode (MBS).
+func (e CompanyProxy) MbsCode() []string { return e.Get("mbsCode") }
+
+// AddMbsCode adds values to Company:mbsCode.
+func (e CompanyProxy) AddMbsCode(values ...string) error { return e.Add("mbsCode", values, false) }
+
+// SetMbsCode replaces the values of Company:mbsCode.
+func (e CompanyProxy) SetMbsCode(values ...string) error { return e.Set("mbsCode", values, false) }
+
+// ModifiedAt returns the values of Thing:modifiedAt (Modified on).
+func (e CompanyProxy) ModifiedAt() []string { return e.Get("modifiedAt") }
+
+// AddModifiedAt adds values to Thing:modifiedAt.
+func (e CompanyProxy) AddModifiedAt(values ...string) error {
+	return e.Add("modifiedAt", values, false)
+}
+
+// SetModifiedAt replaces the values of Thing:modifiedAt.
+func (e CompanyProxy) SetModifiedAt(values ...string) error {
+	return e.Set("modifiedAt", values, false)
+}
+
+// NpiCode returns the values of LegalEntity:npiCode (NPI).
+func (e CompanyProxy) NpiCode() []string { return e.Get("npiCode") }
+
+// AddNpiCode adds values to LegalEntity:npiCode.
+func (e CompanyProxy) AddNpiCode(values ...string) error { return e.Add("npiCode", values, false) }
+
+// SetNpiCode replaces the values of LegalEntity:npiCode.
+func (e CompanyProxy) SetNpiCode(values ...string) error { return e.Set("npiCode", values, false) }
+
+// Notes returns the values of Thing:notes (Notes).
+func (e CompanyProxy) Notes() []string { return e.Get("notes") }
+
+// AddNotes adds values to Thing:notes.
+func (e CompanyProxy) AddNotes(values ...string) error { return e.Add("notes", values, false) }
+
+// SetNotes replaces the values of Thing:notes.
+func (e CompanyProxy) SetNotes(values ...string) error { return e.Set("notes", values, false) }
+
+// OgrnCode returns the values of LegalEntity:ogrnCode (OGRN).
+func (e CompanyProxy) OgrnCode() []string { return e.Get("ogrnCode") }
+
+// AddOgrnCode adds values to LegalEntity:ogrnCode.
+func (e CompanyProxy) AddOgrnCode(values ...string) error { return e.Add("ogrnCode", values, false) }
+
+// SetOgrnCode replaces the values of LegalEntity:ogrnCode.
+func (e CompanyProxy) SetOgrnCode(values ...string) error { return e.Set("ogrnCode", values, false) }
+
+// OkopfCode returns the values of Company:okopfCode (OKOPF).
+func (e CompanyProxy) OkopfCode() []string { return e.Get("okopfCode") }
+
+// AddOkopfCode adds values to Company:okopfCode.
+func (e CompanyProxy) AddOkopfCode(values ...string) error { return e.Add("okopfCode", values, false) }
+
+// SetOkopfCode replaces the values of Company:okopfCode.
+func (e CompanyProxy) SetOkopfCode(values ...string) error { return e.Set("okopfCode", values, false) }
+
+// OkpoCode returns the values of LegalEntity:okpoCode (OKPO).
+func (e CompanyProxy) OkpoCode() []string { return e.Get("okpoCode") }
+
+// AddOkpoCode adds values to LegalEntity:okpoCode.
+func (e CompanyProxy) AddOkpoCode(values ...string) error { return e.Add("okpoCode", values, false) }
+
+// SetOkpoCode replaces the values of LegalEntity:okpoCode.
+func (e CompanyProxy) SetOkpoCode(values ...string) error { return e.Set("okpoCode", values, false) }
+
+// OksmCode returns the values of Company:oksmCode (OKSM).
+func (e CompanyProxy) OksmCode() []string { return e.Get("oksmCode") }
+
+// AddOksmCode adds values to Company:oksmCode.
+func (e CompanyProxy) AddOksmCode(values ...string) error { return e.Add("oksmCode", values, false) }
+
+// SetOksmCode replaces the values of Company:oksmCode.
+func (e CompanyProxy) SetOksmCode(values ...string) error { return e.Set("oksmCode", values, false) }
+
+// OkvedCode returns the values of Company:okvedCode (OKVED(2) Classifier).
+func (e CompanyProxy) OkvedCode() []string { return e.Get("okvedCode") }
+
+// AddOkvedCode adds values to Company:okvedCode.
+func (e CompanyProxy) AddOkvedCode(values ...string) error { return e.Add("okvedCode", values, false) }
+
+// SetOkvedCode replaces the values of Company:okvedCode.
+func (e CompanyProxy) SetOkvedCode(values ...string) error { return e.Set("okvedCode", values, false) }
+
+// OpencorporatesUrl returns the values of LegalEntity:opencorporatesUrl (OpenCorporates URL).
+func (e CompanyProxy) OpencorporatesUrl() []string { return e.Get("opencorporatesUrl") }
+
+// AddOpencorporatesUrl adds values to LegalEntity:opencorporatesUrl.
+func (e CompanyProxy) AddOpencorporatesUrl(values ...string) error {
+	return e.Add("opencorporatesUrl", values, false)
+}
+
+// SetOpencorporatesUrl replaces the values of LegalEntity:opencorporatesUrl.
+func (e CompanyProxy) SetOpencorporatesUrl(values ...string) error {
+	return e.Set("opencorporatesUrl", values, false)
+}
+
+// Alias returns the values of Thing:alias (Other name).
+func (e CompanyProxy) Alias() []string { return e.Get("alias") }
+
+// AddAlias adds values to Thing:alias.
+func (e CompanyProxy) AddAlias(values ...string) error { return e.Add("alias", values, false) }
+
+// SetAlias replaces the values of Thing:alias.
+func (e CompanyProxy) SetAlias(values ...string) error { return e.Set("alias", values, false) }
+
+// PfrNumber returns the values of Company:pfrNumber (PFR Number).
+func (e CompanyProxy) PfrNumber() []string { return e.Get("pfrNumber") }
+
+// AddPfrNumber adds values to Company:pfrNumber.
+func (e CompanyProxy) AddPfrNumber(values ...string) error { return e.Add("pfrNumber", values, false) }
+
+// SetPfrNumber replaces the values of Company:pfrNumber.
+func (e CompanyProxy) SetPfrNumber(values ...string) error { return e.Set("pfrNumber", values, false) }
+
+// Parent returns the values of LegalEntity:parent (Parent company).
+//
+// Deprecated: LegalEntity:parent is deprecated in the model.
+func (e CompanyProxy) Parent() []string { return e.Get("parent") }
+
+// AddParent adds values to LegalEntity:parent.
+func (e CompanyProxy) AddParent(values ...string) error { return e.Add("parent", values, false) }
+
+// SetParent replaces the values of LegalEntity:parent.
+func (e CompanyProxy) SetParent(values ...string) error { return e.Set("parent", values, false) }
+
+// PermId returns the values of Organization:permId (PermID).
+func (e CompanyProxy) PermId() []string { return e.Get("permId") }
+
+// AddPermId adds values to Organization:permId.
+func (e CompanyProxy) AddPermId(values ...string) error { return e.Add("permId", values, false) }
+
+// SetPermId replaces the values of Organization:permId.
+func (e CompanyProxy) SetPermId(values ...string) error { return e.Set("permId", values, false) }
+
+// Phone returns the values of LegalEntity:phone (Phone).
+func (e CompanyProxy) Phone() []string { return e.Get("phone") }
+
+// AddPhone adds values to LegalEntity:phone.
+func (e CompanyProxy) AddPhone(values ...string) error { return e.Add("phone", values, false) }
+
+// SetPhone replaces the values of LegalEntity:phone.
+func (e CompanyProxy) SetPhone(values ...string) error { return e.Set("phone", values, false) }
+
+// PreviousName returns the values of Thing:previousName (Previous name).
+func (e CompanyProxy) PreviousName() []string { return e.Get("previousName") }
+
+// AddPreviousName adds values to Thing:previousName.
+func (e CompanyProxy) AddPreviousName(values ...string) error {
+	return e.Add("previousName", values, false)
+}
+
+// SetPreviousName replaces the values of Thing:previousName.
+func (e CompanyProxy) SetPreviousName(values ...string) error {
+	return e.Set("previousName", values, false)
+}
+
+// Program returns the values of Thing:program (Program).
+func (e CompanyProxy) Program() []string { return e.Get("program") }
+
+// AddProgram adds values to Thing:program.
+func (e CompanyProxy) AddProgram(values ...string) error { return e.Add("program", values, false) }
+
+// SetProgram replaces the values of Thing:program.
+func (e CompanyProxy) SetProgram(values ...string) error { return e.Set("program", values, false) }
+
+// Publisher returns the values of Thing:publisher (Publishing source).
+func (e CompanyProxy) Publisher() []string { return e.Get("publisher") }
+
+// AddPublisher adds values to Thing:publisher.
+func (e CompanyProxy) AddPublisher(values ...string) error { return e.Add("publisher", values, false) }
+
+// SetPublisher replaces the values of Thing:publisher.
+func (e CompanyProxy) SetPublisher(values ...string) error { return e.Set("publisher", values, false) }
+
+// PublisherUrl returns the values of Thing:publisherUrl (Publishing source URL).
+func (e CompanyProxy) PublisherUrl() []string { return e.Get("publisherUrl") }
+
+// AddPublisherUrl adds values to Thing:publisherUrl.
+func (e CompanyProxy) AddPublisherUrl(values ...string) error {
+	return e.Add("publisherUrl", values, false)
+}
+
+// SetPublisherUrl replaces the values of Thing:publisherUrl.
+func (e CompanyProxy) SetPublisherUrl(values ...string) error {
+	return e.Set("publisherUrl", values, false)
+}
+
+// RetrievedAt returns the values of Thing:retrievedAt (Retrieved on).
+func (e CompanyProxy) RetrievedAt() []string { return e.Get("retrievedAt") }
+
+// AddRetrievedAt adds values to Thing:retrievedAt.
+func (e CompanyProxy) AddRetrievedAt(values ...string) error {
+	return e.Add("retrievedAt", values, false)
+}
+
+// SetRetrievedAt replaces the values of Thing:retrievedAt.
+func (e CompanyProxy) SetRetrievedAt(values ...string) error {
+	return e.Set("retrievedAt", values, false)
+}
+
+// RicCode returns the values of Company:ricCode (Reuters Instrument Code).
+func (e CompanyProxy) RicCode() []string { return e.Get("ricCode") }
+
+// AddRicCode adds values to Company:ricCode.
+func (e CompanyProxy) AddRicCode(values ...string) error { return e.Add("ricCode", values, false) }
+
+// SetRicCode replaces the values of Company:ricCode.
+func (e CompanyProxy) SetRicCode(values ...string) error { return e.Set("ricCode", values, false) }
+
+// CikCode returns the values of Company:cikCode (SEC Central Index Key).
+func (e CompanyProxy) CikCode() []string { return e.Get("cikCode") }
+
+// AddCikCode adds values to Company:cikCode.
+func (e CompanyProxy) AddCikCode(values ...string) error { return e.Add("cikCode", values, false) }
+
+// SetCikCode replaces the values of Company:cikCode.
+func (e CompanyProxy) SetCikCode(values ...string) error { return e.Set("cikCode", values, false) }
+
+// SwiftBic returns the values of LegalEntity:swiftBic (SWIFT/BIC).
+func (e CompanyProxy) SwiftBic() []string { return e.Get("swiftBic") }
+
+// AddSwiftBic adds values to LegalEntity:swiftBic.
+func (e CompanyProxy) AddSwiftBic(values ...string) error { return e.Add("swiftBic", values, false) }
+
+// SetSwiftBic replaces the values of LegalEntity:swiftBic.
+func (e CompanyProxy) SetSwiftBic(values ...string) error { return e.Set("swiftBic", values, false) }
+
+// Sector returns the values of LegalEntity:sector (Sector).
+func (e CompanyProxy) Sector() []string { return e.Get("sector") }
+
+// AddSector adds values to LegalEntity:sector.
+func (e CompanyProxy) AddSector(values ...string) error { return e.Add("sector", values, false) }
+
+// SetSector replaces the values of LegalEntity:sector.
+func (e CompanyProxy) SetSector(values ...string) error { return e.Set("sector", values, false) }
+
+// Proof returns the values of Thing:proof (Source document).
+func (e CompanyProxy) Proof() []string { return e.Get("proof") }
+
+// AddProof adds values to Thing:proof.
+func (e CompanyProxy) AddProof(values ...string) error { return e.Add("proof", values, false) }
+
+// SetProof replaces the values of Thing:proof.
+func (e CompanyProxy) SetProof(values ...string) error { return e.Set("proof", values, false) }
+
+// SourceUrl returns the values of Thing:sourceUrl (Source link).
+func (e CompanyProxy) SourceUrl() []string { return e.Get("sourceUrl") }
+
+// AddSourceUrl adds values to Thing:sourceUrl.
+func (e CompanyProxy) AddSourceUrl(values ...string) error { return e.Add("sourceUrl", values, false) }
+
+// SetSourceUrl replaces the values of Thing:sourceUrl.
+func (e CompanyProxy) SetSourceUrl(values ...string) error { return e.Set("sourceUrl", values, false) }
+
+// Status returns the values of LegalEntity:status (Status).
+func (e CompanyProxy) Status() []string { return e.Get("status") }
+
+// AddStatus adds values to LegalEntity:status.
+func (e CompanyProxy) AddStatus(values ...string) error { return e.Add("status", values, false) }
+
+// SetStatus replaces the values of LegalEntity:status.
+func (e CompanyProxy) SetStatus(values ...string) error { return e.Set("status", values, false) }
+
+// Ticker returns the values of Company:ticker (Stock ticker symbol).
+func (e CompanyProxy) Ticker() []string { return e.Get("ticker") }
+
+// AddTicker adds values to Company:ticker.
+func (e CompanyProxy) AddTicker(values ...string) error { return e.Add("ticker", values, false) }
+
+// SetTicker replaces the values of Company:ticker.
+func (e CompanyProxy) SetTicker(values ...string) error { return e.Set("ticker", values, false) }
+
+// Summary returns the values of Thing:summary (Summary).
+func (e CompanyProxy) Summary() []string { return e.Get("summary") }
+
+// AddSummary adds values to Thing:summary.
+func (e CompanyProxy) AddSummary(values ...string) error { return e.Add("summary", values, false) }
+
+// SetSummary replaces the values of Thing:summary.
+func (e CompanyProxy) SetSummary(values ...string) error { return e.Set("summary", values, false) }
+
+// TaxNumber returns the values of LegalEntity:taxNumber (Tax Number).
+func (e CompanyProxy) TaxNumber() []string { return e.Get("taxNumber") }
+
+// AddTaxNumber adds values to LegalEntity:taxNumber.
+func (e CompanyProxy) AddTaxNumber(values ...string) error { return e.Add("taxNumber", values, false) }
+
+// SetTaxNumber replaces the values of LegalEntity:taxNumber.
+func (e CompanyProxy) SetTaxNumber(values ...string) error { return e.Set("taxNumber", values, false) }
+
+// TaxStatus returns the values of LegalEntity:taxStatus (Tax status).
+func (e CompanyProxy) TaxStatus() []string { return e.Get("taxStatus") }
+
+// AddTaxStatus adds values to LegalEntity:taxStatus.
+func (e CompanyProxy) AddTaxStatus(values ...string) error { return e.Add("taxStatus", values, false) }
+
+// SetTaxStatus replaces the values of LegalEntity:taxStatus.
+func (e CompanyProxy) SetTaxStatus(values ...string) error { return e.Set("taxStatus", values, false) }
+
+// Topics returns the values of Thing:topics (Topics).
+func (e CompanyProxy) Topics() []string { return e.Get("topics") }
+
+// AddTopics adds values to Thing:topics.
+func (e CompanyProxy) AddTopics(values ...string) error { return e.Add("topics", values, false) }
+
+// SetTopics replaces the values of Thing:topics.
+func (e CompanyProxy) SetTopics(values ...string) error { return e.Set("topics", values, false) }
+
+// UscCode returns the values of LegalEntity:uscCode (USCC).
+func (e CompanyProxy) UscCode() []string { return e.Get("uscCode") }
+
+// AddUscCode adds values to LegalEntity:uscCode.
+func (e CompanyProxy) AddUscCode(values ...string) error { return e.Add("uscCode", values, false) }
+
+// SetUscCode replaces the values of LegalEntity:uscCode.
+func (e CompanyProxy) SetUscCode(values ...string) error { return e.Set("uscCode", values, false) }
+
+// UniqueEntityId returns the values of LegalEntity:uniqueEntityId (Unique Entity ID).
+func (e CompanyProxy) UniqueEntityId() []string { return e.Get("uniqueEntityId") }
+
+// AddUniqueEntityId adds values to LegalEntity:uniqueEntityId.
+func (e CompanyProxy) AddUniqueEntityId(values ...string) error {
+	return e.Add("uniqueEntityId", values, false)
+}
+
+// SetUniqueEntityId replaces the values of LegalEntity:uniqueEntityId.
+func (e CompanyProxy) SetUniqueEntityId(values ...string) error {
+	return e.Set("uniqueEntityId", values, false)
+}
+
+// VatCode returns the values of LegalEntity:vatCode (V.A.T. Identifier).
+func (e CompanyProxy) VatCode() []string { return e.Get("vatCode") }
+
+// AddVatCode adds values to LegalEntity:vatCode.
+func (e CompanyProxy) AddVatCode(values ...string) error { return e.Add("vatCode", values, false) }
+
+// SetVatCode replaces the values of LegalEntity:vatCode.
+func (e CompanyProxy) SetVatCode(values ...string) error { return e.Set("vatCode", values, false) }
+
+// VoenCode returns the values of Company:voenCode (VOEN).
+func (e CompanyProxy) VoenCode() []string { return e.Get("voenCode") }
+
+// AddVoenCode adds values to Company:voenCode.
+func (e CompanyProxy) AddVoenCode(values ...string) error { return e.Add("voenCode", values, false) }
+
+// SetVoenCode replaces the values of Company:voenCode.
+func (e CompanyProxy) SetVoenCode(values ...string) error { return e.Set("voenCode", values, false) }
+
+// WeakAlias returns the values of Thing:weakAlias (Weak alias).
+func (e CompanyProxy) WeakAlias() []string { return e.Get("weakAlias") }
+
+// AddWeakAlias adds values to Thing:weakAlias.
+func (e CompanyProxy) AddWeakAlias(values ...string) error { return e.Add("weakAlias", values, false) }
+
+// SetWeakAlias replaces the values of Thing:weakAlias.
+func (e CompanyProxy) SetWeakAlias(values ...string) error { return e.Set("weakAlias", values, false) }
+
+// Website returns the values of LegalEntity:website (Website).
+func (e CompanyProxy) Website() []string { return e.Get("website") }
+
+// AddWebsite adds values to LegalEntity:website.
+func (e CompanyProxy) AddWebsite(values ...string) error { return e.Add("website", values, false) }
+
+// SetWebsite replaces the values of LegalEntity:website.
+func (e CompanyProxy) SetWebsite(values ...string) error { return e.Set("website", values, false) }
+
+// WikidataId returns the values of Thing:wikidataId (Wikidata ID).
+func (e CompanyProxy) WikidataId() []string { return e.Get("wikidataId") }
+
+// AddWikidataId adds values to Thing:wikidataId.
+func (e CompanyProxy) AddWikidataId(values ...string) error {
+	return e.Add("wikidataId", values, false)
+}
+
+// SetWikidataId replaces the values of Thing:wikidataId.
+func (e CompanyProxy) SetWikidataId(values ...string) error {
+	return e.Set("wikidataId", values, false)
+}
+
+// WikipediaUrl returns the values of Thing:wikipediaUrl (Wikipedia Article).
+func (e CompanyProxy) WikipediaUrl() []string { return e.Get("wikipediaUrl") }
+
+// AddWikipediaUrl adds values to Thing:wikipediaUrl.
+func (e CompanyProxy) AddWikipediaUrl(values ...string) error {
+	return e.Add("wikipediaUrl", values, false)
+}
+
+// SetWikipediaUrl replaces the values of Thing:wikipediaUrl.
+func (e CompanyProxy) SetWikipediaUrl(values ...string) error {
+	return e.Set("wikipediaUrl", values, false)
+}
+
+// AddressProxy is a typed wrapper of an entity of schema Address (Address).
+type AddressProxy struct{ *EntityProxy }
+
+// NewAddress creates an empty Address entity in model m.
+func NewAddress(m *Model, id string) AddressProxy {
+	return AddressProxy{NewEntityProxy(m.Get("Address"), id)}
+}
+
+// AsAddress wraps e if its schema is or extends Address.
+func AsAddress(e *EntityProxy) (AddressProxy, bool) {
+	if e == nil || e.Schema == nil || !e.Schema.IsA("Address") {
+		return AddressProxy{}, false
+	}
+	return AddressProxy{e}, true
+}
+
+// Full returns the values of Address:full (Full address).
+func (e AddressProxy) Full() []string { return e.Get("full") }
+
+// AddFull adds values to Address:full.
+func (e AddressProxy) AddFull(values ...string) error { return e.Add("full", values, false) }
+
+// SetFull replaces the values of Address:full.
+func (e AddressProxy) SetFull(values ...string) error { return e.Set("full", values, false) }
+
+// Summary returns the values of Thing:summary (Summary).
+func (e AddressProxy) Summary() []string { return e.Get("summary") }
+
+// AddSummary adds values to Thing:summary.
+func (e AddressProxy) AddSummary(values ...string) error { return e.Add("summary", values, false) }
+
+// SetSummary replaces the values of Thing:summary.
+func (e AddressProxy) SetSummary(values ...string) error { return e.Set("summary", values, false) }
+
+// City returns the values of Address:city (City).
+func (e AddressProxy) City() []string { return e.Get("city") }
+
+// AddCity adds values to Address:city.
+func (e AddressProxy) AddCity(values ...string) error { return e.Add("city", values, false) }
+
+// SetCity replaces the values of Address:city.
+func (e AddressProxy) SetCity(values ...string) error { return e.Set("city", values, false) }
+
+// Remarks returns the values of Address:remarks (Remarks).
+func (e AddressProxy) Remarks() []string { return e.Get("remarks") }
+
+// AddRemarks adds values to Address:remarks.
+func (e AddressProxy) AddRemarks(values ...string) error { return e.Add("remarks", values, false) }
+
+// SetRemarks replaces the values of Address:remarks.
+func (e AddressProxy) SetRemarks(values ...string) error { return e.Set("remarks", values, false) }
+
+// Street returns the values of Address:street (Street address).
+func (e AddressProxy) Street() []string { return e.Get("street") }
+
+// AddStreet adds values to Address:street.
+func (e AddressProxy) AddStreet(values ...string) error { return e.Add("street", values, false) }
+
+// SetStreet replaces the values of Address:street.
+func (e AddressProxy) SetStreet(values ...string) error { return e.Set("street", values, false) }
+
+// Country returns the values of Address:country (Country).
+func (e AddressProxy) Country() []string { return e.Get("country") }
+
+// AddCountry adds values to Address:country.
+func (e AddressProxy) AddCountry(values ...string) error { return e.Add("country", values, false) }
+
+// SetCountry replaces the values of Address:country.
+func (e AddressProxy) SetCountry(values ...string) error { return e.Set("country", values, false) }
+
+// Address returns the values of Thing:address (Address).
+func (e AddressProxy) Address() []string { return e.Get("address") }
+
+// AddAddress adds values to Thing:address.
+func (e AddressProxy) AddAddress(values ...string) error { return e.Add("address", values, false) }
+
+// SetAddress replaces the values of Thing:address.
+func (e AddressProxy) SetAddress(values ...string) error { return e.Set("address", values, false) }
+
+// AddressEntity returns the values of Thing:addressEntity (Address).
+func (e AddressProxy) AddressEntity() []string { return e.Get("addressEntity") }
+
+// AddAddressEntity adds values to Thing:addressEntity.
+func (e AddressProxy) AddAddressEntity(values ...string) error {
+	return e.Add("addressEntity", values, false)
+}
+
+// SetAddressEntity replaces the values of Thing:addressEntity.
+func (e AddressProxy) SetAddressEntity(values ...string) error {
+	return e.Set("addressEntity", values, false)
+}
+
+// CreatedAt returns the values of Thing:createdAt (Created at).
+func (e AddressProxy) CreatedAt() []string { return e.Get("createdAt") }
+
+// AddCreatedAt adds values to Thing:createdAt.
+func (e AddressProxy) AddCreatedAt(values ...string) error { return e.Add("createdAt", values, false) }
+
+// SetCreatedAt replaces the values of Thing:createdAt.
+func (e AddressProxy) SetCreatedAt(values ...string) error { return e.Set("createdAt", values, false) }
+
+// Description returns the values of Thing:description (Description).
+func (e AddressProxy) Description() []string { return e.Get("description") }
+
+// AddDescription adds values to Thing:description.
+func (e AddressProxy) AddDescription(values ...string) error {
+	return e.Add("description", values, false)
+}
+
+// SetDescription replaces the values of Thing:description.
+func (e AddressProxy) SetDescription(values ...string) error {
+	return e.Set("description", values, false)
+}
+
+// GooglePlaceId returns the values of Address:googlePlaceId (Google Places ID).
+func (e AddressProxy) GooglePlaceId() []string { return e.Get("googlePlaceId") }
+
+// AddGooglePlaceId adds values to Address:googlePlaceId.
+func (e AddressProxy) AddGooglePlaceId(values ...string) error {
+	return e.Add("googlePlaceId", values, false)
+}
+
+// SetGooglePlaceId replaces the values of Address:googlePlaceId.
+func (e AddressProxy) SetGooglePlaceId(values ...string) error {
+	return e.Set("googlePlaceId", values, false)
+}
+
+// Keywords returns the values of Thing:keywords (Keywords).
+func (e AddressProxy) Keywords() []string { return e.Get("keywords") }
+
+// AddKeywords adds values to Thing:keywords.
+func (e AddressProxy) AddKeywords(values ...string) error { return e.Add("keywords", values, false) }
+
+// SetKeywords replaces the values of Thing:keywords.
+func (e AddressProxy) SetKeywords(values ...string) error { return e.Set("keywords", values, false) }
+
+// Latitude returns the values of Address:latitude (Latitude).
+func (e AddressProxy) Latitude() []string { return e.Get("latitude") }
+
+// AddLatitude adds values to Address:latitude.
+func (e AddressProxy) AddLatitude(values ...string) error { return e.Add("latitude", values, false) }
+
+// SetLatitude replaces the values of Address:latitude.
+func (e AddressProxy) SetLatitude(values ...string) error { return e.Set("latitude", values, false) }
+
+// Longitude returns the values of Address:longitude (Longitude).
+func (e AddressProxy) Longitude() []string { return e.Get("longitude") }
+
+// AddLongitude adds values to Address:longitude.
+func (e AddressProxy) AddLongitude(values ...string) error { return e.Add("longitude", values, false) }
+
+// SetLongitude replaces the values of Address:longitude.
+func (e AddressProxy) SetLongitude(values ...string) error { return e.Set("longitude", values, false) }
+
+// ModifiedAt returns the values of Thing:modifiedAt (Modified on).
+func (e AddressProxy) ModifiedAt() []string { return e.Get("modifiedAt") }
+
+// AddModifiedAt adds values to Thing:modifiedAt.
+func (e AddressProxy) AddModifiedAt(values ...string) error {
+	return e.Add("modifiedAt", values, false)
+}
+
+// SetModifiedAt replaces the values of Thing:modifiedAt.
+func (e AddressProxy) SetModifiedAt(values ...string) error {
+	return e.Set("modifiedAt", values, false)
+}
+
+// Name returns the values of Thing:name (Name).
+func (e AddressProxy) Name() []string { return e.Get("name") }
+
+// AddName adds values to Thing:name.
+func (e AddressProxy) AddName(values ...string) error { return e.Add("name", values, false) }
+
+// SetName replaces the values of Thing:name.
+func (e AddressProxy) SetName(values ...string) error { return e.Set("name", values, false) }
+
+// Notes returns the values of Thing:notes (Notes).
+func (e AddressProxy) Notes() []string { return e.Get("notes") }
+
+// AddNotes adds values to Thing:notes.
+func (e AddressProxy) AddNotes(values ...string) error { return e.Add("notes", values, false) }
+
+// SetNotes replaces the values of Thing:notes.
+func (e AddressProxy) SetNotes(values ...string) error { return e.Set("notes", values, false) }
+
+// OsmId returns the values of Address:osmId (OpenStreetmap Place ID).
+func (e AddressProxy) OsmId() []string { return e.Get("osmId") }
+
+// AddOsmId adds values to Address:osmId.
+func (e AddressProxy) AddOsmId(values ...string) error { return e.Add("osmId", values, false) }
+
+// SetOsmId replaces the values of Address:osmId.
+func (e AddressProxy) SetOsmId(values ...string) error { return e.Set("osmId", values, false) }
+
+// Alias returns the values of Thing:alias (Other name).
+func (e AddressProxy) Alias() []string { return e.Get("alias") }
+
+// AddAlias adds values to Thing:alias.
+func (e AddressProxy) AddAlias(values ...string) error { return e.Add("alias", values, false) }
+
+// SetAlias replaces the values of Thing:alias.
+func (e AddressProxy) SetAlias(values ...string) error { return e.Set("alias", values, false) }
+
+// PostOfficeBox returns the values of Address:postOfficeBox (PO Box).
+func (e AddressProxy) PostOfficeBox() []string { return e.Get("postOfficeBox") }
+
+// AddPostOfficeBox adds values to Address:postOfficeBox.
+func (e AddressProxy) AddPostOfficeBox(values ...string) error {
+	return e.Add("postOfficeBox", values, false)
+}
+
+// SetPostOfficeBox replaces the values of Address:postOfficeBox.
+func (e AddressProxy) SetPostOfficeBox(values ...string) error {
+	return e.Set("postOfficeBox", values, false)
+}
+
+// PostalCode returns the values of Address:postalCode (Postal code).
+func (e AddressProxy) PostalCode() []string { return e.Get("postalCode") }
+
+// AddPostalCode adds values to Address:postalCode.
+func (e AddressProxy) AddPostalCode(values ...string) error {
+	return e.Add("postalCode", values, false)
+}
+
+// SetPostalCode replaces the values of Address:postalCode.
+func (e AddressProxy) SetPostalCode(values ...string) error {
+	return e.Set("postalCode", values, false)
+}
+
+// PreviousName returns the values of Thing:previousName (Previous name).
+func (e AddressProxy) PreviousName() []string { return e.Get("previousName") }
+
+// AddPreviousName adds values to Thing:previousName.
+func (e AddressProxy) AddPreviousName(values ...string) error {
+	return e.Add("previousName", values, false)
+}
+
+// SetPreviousName replaces the values of Thing:previousName.
+func (e AddressProxy) SetPreviousName(values ...string) error {
+	return e.Set("previousName", values, false)
+}
+
+// Program returns the values of Thing:program (Program).
+func (e AddressProxy) Program() []string { return e.Get("program") }
+
+// AddProgram adds values to Thing:program.
+func (e AddressProxy) AddProgram(values ...string) error { return e.Add("program", values, false) }
+
+// SetProgram replaces the values of Thing:program.
+func (e AddressProxy) SetProgram(values ...string) error { return e.Set("program", values, false) }
+
+// Publisher returns the values of Thing:publisher (Publishing source).
+func (e AddressProxy) Publisher() []string { return e.Get("publisher") }
+
+// AddPublisher adds values to Thing:publisher.
+func (e AddressProxy) AddPublisher(values ...string) error { return e.Add("publisher", values, false) }
+
+// SetPublisher replaces the values of Thing:publisher.
+func (e AddressProxy) SetPublisher(values ...string) error { return e.Set("publisher", values, false) }
+
+// PublisherUrl returns the values of Thing:publisherUrl (Publishing source URL).
+func (e AddressProxy) PublisherUrl() []string { return e.Get("publisherUrl") }
+
+// AddPublisherUrl adds values to Thing:publisherUrl.
+func (e AddressProxy) AddPublisherUrl(values ...string) error {
+	return e.Add("publisherUrl", values, false)
+}
+
+// SetPublisherUrl replaces the values of Thing:publisherUrl.
+func (e AddressProxy) SetPublisherUrl(values ...string) error {
+	return e.Set("publisherUrl", values, false)
+}
+
+// Region returns the values of Address:region (Region).
+func (e AddressProxy) Region() []string { return e.Get("region") }
+
+// AddRegion adds values to Address:region.
+func (e AddressProxy) AddRegion(values ...string) error { return e.Add("region", values, false) }
+
+// SetRegion replaces the values of Address:region.
+func (e AddressProxy) SetRegion(values ...string) error { return e.Set("region", values, false) }
+
+// RetrievedAt returns the values of Thing:retrievedAt (Retrieved on).
+func (e AddressProxy) RetrievedAt() []string { return e.Get("retrievedAt") }
+
+// AddRetrievedAt adds values to Thing:retrievedAt.
+func (e AddressProxy) AddRetrievedAt(values ...string) error {
+	return e.Add("retrievedAt", values, false)
+}
+
+// SetRetrievedAt replaces the values of Thing:retrievedAt.
+func (e AddressProxy) SetRetrievedAt(values ...string) error {
+	return e.Set("retrievedAt", values, false)
+}
+
+// Proof returns the values of Thing:proof (Source document).
+func (e AddressProxy) Proof() []string { return e.Get("proof") }
+
+// AddProof adds values to Thing:proof.
+func (e AddressProxy) AddProof(values ...string) error { return e.Add("proof", values, false) }
+
+// SetProof replaces the values of Thing:proof.
+func (e AddressProxy) SetProof(values ...string) error { return e.Set("proof", values, false) }
+
+// SourceUrl returns the values of Thing:sourceUrl (Source link).
+func (e AddressProxy) SourceUrl() []string { return e.Get("sourceUrl") }
+
+// AddSourceUrl adds values to Thing:sourceUrl.
+func (e AddressProxy) AddSourceUrl(values ...string) error { return e.Add("sourceUrl", values, false) }
+
+// SetSourceUrl replaces the values of Thing:sourceUrl.
+func (e AddressProxy) SetSourceUrl(values ...string) error { return e.Set("sourceUrl", values, false) }
+
+// State returns the values of Address:state (State).
+func (e AddressProxy) State() []string { return e.Get("state") }
+
+// AddState adds values to Address:state.
+func (e AddressProxy) AddState(values ...string) error { return e.Add("state", values, false) }
+
+// SetState replaces the values of Address:state.
+func (e AddressProxy) SetState(values ...string) error { return e.Set("state", values, false) }
+
+// Street2 returns the values of Address:street2 (Street address (ctd.)).
+func (e AddressProxy) Street2() []string { return e.Get("street2") }
+
+// AddStreet2 adds values to Address:street2.
+func (e AddressProxy) AddStreet2(values ...string) error { return e.Add("street2", values, false) }
+
+// SetStreet2 replaces the values of Address:street2.
+func (e AddressProxy) SetStreet2(values ...string) error { return e.Set("street2", values, false) }
+
+// Topics returns the values of Thing:topics (Topics).
+func (e AddressProxy) Topics() []string { return e.Get("topics") }
+
+// AddTopics adds values to Thing:topics.
+func (e AddressProxy) AddTopics(values ...string) error { return e.Add("topics", values, false) }
+
+// SetTopics replaces the values of Thing:topics.
+func (e AddressProxy) SetTopics(values ...string) error { return e.Set("topics", values, false) }
+
+// WeakAlias returns the values of Thing:weakAlias (Weak alias).
+func (e AddressProxy) WeakAlias() []string { return e.Get("weakAlias") }
+
+// AddWeakAlias adds values to Thing:weakAlias.
+func (e AddressProxy) AddWeakAlias(values ...string) error { return e.Add("weakAlias", values, false) }
+
+// SetWeakAlias replaces the values of Thing:weakAlias.
+func (e AddressProxy) SetWeakAlias(values ...string) error { return e.Set("weakAlias", values, false) }
+
+// WikidataId returns the values of Thing:wikidataId (Wikidata ID).
+func (e AddressProxy) WikidataId() []string { return e.Get("wikidataId") }
+
+// AddWikidataId adds values to Thing:wikidataId.
+func (e AddressProxy) AddWikidataId(values ...string) error {
+	return e.Add("wikidataId", values, false)
+}
+
+// SetWikidataId replaces the values of Thing:wikidataId.
+func (e AddressProxy) SetWikidataId(values ...string) error {
+	return e.Set("wikidataId", values, false)
+}
+
+// WikipediaUrl returns the values of Thing:wikipediaUrl (Wikipedia Article).
+func (e AddressProxy) WikipediaUrl() []string { return e.Get("wikipediaUrl") }
+
+// AddWikipediaUrl adds values to Thing:wikipediaUrl.
+func (e AddressProxy) AddWikipediaUrl(values ...string) error {
+	return e.Add("wikipediaUrl", values, false)
+}
+
+// SetWikipediaUrl replaces the values of Thing:wikipediaUrl.
+func (e AddressProxy) SetWikipediaUrl(values ...string) error {
+	return e.Set("wikipediaUrl", values, false)
+}
+
+// OwnershipProxy is a typed wrapper of an entity of schema Ownership (Ownership).
+type OwnershipProxy struct{ *EntityProxy }
+
+// NewOwnership creates an empty Ownership entity in model m.
+func NewOwnership(m *Model, id string) OwnershipProxy {
+	return OwnershipProxy{NewEntityProxy(m.Get("Ownership"), id)}
+}
+
+// AsOwnership wraps e if its schema is or extends Ownership.
+func AsOwnership(e *EntityProxy) (OwnershipProxy, bool) {
+	if e == nil || e.Schema == nil || !e.Schema.IsA("Ownership") {
+		return OwnershipProxy{}, false
+	}
+	return OwnershipProxy{e}, true
+}
+
+// Owner returns the values of Ownership:owner (Owner).
+func (e OwnershipProxy) Owner() []string { return e.Get("owner") }
+
+// AddOwner adds values to Ownership:owner.
+func (e OwnershipProxy) AddOwner(values ...string) error { return e.Add("owner", values, false) }
+
+// SetOwner replaces the values of Ownership:owner.
+func (e OwnershipProxy) SetOwner(values ...string) error { return e.Set("owner", values, false) }
+
+// Asset returns the values of Ownership:asset (Asset).
+func (e OwnershipProxy) Asset() []string { return e.Get("asset") }
+
+// AddAsset adds values to Ownership:asset.
+func (e OwnershipProxy) AddAsset(values ...string) error { return e.Add("asset", values, false) }
+
+// SetAsset replaces the values of Ownership:asset.
+func (e OwnershipProxy) SetAsset(values ...string) error { return e.Set("asset", values, false) }
+
+// Percentage returns the values of Ownership:percentage (Percentage held).
+func (e OwnershipProxy) Percentage() []string { return e.Get("percentage") }
+
+// AddPercentage adds values to Ownership:percentage.
+func (e OwnershipProxy) AddPercentage(values ...string) error {
+	return e.Add("percentage", values, false)
+}
+
+// SetPercentage replaces the values of Ownership:percentage.
+func (e OwnershipProxy) SetPercentage(values ...string) error {
+	return e.Set("percentage", values, false)
+}
+
+// StartDate returns the values of Interval:startDate (Start date).
+func (e OwnershipProxy) StartDate() []string { return e.Get("startDate") }
+
+// AddStartDate adds values to Interval:startDate.
+func (e OwnershipProxy) AddStartDate(values ...string) error {
+	return e.Add("startDate", values, false)
+}
+
+// SetStartDate replaces the values of Interval:startDate.
+func (e OwnershipProxy) SetStartDate(values ...string) error {
+	return e.Set("startDate", values, false)
+}
+
+// EndDate returns the values of Interval:endDate (End date).
+func (e OwnershipProxy) EndDate() []string { return e.Get("endDate") }
+
+// AddEndDate adds values to Interval:endDate.
+func (e OwnershipProxy) AddEndDate(values ...string) error { return e.Add("endDate", values, false) }
+
+// SetEndDate replaces the values of Interval:endDate.
+func (e OwnershipProxy) SetEndDate(values ...string) error { return e.Set("endDate", values, false) }
+
+// SharesCurrency returns the values of Ownership:sharesCurrency (Currency of shares).
+func (e OwnershipProxy) SharesCurrency() []string { return e.Get("sharesCurrency") }
+
+// AddSharesCurrency adds values to Ownership:sharesCurrency.
+func (e OwnershipProxy) AddSharesCurrency(values ...string) error {
+	return e.Add("sharesCurrency", values, false)
+}
+
+// SetSharesCurrency replaces the values of Ownership:sharesCurrency.
+func (e OwnershipProxy) SetSharesCurrency(values ...string) error {
+	return e.Set("sharesCurrency", values, false)
+}
+
+// Date returns the values of Interval:date (Date).
+func (e OwnershipProxy) Date() []string { return e.Get("date") }
+
+// AddDate adds values to Interval:date.
+func (e OwnershipProxy) AddDate(values ...string) error { return e.Add("date", values, false) }
+
+// SetDate replaces the values of Interval:date.
+func (e OwnershipProxy) SetDate(values ...string) error { return e.Set("date", values, false) }
+
+// Description returns the values of Interval:description (Description).
+func (e OwnershipProxy) Description() []string { return e.Get("description") }
+
+// AddDescription adds values to Interval:description.
+func (e OwnershipProxy) AddDescription(values ...string) error {
+	return e.Add("description", values, false)
+}
+
+// SetDescription replaces the values of Interval:description.
+func (e OwnershipProxy) SetDescription(values ...string) error {
+	return e.Set("description", values, false)
+}
+
+// LegalBasis returns the values of Ownership:legalBasis (Legal basis).
+func (e OwnershipProxy) LegalBasis() []string { return e.Get("legalBasis") }
+
+// AddLegalBasis adds values to Ownership:legalBasis.
+func (e OwnershipProxy) AddLegalBasis(values ...string) error {
+	return e.Add("legalBasis", values, false)
+}
+
+// SetLegalBasis replaces the values of Ownership:legalBasis.
+func (e OwnershipProxy) SetLegalBasis(values ...string) error {
+	return e.Set("legalBasis", values, false)
+}
+
+// ModifiedAt returns the values of Interval:modifiedAt (Modified on).
+func (e OwnershipProxy) ModifiedAt() []string { return e.Get("modifiedAt") }
+
+// AddModifiedAt adds values to Interval:modifiedAt.
+func (e OwnershipProxy) AddModifiedAt(values ...string) error {
+	return e.Add("modifiedAt", values, false)
+}
+
+// SetModifiedAt replaces the values of Interval:modifiedAt.
+func (e OwnershipProxy) SetModifiedAt(values ...string) error {
+	return e.Set("modifiedAt", values, false)
+}
+
+// SharesCount returns the values of Ownership:sharesCount (Number of shares).
+func (e OwnershipProxy) SharesCount() []string { return e.Get("sharesCount") }
+
+// AddSharesCount adds values to Ownership:sharesCount.
+func (e OwnershipProxy) AddSharesCount(values ...string) error {
+	return e.Add("sharesCount", values, false)
+}
+
+// SetSharesCount replaces the values of Ownership:sharesCount.
+func (e OwnershipProxy) SetSharesCount(values ...string) error {
+	return e.Set("sharesCount", values, false)
+}
+
+// Publisher returns the values of Interval:publisher (Publishing source).
+func (e OwnershipProxy) Publisher() []string { return e.Get("publisher") }
+
+// AddPublisher adds values to Interval:publisher.
+func (e OwnershipProxy) AddPublisher(values ...string) error {
+	return e.Add("publisher", values, false)
+}
+
+// SetPublisher replaces the values of Interval:publisher.
+func (e OwnershipProxy) SetPublisher(values ...string) error {
+	return e.Set("publisher", values, false)
+}
+
+// PublisherUrl returns the values of Interval:publisherUrl (Publishing source URL).
+func (e OwnershipProxy) PublisherUrl() []string { return e.Get("publisherUrl") }
+
+// AddPublisherUrl adds values to Interval:publisherUrl.
+func (e OwnershipProxy) AddPublisherUrl(values ...string) error {
+	return e.Add("publisherUrl", values, false)
+}
+
+// SetPublisherUrl replaces the values of Interval:publisherUrl.
+func (e OwnershipProxy) SetPublisherUrl(values ...string) error {
+	return e.Set("publisherUrl", values, false)
+}
+
+// RecordId returns the values of Interval:recordId (Record ID).
+func (e OwnershipProxy) RecordId() []string { return e.Get("recordId") }
+
+// AddRecordId adds values to Interval:recordId.
+func (e OwnershipProxy) AddRecordId(values ...string) error { return e.Add("recordId", values, false) }
+
+// SetRecordId replaces the values of Interval:recordId.
+func (e OwnershipProxy) SetRecordId(values ...string) error { return e.Set("recordId", values, false) }
+
+// RetrievedAt returns the values of Interval:retrievedAt (Retrieved on).
+func (e OwnershipProxy) RetrievedAt() []string { return e.Get("retrievedAt") }
+
+// AddRetrievedAt adds values to Interval:retrievedAt.
+func (e OwnershipProxy) AddRetrievedAt(values ...string) error {
+	return e.Add("retrievedAt", values, false)
+}
+
+// SetRetrievedAt replaces the values of Interval:retrievedAt.
+func (e OwnershipProxy) SetRetrievedAt(values ...string) error {
+	return e.Set("retrievedAt", values, false)
+}
+
+// Role returns the values of Interest:role (Role).
+func (e OwnershipProxy) Role() []string { return e.Get("role") }
+
+// AddRole adds values to Interest:role.
+func (e OwnershipProxy) AddRole(values ...string) error { return e.Add("role", values, false) }
+
+// SetRole replaces the values of Interest:role.
+func (e OwnershipProxy) SetRole(values ...string) error { return e.Set("role", values, false) }
+
+// Proof returns the values of Interval:proof (Source document).
+func (e OwnershipProxy) Proof() []string { return e.Get("proof") }
+
+// AddProof adds values to Interval:proof.
+func (e OwnershipProxy) AddProof(values ...string) error { return e.Add("proof", values, false) }
+
+// SetProof replaces the values of Interval:proof.
+func (e OwnershipProxy) SetProof(values ...string) error { return e.Set("proof", values, false) }
+
+// SourceUrl returns the values of Interval:sourceUrl (Source link).
+func (e OwnershipProxy) SourceUrl() []string { return e.Get("sourceUrl") }
+
+// AddSourceUrl adds values to Interval:sourceUrl.
+func (e OwnershipProxy) AddSourceUrl(values ...string) error {
+	return e.Add("sourceUrl", values, false)
+}
+
+// SetSourceUrl replaces the values of Interval:sourceUrl.
+func (e OwnershipProxy) SetSourceUrl(values ...string) error {
+	return e.Set("sourceUrl", values, false)
+}
+
+// Status returns the values of Interest:status (Status).
+func (e OwnershipProxy) Status() []string { return e.Get("status") }
+
+// AddStatus adds values to Interest:status.
+func (e OwnershipProxy) AddStatus(values ...string) error { return e.Add("status", values, false) }
+
+// SetStatus replaces the values of Interest:status.
+func (e OwnershipProxy) SetStatus(values ...string) error { return e.Set("status", values, false) }
+
+// Summary returns the values of Interval:summary (Summary).
+func (e OwnershipProxy) Summary() []string { return e.Get("summary") }
+
+// AddSummary adds values to Interval:summary.
+func (e OwnershipProxy) AddSummary(values ...string) error { return e.Add("summary", values, false) }
+
+// SetSummary replaces the values of Interval:summary.
+func (e OwnershipProxy) SetSummary(values ...string) error { return e.Set("summary", values, false) }
+
+// OwnershipType returns the values of Ownership:ownershipType (Type of ownership).
+func (e OwnershipProxy) OwnershipType() []string { return e.Get("ownershipType") }
+
+// AddOwnershipType adds values to Ownership:ownershipType.
+func (e OwnershipProxy) AddOwnershipType(values ...string) error {
+	return e.Add("ownershipType", values, false)
+}
+
+// SetOwnershipType replaces the values of Ownership:ownershipType.
+func (e OwnershipProxy) SetOwnershipType(values ...string) error {
+	return e.Set("ownershipType", values, false)
+}
+
+// SharesType returns the values of Ownership:sharesType (Type of shares).
+func (e OwnershipProxy) SharesType() []string { return e.Get("sharesType") }
+
+// AddSharesType adds values to Ownership:sharesType.
+func (e OwnershipProxy) AddSharesType(values ...string) error {
+	return e.Add("sharesType", values, false)
+}
+
+// SetSharesType replaces the values of Ownership:sharesType.
+func (e OwnershipProxy) SetSharesType(values ...string) error {
+	return e.Set("sharesType", values, false)
+}
+
+// SharesValue returns the values of Ownership:sharesValue (Value of shares).
+func (e OwnershipProxy) SharesValue() []string { return e.Get("sharesValue") }
+
+// AddSharesValue adds values to Ownership:sharesValue.
+func (e OwnershipProxy) AddSharesValue(values ...string) error {
+	return e.Add("sharesValue", values, false)
+}
+
+// SetSharesValue replaces the values of Ownership:sharesValue.
+func (e OwnershipProxy) SetSharesValue(values ...string) error {
+	return e.Set("sharesValue", values, false)
+}
+
+// DirectorshipProxy is a typed wrapper of an entity of schema Directorship (Directorship).
+type DirectorshipProxy struct{ *EntityProxy }
+
+// NewDirectorship creates an empty Directorship entity in model m.
+func NewDirectorship(m *Model, id string) DirectorshipProxy {
+	return DirectorshipProxy{NewEntityProxy(m.Get("Directorship"), id)}
+}
+
+// AsDirectorship wraps e if its schema is or extends Directorship.
+func AsDirectorship(e *EntityProxy) (DirectorshipProxy, bool) {
+	if e == nil || e.Schema == nil || !e.Schema.IsA("Directorship") {
+		return DirectorshipProxy{}, false
+	}
+	return DirectorshipProxy{e}, true
+}
+
+// Role returns the values of Interest:role (Role).
+func (e DirectorshipProxy) Role() []string { return e.Get("role") }
+
+// AddRole adds values to Interest:role.
+func (e DirectorshipProxy) AddRole(values ...string) error { return e.Add("role", values, false) }
+
+// SetRole replaces the values of Interest:role.
+func (e DirectorshipProxy) SetRole(values ...string) error { return e.Set("role", values, false) }
+
+// Director returns the values of Directorship:director (Director).
+func (e DirectorshipProxy) Director() []string { return e.Get("director") }
+
+// AddDirector adds values to Directorship:director.
+func (e DirectorshipProxy) AddDirector(values ...string) error {
+	return e.Add("director", values, false)
+}
+
+// SetDirector replaces the values of Directorship:director.
+func (e DirectorshipProxy) SetDirector(values ...string) error {
+	return e.Set("director", values, false)
+}
+
+// Organization returns the values of Directorship:organization (Organization).
+func (e DirectorshipProxy) Organization() []string { return e.Get("organization") }
+
+// AddOrganization adds values to Directorship:organization.
+func (e DirectorshipProxy) AddOrganization(values ...string) error {
+	return e.Add("organization", values, false)
+}
+
+// SetOrganization replaces the values of Directorship:organization.
+func (e DirectorshipProxy) SetOrganization(values ...string) error {
+	return e.Set("organization", values, false)
+}
+
+// StartDate returns the values of Interval:startDate (Start date).
+func (e DirectorshipProxy) StartDate() []string { return e.Get("startDate") }
+
+// AddStartDate adds values to Interval:startDate.
+func (e DirectorshipProxy) AddStartDate(values ...string) error {
+	return e.Add("startDate", values, false)
+}
+
+// SetStartDate replaces the values of Interval:startDate.
+func (e DirectorshipProxy) SetStartDate(values ...string) error {
+	return e.Set("startDate", values, false)
+}
+
+// EndDate returns the values of Interval:endDate (End date).
+func (e DirectorshipProxy) EndDate() []string { return e.Get("endDate") }
+
+// AddEndDate adds values to Interval:endDate.
+func (e DirectorshipProxy) AddEndDate(values ...string) error { return e.Add("endDate", values, false) }
+
+// SetEndDate replaces the values of Interval:endDate.
+func (e DirectorshipProxy) SetEndDate(values ...string) error { return e.Set("endDate", values, false) }
+
+// Date returns the values of Interval:date (Date).
+func (e DirectorshipProxy) Date() []string { return e.Get("date") }
+
+// AddDate adds values to Interval:date.
+func (e DirectorshipProxy) AddDate(values ...string) error { return e.Add("date", values, false) }
+
+// SetDate replaces the values of Interval:date.
+func (e DirectorshipProxy) SetDate(values ...string) error { return e.Set("date", values, false) }
+
+// Description returns the values of Interval:description (Description).
+func (e DirectorshipProxy) Description() []string { return e.Get("description") }
+
+// AddDescription adds values to Interval:description.
+func (e DirectorshipProxy) AddDescription(values ...string) error {
+	return e.Add("description", values, false)
+}
+
+// SetDescription replaces the values of Interval:description.
+func (e DirectorshipProxy) SetDescription(values ...string) error {
+	return e.Set("description", values, false)
+}
+
+// ModifiedAt returns the values of Interval:modifiedAt (Modified on).
+func (e DirectorshipProxy) ModifiedAt() []string { return e.Get("modifiedAt") }
+
+// AddModifiedAt adds values to Interval:modifiedAt.
+func (e DirectorshipProxy) AddModifiedAt(values ...string) error {
+	return e.Add("modifiedAt", values, false)
+}
+
+// SetModifiedAt replaces the values of Interval:modifiedAt.
+func (e DirectorshipProxy) SetModifiedAt(values ...string) error {
+	return e.Set("modifiedAt", values, false)
+}
+
+// Publisher returns the values of Interval:publisher (Publishing source).
+func (e DirectorshipProxy) Publisher() []string { return e.Get("publisher") }
+
+// AddPublisher adds values to Interval:publisher.
+func (e DirectorshipProxy) AddPublisher(values ...string) error {
+	return e.Add("publisher", values, false)
+}
+
+// SetPublisher replaces the values of Interval:publisher.
+func (e DirectorshipProxy) SetPublisher(values ...string) error {
+	return e.Set("publisher", values, false)
+}
+
+// PublisherUrl returns the values of Interval:publisherUrl (Publishing source URL).
+func (e DirectorshipProxy) PublisherUrl() []string { return e.Get("publisherUrl") }
+
+// AddPublisherUrl adds values to Interval:publisherUrl.
+func (e DirectorshipProxy) AddPublisherUrl(values ...string) error {
+	return e.Add("publisherUrl", values, false)
+}
+
+// SetPublisherUrl replaces the values of Interval:publisherUrl.
+func (e DirectorshipProxy) SetPublisherUrl(values ...string) error {
+	return e.Set("publisherUrl", values, false)
+}
+
+// RecordId returns the values of Interval:recordId (Record ID).
+func (e DirectorshipProxy) RecordId() []string { return e.Get("recordId") }
+
+// AddRecordId adds values to Interval:recordId.
+func (e DirectorshipProxy) AddRecordId(values ...string) error {
+	return e.Add("recordId", values, false)
+}
+
+// SetRecordId replaces the values of Interval:recordId.
+func (e DirectorshipProxy) SetRecordId(values ...string) error {
+	return e.Set("recordId", values, false)
+}
+
+// RetrievedAt returns the values of Interval:retrievedAt (Retrieved on).
+func (e DirectorshipProxy) RetrievedAt() []string { return e.Get("retrievedAt") }
+
+// AddRetrievedAt adds values to Interval:retrievedAt.
+func (e DirectorshipProxy) AddRetrievedAt(values ...string) error {
+	return e.Add("retrievedAt", values, false)
+}
+
+// SetRetrievedAt replaces the values of Interval:retrievedAt.
+func (e DirectorshipProxy) SetRetrievedAt(values ...string) error {
+	return e.Set("retrievedAt", values, false)
+}
+
+// Secretary returns the values of Directorship:secretary (Secretary).
+func (e DirectorshipProxy) Secretary() []string { return e.Get("secretary") }
+
+// AddSecretary adds values to Directorship:secretary.
+func (e DirectorshipProxy) AddSecretary(values ...string) error {
+	return e.Add("secretary", values, false)
+}
+
+// SetSecretary replaces the values of Directorship:secretary.
+func (e DirectorshipProxy) SetSecretary(values ...string) error {
+	return e.Set("secretary", values, false)
+}
+
+// Proof returns the values of Interval:proof (Source document).
+func (e DirectorshipProxy) Proof() []string { return e.Get("proof") }
+
+// AddProof adds values to Interval:proof.
+func (e DirectorshipProxy) AddProof(values ...string) error { return e.Add("proof", values, false) }
+
+// SetProof replaces the values of Interval:proof.
+func (e DirectorshipProxy) SetProof(values ...string) error { return e.Set("proof", values, false) }
+
+// SourceUrl returns the values of Interval:sourceUrl (Source link).
+func (e DirectorshipProxy) SourceUrl() []string { return e.Get("sourceUrl") }
+
+// AddSourceUrl adds values to Interval:sourceUrl.
+func (e DirectorshipProxy) AddSourceUrl(values ...string) error {
+	return e.Add("sourceUrl", values, false)
+}
+
+// SetSourceUrl replaces the values of Interval:sourceUrl.
+func (e DirectorshipProxy) SetSourceUrl(values ...string) error {
+	return e.Set("sourceUrl", values, false)
+}
+
+// Status returns the values of Interest:status (Status).
+func (e DirectorshipProxy) Status() []string { return e.Get("status") }
+
+// AddStatus adds values to Interest:status.
+func (e DirectorshipProxy) AddStatus(values ...string) error { return e.Add("status", values, false) }
+
+// SetStatus replaces the values of Interest:status.
+func (e DirectorshipProxy) SetStatus(values ...string) error { return e.Set("status", values, false) }
+
+// Summary returns the values of Interval:summary (Summary).
+func (e DirectorshipProxy) Summary() []string { return e.Get("summary") }
+
+// AddSummary adds values to Interval:summary.
+func (e DirectorshipProxy) AddSummary(values ...string) error { return e.Add("summary", values, false) }
+
+// SetSummary replaces the values of Interval:summary.
+func (e DirectorshipProxy) SetSummary(values ...string) error { return e.Set("summary", values, false) }
+
+// SanctionProxy is a typed wrapper of an entity of schema Sanction (Sanction).
+type SanctionProxy struct{ *EntityProxy }
+
+// NewSanction creates an empty Sanction entity in model m.
+func NewSanction(m *Model, id string) SanctionProxy {
+	return SanctionProxy{NewEntityProxy(m.Get("Sanction"), id)}
+}
+
+// AsSanction wraps e if its schema is or extends Sanction.
+func AsSanction(e *EntityProxy) (SanctionProxy, bool) {
+	if e == nil || e.Schema == nil || !e.Schema.IsA("Sanction") {
+		return SanctionProxy{}, false
+	}
+	return SanctionProxy{e}, true
+}
+
+// Program returns the values of Sanction:program (Program).
+func (e SanctionProxy) Program() []string { return e.Get("program") }
+
+// AddProgram adds values to Sanction:program.
+func (e SanctionProxy) AddProgram(values ...string) error { return e.Add("program", values, false) }
+
+// SetProgram replaces the values of Sanction:program.
+func (e SanctionProxy) SetProgram(values ...string) error { return e.Set("program", values, false) }
+
+// Entity returns the values of Sanction:entity (Entity).
+func (e SanctionProxy) Entity() []string { return e.Get("entity") }
+
+// AddEntity adds values to Sanction:entity.
+func (e SanctionProxy) AddEntity(values ...string) error { return e.Add("entity", values, false) }
+
+// SetEntity replaces the values of Sanction:entity.
+func (e SanctionProxy) SetEntity(values ...string) error { return e.Set("entity", values, false) }
+
+// Country returns the values of Sanction:country (Country).
+func (e SanctionProxy) Country() []string { return e.Get("country") }
+
+// AddCountry adds values to Sanction:country.
+func (e SanctionProxy) AddCountry(values ...string) error { return e.Add("country", values, false) }
+
+// SetCountry replaces the values of Sanction:country.
+func (e SanctionProxy) SetCountry(values ...string) error { return e.Set("country", values, false) }
+
+// Authority returns the values of Sanction:authority (Authority).
+func (e SanctionProxy) Authority() []string { return e.Get("authority") }
+
+// AddAuthority adds values to Sanction:authority.
+func (e SanctionProxy) AddAuthority(values ...string) error { return e.Add("authority", values, false) }
+
+// SetAuthority replaces the values of Sanction:authority.
+func (e SanctionProxy) SetAuthority(values ...string) error { return e.Set("authority", values, false) }
+
+// StartDate returns the values of Interval:startDate (Start date).
+func (e SanctionProxy) StartDate() []string { return e.Get("startDate") }
+
+// AddStartDate adds values to Interval:startDate.
+func (e SanctionProxy) AddStartDate(values ...string) error { return e.Add("startDate", values, false) }
+
+// SetStartDate replaces the values of Interval:startDate.
+func (e SanctionProxy) SetStartDate(values ...string) error { return e.Set("startDate", values, false) }
+
+// AuthorityId returns the values of Sanction:authorityId (Authority-issued identifier).
+func (e SanctionProxy) AuthorityId() []string { return e.Get("authorityId") }
+
+// AddAuthorityId adds values to Sanction:authorityId.
+func (e SanctionProxy) AddAuthorityId(values ...string) error {
+	return e.Add("authorityId", values, false)
+}
+
+// SetAuthorityId replaces the values of Sanction:authorityId.
+func (e SanctionProxy) SetAuthorityId(values ...string) error {
+	return e.Set("authorityId", values, false)
+}
+
+// Date returns the values of Interval:date (Date).
+func (e SanctionProxy) Date() []string { return e.Get("date") }
+
+// AddDate adds values to Interval:date.
+func (e SanctionProxy) AddDate(values ...string) error { return e.Add("date", values, false) }
+
+// SetDate replaces the values of Interval:date.
+func (e SanctionProxy) SetDate(values ...string) error { return e.Set("date", values, false) }
+
+// Description returns the values of Interval:description (Description).
+func (e SanctionProxy) Description() []string { return e.Get("description") }
+
+// AddDescription adds values to Interval:description.
+func (e SanctionProxy) AddDescription(values ...string) error {
+	return e.Add("description", values, false)
+}
+
+// SetDescription replaces the values of Interval:description.
+func (e SanctionProxy) SetDescription(values ...string) error {
+	return e.Set("description", values, false)
+}
+
+// Duration returns the values of Sanction:duration (Duration).
+func (e SanctionProxy) Duration() []string { return e.Get("duration") }
+
+// AddDuration adds values to Sanction:duration.
+func (e SanctionProxy) AddDuration(values ...string) error { return e.Add("duration", values, false) }
+
+// SetDuration replaces the values of Sanction:duration.
+func (e SanctionProxy) SetDuration(values ...string) error { return e.Set("duration", values, false) }
+
+// EndDate returns the values of Interval:endDate (End date).
+func (e SanctionProxy) EndDate() []string { return e.Get("endDate") }
+
+// AddEndDate adds values to Interval:endDate.
+func (e SanctionProxy) AddEndDate(values ...string) error { return e.Add("endDate", values, false) }
+
+// SetEndDate replaces the values of Interval:endDate.
+func (e SanctionProxy) SetEndDate(values ...string) error { return e.Set("endDate", values, false) }
+
+// ListingDate returns the values of Sanction:listingDate (Listing date).
+func (e SanctionProxy) ListingDate() []string { return e.Get("listingDate") }
+
+// AddListingDate adds values to Sanction:listingDate.
+func (e SanctionProxy) AddListingDate(values ...string) error {
+	return e.Add("listingDate", values, false)
+}
+
+// SetListingDate replaces the values of Sanction:listingDate.
+func (e SanctionProxy) SetListingDate(values ...string) error {
+	return e.Set("listingDate", values, false)
+}
+
+// ModifiedAt returns the values of Interval:modifiedAt (Modified on).
+func (e SanctionProxy) ModifiedAt() []string { return e.Get("modifiedAt") }
+
+// AddModifiedAt adds values to Interval:modifiedAt.
+func (e SanctionProxy) AddModifiedAt(values ...string) error {
+	return e.Add("modifiedAt", values, false)
+}
+
+// SetModifiedAt replaces the values of Interval:modifiedAt.
+func (e SanctionProxy) SetModifiedAt(values ...string) error {
+	return e.Set("modifiedAt", values, false)
+}
+
+// ProgramId returns the values of Sanction:programId (Program ID).
+func (e SanctionProxy) ProgramId() []string { return e.Get("programId") }
+
+// AddProgramId adds values to Sanction:programId.
+func (e SanctionProxy) AddProgramId(values ...string) error { return e.Add("programId", values, false) }
+
+// SetProgramId replaces the values of Sanction:programId.
+func (e SanctionProxy) SetProgramId(values ...string) error { return e.Set("programId", values, false) }
+
+// ProgramUrl returns the values of Sanction:programUrl (Program URL).
+func (e SanctionProxy) ProgramUrl() []string { return e.Get("programUrl") }
+
+// AddProgramUrl adds values to Sanction:programUrl.
+func (e SanctionProxy) AddProgramUrl(values ...string) error {
+	return e.Add("programUrl", values, false)
+}
+
+// SetProgramUrl replaces the values of Sanction:programUrl.
+func (e SanctionProxy) SetProgramUrl(values ...string) error {
+	return e.Set("programUrl", values, false)
+}
+
+// Publisher returns the values of Interval:publisher (Publishing source).
+func (e SanctionProxy) Publisher() []string { return e.Get("publisher") }
+
+// AddPublisher adds values to Interval:publisher.
+func (e SanctionProxy) AddPublisher(values ...string) error { return e.Add("publisher", values, false) }
+
+// SetPublisher replaces the values of Interval:publisher.
+func (e SanctionProxy) SetPublisher(values ...string) error { return e.Set("publisher", values, false) }
+
+// PublisherUrl returns the values of Interval:publisherUrl (Publishing source URL).
+func (e SanctionProxy) PublisherUrl() []string { return e.Get("publisherUrl") }
+
+// AddPublisherUrl adds values to Interval:publisherUrl.
+func (e SanctionProxy) AddPublisherUrl(values ...string) error {
+	return e.Add("publisherUrl", values, false)
+}
+
+// SetPublisherUrl replaces the values of Interval:publisherUrl.
+func (e SanctionProxy) SetPublisherUrl(values ...string) error {
+	return e.Set("publisherUrl", values, false)
+}
+
+// Reason returns the values of Sanction:reason (Reason).
+func (e SanctionProxy) Reason() []string { return e.Get("reason") }
+
+// AddReason adds values to Sanction:reason.
+func (e SanctionProxy) AddReason(values ...string) error { return e.Add("reason", values, false) }
+
+// SetReason replaces the values of Sanction:reason.
+func (e SanctionProxy) SetReason(values ...string) error { return e.Set("reason", values, false) }
+
+// RecordId returns the values of Interval:recordId (Record ID).
+func (e SanctionProxy) RecordId() []string { return e.Get("recordId") }
+
+// AddRecordId adds values to Interval:recordId.
+func (e SanctionProxy) AddRecordId(values ...string) error { return e.Add("recordId", values, false) }
+
+// SetRecordId replaces the values of Interval:recordId.
+func (e SanctionProxy) SetRecordId(values ...string) error { return e.Set("recordId", values, false) }
+
+// RetrievedAt returns the values of Interval:retrievedAt (Retrieved on).
+func (e SanctionProxy) RetrievedAt() []string { return e.Get("retrievedAt") }
+
+// AddRetrievedAt adds values to Interval:retrievedAt.
+func (e SanctionProxy) AddRetrievedAt(values ...string) error {
+	return e.Add("retrievedAt", values, false)
+}
+
+// SetRetrievedAt replaces the values of Interval:retrievedAt.
+func (e SanctionProxy) SetRetrievedAt(values ...string) error {
+	return e.Set("retrievedAt", values, false)
+}
+
+// Provisions returns the values of Sanction:provisions (Scope of sanctions).
+func (e SanctionProxy) Provisions() []string { return e.Get("provisions") }
+
+// AddProvisions adds values to Sanction:provisions.
+func (e SanctionProxy) AddProvisions(values ...string) error {
+	return e.Add("provisions", values, false)
+}
+
+// SetProvisions replaces the values of Sanction:provisions.
+func (e SanctionProxy) SetProvisions(values ...string) error {
+	return e.Set("provisions", values, false)
+}
+
+// Proof returns the values of Interval:proof (Source document).
+func (e SanctionProxy) Proof() []string { return e.Get("proof") }
+
+// AddProof adds values to Interval:proof.
+func (e SanctionProxy) AddProof(values ...string) error { return e.Add("proof", values, false) }
+
+// SetProof replaces the values of Interval:proof.
+func (e SanctionProxy) SetProof(values ...string) error { return e.Set("proof", values, false) }
+
+// SourceUrl returns the values of Interval:sourceUrl (Source link).
+func (e SanctionProxy) SourceUrl() []string { return e.Get("sourceUrl") }
+
+// AddSourceUrl adds values to Interval:sourceUrl.
+func (e SanctionProxy) AddSourceUrl(values ...string) error { return e.Add("sourceUrl", values, false) }
+
+// SetSourceUrl replaces the values of Interval:sourceUrl.
+func (e SanctionProxy) SetSourceUrl(values ...string) error { return e.Set("sourceUrl", values, false) }
+
+// Status returns the values of Sanction:status (Status).
+func (e SanctionProxy) Status() []string { return e.Get("status") }
+
+// AddStatus adds values to Sanction:status.
+func (e SanctionProxy) AddStatus(values ...string) error { return e.Add("status", values, false) }
+
+// SetStatus replaces the values of Sanction:status.
+func (e SanctionProxy) SetStatus(values ...string) error { return e.Set("status", values, false) }
+
+// Summary returns the values of Interval:summary (Summary).
+func (e SanctionProxy) Summary() []string { return e.Get("summary") }
+
+// AddSummary adds values to Interval:summary.
+func (e SanctionProxy) AddSummary(values ...string) error { return e.Add("summary", values, false) }
+
+// SetSummary replaces the values of Interval:summary.
+func (e SanctionProxy) SetSummary(values ...string) error { return e.Set("summary", values, false) }
+
+// UnscId returns the values of Sanction:unscId (UN SC identifier).
+func (e SanctionProxy) UnscId() []string { return e.Get("unscId") }
+
+// AddUnscId adds values to Sanction:unscId.
+func (e SanctionProxy) AddUnscId(values ...string) error { return e.Add("unscId", values, false) }
+
+// SetUnscId replaces the values of Sanction:unscId.
+func (e SanctionProxy) SetUnscId(values ...string) error { return e.Set("unscId", values, false) }
@@ -2,27 +2,63 @@ package ftm
 
 import (
 	"sort"
+	"strings"
 )
 
+// AggregateOptions configures AggregateSortedStatementsWith.
+type AggregateOptions struct {
+	Dedupe      bool // apply DedupeValues to each entity
+	Consolidate bool // apply ConsolidateText to each entity
+
+	// Precedence, if set, keeps only the values of the most trusted sources
+	// for single-valued properties, as in StatementAggregator.
+	Precedence *SourcePrecedence
+}
+
 // AggregateSortedStatements aggregates a slice of statements assumed to be sorted by GroupKey
 // (canonical_id or entity_id). It returns a slice of EntityProxy constructed by merging
-// statements for each group.
+// statements for each group, with the provenance of the group in the context (see provenance).
 func AggregateSortedStatements(m *Model, st []Statement) []*EntityProxy {
+	return AggregateSortedStatementsWith(m, st, AggregateOptions{})
+}
+
+// AggregateSortedStatementsWith is AggregateSortedStatements with the
+// post-processing of StatementAggregator applied to each entity.
+func AggregateSortedStatementsWith(m *Model, st []Statement, opts AggregateOptions) []*EntityProxy {
+	out := aggregateSorted(m, st, opts.Precedence)
+	for _, e := range out {
+		if opts.Dedupe {
+			DedupeValues(e)
+		}
+		if opts.Consolidate {
+			ConsolidateText(e)
+		}
+	}
+	return out
+}
+
+func aggregateSorted(m *Model, st []Statement, precedence *SourcePrecedence) []*EntityProxy {
 	if len(st) == 0 {
 		return nil
 	}
 	// Ensure sorted by GroupKey for safety
 	sort.Slice(st, func(i, j int) bool { return st[i].GroupKey() < st[j].GroupKey() })
+	if precedence != nil {
+		st = precedence.applySorted(st)
+	}
 	var out []*EntityProxy
 	var cur *EntityProxy
 	var curKey string
+	var seen provenance
 	for i := range st {
 		s := st[i]
 		key := s.GroupKey()
 		if cur == nil || key != curKey {
 			if cur != nil {
+				seen.apply(cur)
 				out = append(out, cur)
 			}
+			seen = provenance{}
 			// Start a new entity using schema from statement
 			sc := m.Get(s.Schema)
 			if sc == nil {
@@ -31,15 +67,197 @@ func AggregateSortedStatements(m *Model, st []Statement) []*EntityProxy {
 			cur = NewEntityProxy(sc, key)
 			curKey = key
 		}
+		seen.add(s)
 		if s.Prop == BaseID {
 			// We already set ID to group key; ignore base ID
 			continue
 		}
 		// Add property value (cleaned assumed)
-		_ = cur.Add(s.Prop, []string{s.Value}, true)
+		_ = cur.addWithMeta(s.Prop, []string{s.Value}, true, s.Lang, s.Original)
 	}
 	if cur != nil {
+		seen.apply(cur)
 		out = append(out, cur)
 	}
 	return out
 }
+
+// provenance collects what nomenklatura reports about the statements folded
+// into an entity: the source datasets, the other entity IDs (referents), the
+// earliest first_seen and the latest last_seen. A statement without last_seen
+// counts as last seen when it was first seen.
+type provenance struct {
+	first, last string
+	datasets    map[string]struct{}
+	referents   map[string]struct{}
+}
+
+func (p *provenance) add(s Statement) {
+	if s.FirstSeen != "" && (p.first == "" || s.FirstSeen < p.first) {
+		p.first = s.FirstSeen
+	}
+	if last := ifEmpty(s.LastSeen, s.FirstSeen); last > p.last {
+		p.last = last
+	}
+	if p.datasets == nil {
+		p.datasets, p.referents = map[string]struct{}{}, map[string]struct{}{}
+	}
+	if s.Dataset != "" {
+		p.datasets[s.Dataset] = struct{}{}
+	}
+	if s.EntityID != "" {
+		p.referents[s.EntityID] = struct{}{}
+	}
+}
+
+// apply stores the provenance in the "datasets" and "referents" context fields
+// (sorted; referents exclude the entity's own ID) and in "first_seen" and
+// "last_seen".
+func (p provenance) apply(e *EntityProxy) {
+	e.Context["datasets"] = sortedKeys(p.datasets)
+	delete(p.referents, e.ID)
+	e.Context["referents"] = sortedKeys(p.referents)
+	if p.first != "" {
+		e.Context["first_seen"] = p.first
+	}
+	if p.last != "" {
+		e.Context["last_seen"] = p.last
+	}
+}
+
+// DedupeValues collapses values of each property that are identical after
+// type-specific normalization (case-insensitive identifiers, spacing
+// differences in names and addresses), keeping the first occurrence. It returns
+// the number of values removed.
+func DedupeValues(e *EntityProxy) int {
+	reg := e.Schema.Model.registry
+	collapsed := 0
+	for _, p := range e.IterProps() {
+		seen := map[string]struct{}{}
+		for _, v := range e.Get(p.Name) {
+			key := dedupeKey(reg, p.Type, v)
+			if _, ok := seen[key]; ok {
+				e.Remove(p.Name, v)
+				collapsed++
+				continue
+			}
+			seen[key] = struct{}{}
+		}
+	}
+	return collapsed
+}
+
+// dedupeKey returns the normalized form used to compare values of a type.
+func dedupeKey(reg *Registry, t PropertyType, value string) string {
+	switch t.Name() {
+	case reg.Identifier.Name(), reg.Checksum.Name():
+		return strings.ToLower(nonWord.ReplaceAllString(value, ""))
+	case reg.Name.Name(), reg.Address.Name():
+		return strings.Join(strings.Fields(value), " ")
+	case reg.Email.Name(), reg.URL.Name():
+		return strings.ToLower(value)
+	default:
+		return value
+	}
+}
+
+// ConsolidateOptions configures ConsolidateTextWith.
+type ConsolidateOptions struct {
+	Shingle    int     // words per shingle, default 3
+	Similarity float64 // Jaccard similarity at which values count as near-duplicates, default 0.8
+	MaxValues  int     // values kept per property, 0 for no limit
+}
+
+// ConsolidateText collapses near-duplicate values of free-text properties with
+// default options. See ConsolidateTextWith.
+func ConsolidateText(e *EntityProxy) int {
+	return ConsolidateTextWith(e, ConsolidateOptions{})
+}
+
+// ConsolidateTextWith reduces the values of text and HTML properties (notes,
+// descriptions) accumulated by merging. Values are compared by their word
+// shingles; of each group of near-duplicates only the longest is kept. Distinct
+// values are then kept longest first, up to MaxValues and within the type's
+// TotalSize budget. Kept values stay in their original order. It returns the
+// number of values removed.
+func ConsolidateTextWith(e *EntityProxy, opts ConsolidateOptions) int {
+	if opts.Shingle <= 0 {
+		opts.Shingle = 3
+	}
+	if opts.Similarity <= 0 {
+		opts.Similarity = 0.8
+	}
+	reg := e.Schema.Model.registry
+	removed := 0
+	for _, p := range e.IterProps() {
+		if n := p.Type.Name(); n != reg.Text.Name() && n != reg.HTML.Name() {
+			continue
+		}
+		values := e.Get(p.Name)
+		if len(values) < 2 {
+			continue
+		}
+		byLength := append([]string{}, values...)
+		sort.SliceStable(byLength, func(i, j int) bool { return len(byLength[i]) > len(byLength[j]) })
+
+		var kept []map[string]struct{}
+		keep := map[string]bool{}
+		size := 0
+		for _, v := range byLength {
+			if opts.MaxValues > 0 && len(kept) >= opts.MaxValues {
+				break
+			}
+			if budget := p.Type.TotalSize(); budget > 0 && size+len(v) > budget {
+				continue
+			}
+			sh := shingles(v, opts.Shingle)
+			duplicate := false
+			for _, other := range kept {
+				if jaccard(sh, other) >= opts.Similarity {
+					duplicate = true
+					break
+				}
+			}
+			if !duplicate {
+				kept = append(kept, sh)
+				keep[v] = true
+				size += len(v)
+			}
+		}
+		for _, v := range values {
+			if !keep[v] {
+				e.Remove(p.Name, v)
+				removed++
+			}
+		}
+	}
+	return removed
+}
+
+// shingles returns the set of n-word sequences of a text, ignoring case and
+// punctuation. Texts shorter than n words form a single shingle.
+func shingles(text string, n int) map[string]struct{} {
+	words := strings.Fields(strings.ToLower(nonWord.ReplaceAllString(text, " ")))
+	out := map[string]struct{}{}
+	if len(words) < n {
+		out[strings.Join(words, " ")] = struct{}{}
+		return out
+	}
+	for i := 0; i+n <= len(words); i++ {
+		out[strings.Join(words[i:i+n], " ")] = struct{}{}
+	}
+	return out
+}
+
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	shared := 0
+	for k := range a {
+		if _, ok := b[k]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
@@ -1,44 +1,227 @@
 package ftm
 
+import "slices"
+
+// SchemaPolicy decides how aggregation handles statements for one entity whose
+// schemata cannot be combined, such as Person and Company.
+type SchemaPolicy int
+
+const (
+	// SchemaKeepFirst keeps the schema of the first statement and drops values
+	// that do not fit it.
+	SchemaKeepFirst SchemaPolicy = iota
+	// SchemaCoerce uses the nearest common ancestor (Person, Company -> LegalEntity).
+	SchemaCoerce
+	// SchemaSplit emits one entity per incompatible schema. The first keeps the
+	// group ID; the others get an ID derived from it and the schema name.
+	SchemaSplit
+	// SchemaReject drops the entity.
+	SchemaReject
+)
+
+// SchemaConflict reports an entity whose statements carry incompatible schemata.
+type SchemaConflict struct {
+	EntityID string
+	Schemata []string // in order of appearance
+	// Dropped lists the properties, as schema:prop of their statements, whose
+	// values were lost because the schema of the entity lacks them, as with
+	// SchemaKeepFirst or SchemaCoerce. In order of appearance.
+	Dropped []string
+}
+
 // StatementAggregator does streaming aggregation assuming input statements are ordered by GroupKey.
+// Entities carry the provenance of their statements in the datasets, referents, first_seen
+// and last_seen context fields, as in nomenklatura's nested entities.
 type StatementAggregator struct {
-	m   *Model
-	cur *EntityProxy
-	key string
+	m     *Model
+	key   string
+	group []Statement
+	ready []*EntityProxy
+
+	// Dedupe enables DedupeValues on each completed entity; Collapsed counts removed values.
+	Dedupe    bool
+	Collapsed int
+
+	// Consolidate enables ConsolidateText on each completed entity; removed
+	// values are counted in Collapsed as well.
+	Consolidate bool
+
+	// SchemaPolicy handles incompatible schemata within a group. Except for
+	// SchemaKeepFirst, compatible schemata narrow to the most specific one.
+	// Conflicts lists every group with incompatible schemata.
+	SchemaPolicy SchemaPolicy
+	Conflicts    []SchemaConflict
+
+	// Precedence, if set, keeps only the values of the most trusted sources
+	// for single-valued properties; Overruled counts the statements dropped.
+	Precedence *SourcePrecedence
+	Overruled  int
 }
 
 func NewStatementAggregator(m *Model) *StatementAggregator { return &StatementAggregator{m: m} }
 
 // Add consumes one statement. If the group key changes, it returns the completed entity for the previous group.
+// With SchemaSplit a group may complete as several entities; they are returned by the following calls.
 func (sa *StatementAggregator) Add(s Statement) *EntityProxy {
-	gk := s.GroupKey()
-	if sa.cur == nil || gk != sa.key {
-		// return previous
-		var done *EntityProxy
-		if sa.cur != nil {
-			done = sa.cur
-		}
+	if gk := s.GroupKey(); len(sa.group) == 0 || gk != sa.key {
+		sa.complete()
+		sa.key = gk
+	}
+	sa.group = append(sa.group, s)
+	return sa.next()
+}
+
+// Flush returns the current entity, if any. Call it until it returns nil to
+// drain all completed entities.
+func (sa *StatementAggregator) Flush() *EntityProxy {
+	sa.complete()
+	sa.key = ""
+	return sa.next()
+}
+
+func (sa *StatementAggregator) next() *EntityProxy {
+	if len(sa.ready) == 0 {
+		return nil
+	}
+	e := sa.ready[0]
+	sa.ready = sa.ready[1:]
+	return e
+}
+
+// complete builds the entities of the buffered group.
+func (sa *StatementAggregator) complete() {
+	if len(sa.group) == 0 {
+		return
+	}
+	group := sa.group
+	if sa.Precedence != nil {
+		var n int
+		group, n = sa.Precedence.Apply(group)
+		sa.Overruled += n
+	}
+	for _, e := range sa.build(sa.key, group) {
+		sa.ready = append(sa.ready, sa.finish(e))
+	}
+	sa.group = sa.group[:0]
+}
+
+// aggregatePart is a set of statements sharing one (possibly narrowed) schema.
+type aggregatePart struct {
+	schema     *Schema
+	statements []Statement
+}
+
+// build groups statements into entities according to the schema policy.
+func (sa *StatementAggregator) build(key string, group []Statement) []*EntityProxy {
+	var parts []*aggregatePart
+	var seen []string
+	var narrowed *Schema // tracks compatibility under SchemaKeepFirst
+	conflict := false
+	for _, s := range group {
 		sc := sa.m.Get(s.Schema)
 		if sc == nil {
-			return done
+			continue
 		}
-		sa.cur = NewEntityProxy(sc, gk)
-		sa.key = gk
-		if s.Prop != BaseID {
-			_ = sa.cur.Add(s.Prop, []string{s.Value}, true)
+		if !slices.Contains(seen, sc.Name) {
+			seen = append(seen, sc.Name)
+		}
+		if len(parts) == 0 {
+			parts = append(parts, &aggregatePart{schema: sc})
+			narrowed = sc
+		}
+		part := parts[0]
+		if sa.SchemaPolicy != SchemaKeepFirst {
+			part = nil
+			for _, p := range parts {
+				if common, ok := narrowSchema(p.schema, sc); ok {
+					p.schema, part = common, p
+					break
+				}
+			}
+			if part == nil {
+				conflict = true
+				part = &aggregatePart{schema: sc}
+				parts = append(parts, part)
+			}
+		} else if common, ok := narrowSchema(narrowed, sc); !ok {
+			conflict = true
+		} else {
+			narrowed = common
+		}
+		part.statements = append(part.statements, s)
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	var dropped []string
+	if conflict {
+		sa.Conflicts = append(sa.Conflicts, SchemaConflict{EntityID: key, Schemata: seen})
+		switch sa.SchemaPolicy {
+		case SchemaReject:
+			return nil
+		case SchemaCoerce:
+			merged := &aggregatePart{schema: parts[0].schema}
+			for _, p := range parts {
+				if anc, err := sa.m.CommonAncestor(merged.schema, p.schema); err == nil {
+					merged.schema = anc
+				}
+				merged.statements = append(merged.statements, p.statements...)
+			}
+			parts = []*aggregatePart{merged}
 		}
-		return done
 	}
-	if s.Prop != BaseID {
-		_ = sa.cur.Add(s.Prop, []string{s.Value}, true)
+
+	out := make([]*EntityProxy, 0, len(parts))
+	for i, p := range parts {
+		id := key
+		if i > 0 {
+			id, _ = makeEntityID("", key, p.schema.Name)
+		}
+		e := NewEntityProxy(p.schema, id)
+		var seen provenance
+		for _, s := range p.statements {
+			seen.add(s)
+			if s.Prop == BaseID {
+				continue
+			}
+			if conflict && e.Schema.Get(s.Prop) == nil {
+				if qname := s.Schema + ":" + s.Prop; !slices.Contains(dropped, qname) {
+					dropped = append(dropped, qname)
+				}
+				continue
+			}
+			_ = e.addWithMeta(s.Prop, []string{s.Value}, true, s.Lang, s.Original)
+		}
+		seen.apply(e)
+		out = append(out, e)
 	}
-	return nil
+	if conflict {
+		sa.Conflicts[len(sa.Conflicts)-1].Dropped = dropped
+	}
+	return out
 }
 
-// Flush returns the current entity, if any.
-func (sa *StatementAggregator) Flush() *EntityProxy {
-	done := sa.cur
-	sa.cur = nil
-	sa.key = ""
-	return done
+// narrowSchema returns the more specific of two schemata if one extends the
+// other. Unlike Model.CommonSchema it ignores the model's SchemaResolver, so
+// that unrelated schemata are always detected as a conflict for the policy to
+// handle.
+func narrowSchema(a, b *Schema) (*Schema, bool) {
+	switch {
+	case a.IsA(b.Name):
+		return a, true
+	case b.IsA(a.Name):
+		return b, true
+	}
+	return nil, false
+}
+
+// finish applies post-processing to a completed entity.
+func (sa *StatementAggregator) finish(e *EntityProxy) *EntityProxy {
+	if sa.Dedupe {
+		sa.Collapsed += DedupeValues(e)
+	}
+	if sa.Consolidate {
+		sa.Collapsed += ConsolidateText(e)
+	}
+	return e
 }
@@ -0,0 +1,72 @@
+package ftm
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// AnnotationsKey is the context key under which text annotations are stored on an entity.
+const AnnotationsKey = "annotations"
+
+// Annotation marks a span of text in a document property (e.g. bodyText) that
+// mentions a value, such as a name detected by an NER pipeline.
+// Offsets are character (rune) positions, End is exclusive. Page is 1-based; 0 means unknown.
+type Annotation struct {
+	Prop  string `json:"prop"`
+	Value string `json:"value"`
+	Type  string `json:"type,omitempty"` // property type of Value, e.g. "name"
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Page  int    `json:"page,omitempty"`
+}
+
+// AddAnnotation validates an annotation and appends it to the entity context.
+func (e *EntityProxy) AddAnnotation(a Annotation) error {
+	if _, err := e.getProp(a.Prop); err != nil {
+		return err
+	}
+	if a.Value == "" {
+		return fmt.Errorf("annotation on %s has no value", a.Prop)
+	}
+	if a.Start < 0 || a.End < a.Start || a.Page < 0 {
+		return fmt.Errorf("invalid annotation span on %s: %d-%d", a.Prop, a.Start, a.End)
+	}
+	xs, err := e.Annotations()
+	if err != nil {
+		return err
+	}
+	// Clones share the context slice; clip it so appending copies.
+	e.Context[AnnotationsKey] = append(slices.Clip(xs), a)
+	return nil
+}
+
+// Annotations returns the annotations stored in the entity context. It accepts both
+// annotations added in-process and the generic form produced by decoding JSON.
+func (e *EntityProxy) Annotations() ([]Annotation, error) {
+	raw, ok := e.Context[AnnotationsKey]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	if xs, ok := raw.([]Annotation); ok {
+		return xs, nil
+	}
+	buf, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var xs []Annotation
+	if err := json.Unmarshal(buf, &xs); err != nil {
+		return nil, fmt.Errorf("invalid %s context: %w", AnnotationsKey, err)
+	}
+	return xs, nil
+}
+
+// AnnotatedText returns the text covered by the annotation in the given property value.
+func (a Annotation) AnnotatedText(text string) (string, bool) {
+	runes := []rune(text)
+	if a.Start < 0 || a.End > len(runes) || a.Start > a.End {
+		return "", false
+	}
+	return string(runes[a.Start:a.End]), true
+}
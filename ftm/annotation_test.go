@@ -0,0 +1,70 @@
+package ftm
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAnnotations(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	doc := NewEntityProxy(m.Get("Document"), "d1")
+	text := "Müller met Ana Lima in São Paulo."
+	_ = doc.Add("bodyText", []string{text}, false)
+
+	a := Annotation{Prop: "bodyText", Value: "Ana Lima", Type: "name", Start: 11, End: 19}
+	if err := doc.AddAnnotation(a); err != nil {
+		t.Fatalf("AddAnnotation: %v", err)
+	}
+	if got, ok := a.AnnotatedText(text); !ok || got != "Ana Lima" {
+		t.Fatalf("annotated text: %q", got)
+	}
+	if _, ok := (Annotation{Start: 30, End: 40}).AnnotatedText(text); ok {
+		t.Fatalf("span beyond the text should fail")
+	}
+	for _, bad := range []Annotation{
+		{Prop: "nope", Value: "x"},
+		{Prop: "bodyText"},
+		{Prop: "bodyText", Value: "x", Start: 5, End: 2},
+		{Prop: "bodyText", Value: "x", Page: -1},
+	} {
+		if err := doc.AddAnnotation(bad); err == nil {
+			t.Errorf("expected error for %+v", bad)
+		}
+	}
+
+	// Appending to a clone must not change the annotations of the original.
+	doc.Context[AnnotationsKey] = make([]Annotation, 1, 4)
+	doc.Context[AnnotationsKey].([]Annotation)[0] = a
+	left, right := doc.Clone(), doc.Clone()
+	_ = left.AddAnnotation(Annotation{Prop: "bodyText", Value: "Müller", Start: 0, End: 6})
+	_ = right.AddAnnotation(Annotation{Prop: "bodyText", Value: "São Paulo", Start: 23, End: 32})
+	orig, _ := doc.Annotations()
+	l, _ := left.Annotations()
+	r, _ := right.Annotations()
+	if len(orig) != 1 || len(l) != 2 || len(r) != 2 || l[1].Value != "Müller" || r[1].Value != "São Paulo" {
+		t.Fatalf("annotations shared between clones: %v / %v / %v", orig, l, r)
+	}
+
+	// Annotations survive a JSON round trip in their generic form.
+	raw, err := json.Marshal(left)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var data map[string]any
+	_ = json.Unmarshal(raw, &data)
+	back, err := EntityProxyFromDict(m, data, "")
+	if err != nil {
+		t.Fatalf("EntityProxyFromDict: %v", err)
+	}
+	xs, err := back.Annotations()
+	if err != nil || len(xs) != 2 || xs[1] != l[1] {
+		t.Fatalf("round trip: %v %v", xs, err)
+	}
+	back.Context[AnnotationsKey] = "garbage"
+	if _, err := back.Annotations(); err == nil {
+		t.Fatalf("expected error for invalid context")
+	}
+}
@@ -0,0 +1,203 @@
+// Package arrowio encodes statement streams as Apache Arrow record batches, so
+// they can be handed to analytical engines or served over Flight without a
+// text round trip. Schema is the canonical Arrow schema of a statement; the
+// IPC stream format is used on the wire.
+package arrowio
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/pedrohavay/followthemoney/ftm"
+)
+
+// Schema is the Arrow schema of statement batches. Column names match the CSV
+// header of ftm.WriteStatementsCSV; optional columns are nullable.
+var Schema = arrow.NewSchema([]arrow.Field{
+	{Name: "id", Type: arrow.BinaryTypes.String},
+	{Name: "entity_id", Type: arrow.BinaryTypes.String},
+	{Name: "canonical_id", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "prop", Type: arrow.BinaryTypes.String},
+	{Name: "prop_type", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "schema", Type: arrow.BinaryTypes.String},
+	{Name: "value", Type: arrow.BinaryTypes.String},
+	{Name: "dataset", Type: arrow.BinaryTypes.String},
+	{Name: "lang", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "original_value", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "external", Type: arrow.FixedWidthTypes.Boolean},
+	{Name: "first_seen", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "last_seen", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "origin", Type: arrow.BinaryTypes.String, Nullable: true},
+}, nil)
+
+// stringColumns points to the string fields of a statement, in schema order
+// with the external column left out.
+func stringColumns(s *ftm.Statement) []*string {
+	return []*string{
+		&s.ID, &s.EntityID, &s.CanonicalID, &s.Prop, &s.PropType, &s.Schema, &s.Value,
+		&s.Dataset, &s.Lang, &s.Original, nil, &s.FirstSeen, &s.LastSeen, &s.Origin,
+	}
+}
+
+// NewRecord builds a record batch of statements. Statements without an ID get
+// their key computed, as in the other writers. The caller releases the record.
+func NewRecord(mem memory.Allocator, statements []ftm.Statement) arrow.Record {
+	if mem == nil {
+		mem = memory.DefaultAllocator
+	}
+	b := array.NewRecordBuilder(mem, Schema)
+	defer b.Release()
+	for i := range statements {
+		s := statements[i]
+		if s.ID == "" {
+			s.MakeKey()
+		}
+		for j, v := range stringColumns(&s) {
+			if v == nil {
+				b.Field(j).(*array.BooleanBuilder).Append(s.External)
+				continue
+			}
+			sb := b.Field(j).(*array.StringBuilder)
+			if *v == "" && Schema.Field(j).Nullable {
+				sb.AppendNull()
+			} else {
+				sb.Append(*v)
+			}
+		}
+	}
+	return b.NewRecord()
+}
+
+// FromRecord decodes the statements of a record batch. Columns are matched by
+// name, so batches may carry extra columns or omit nullable ones. Values are
+// copied, so the statements outlive the record.
+func FromRecord(rec arrow.Record) ([]ftm.Statement, error) {
+	cols := make([]arrow.Array, len(Schema.Fields()))
+	for j, f := range Schema.Fields() {
+		idx := rec.Schema().FieldIndices(f.Name)
+		if len(idx) == 0 {
+			if !f.Nullable {
+				return nil, fmt.Errorf("arrow batch lacks column %s", f.Name)
+			}
+			continue
+		}
+		col := rec.Column(idx[0])
+		if !arrow.TypeEqual(col.DataType(), f.Type) {
+			return nil, fmt.Errorf("arrow column %s: expected %s, got %s", f.Name, f.Type, col.DataType())
+		}
+		cols[j] = col
+	}
+	out := make([]ftm.Statement, rec.NumRows())
+	for i := range out {
+		for j, v := range stringColumns(&out[i]) {
+			col := cols[j]
+			if col == nil || col.IsNull(i) {
+				continue
+			}
+			if v == nil {
+				out[i].External = col.(*array.Boolean).Value(i)
+			} else {
+				*v = strings.Clone(col.(*array.String).Value(i))
+			}
+		}
+	}
+	return out, nil
+}
+
+// Writer writes statements to an Arrow IPC stream in batches.
+type Writer struct {
+	w         *ipc.Writer
+	mem       memory.Allocator
+	batch     []ftm.Statement
+	batchSize int
+}
+
+// NewWriter creates a writer emitting a record batch every batchSize
+// statements (65536 if batchSize is not positive).
+func NewWriter(w io.Writer, batchSize int) *Writer {
+	if batchSize <= 0 {
+		batchSize = 65536
+	}
+	mem := memory.DefaultAllocator
+	return &Writer{
+		w:         ipc.NewWriter(w, ipc.WithSchema(Schema), ipc.WithAllocator(mem)),
+		mem:       mem,
+		batchSize: batchSize,
+	}
+}
+
+// Write buffers statements and writes full batches.
+func (w *Writer) Write(statements ...ftm.Statement) error {
+	for _, s := range statements {
+		w.batch = append(w.batch, s)
+		if len(w.batch) >= w.batchSize {
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Flush writes the buffered statements as a batch.
+func (w *Writer) Flush() error {
+	if len(w.batch) == 0 {
+		return nil
+	}
+	rec := NewRecord(w.mem, w.batch)
+	defer rec.Release()
+	w.batch = w.batch[:0]
+	return w.w.Write(rec)
+}
+
+// Close flushes the last batch and ends the stream. It does not close the
+// underlying writer.
+func (w *Writer) Close() error {
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return w.w.Close()
+}
+
+// WriteStatements writes statements as an Arrow IPC stream of one batch.
+func WriteStatements(w io.Writer, statements []ftm.Statement) error {
+	aw := NewWriter(w, len(statements))
+	if err := aw.Write(statements...); err != nil {
+		return err
+	}
+	return aw.Close()
+}
+
+// IterStatements iterates over the statements of an Arrow IPC stream. A read
+// error is yielded once as the final element.
+func IterStatements(r io.Reader) iter.Seq2[ftm.Statement, error] {
+	return func(yield func(ftm.Statement, error) bool) {
+		rd, err := ipc.NewReader(r)
+		if err != nil {
+			yield(ftm.Statement{}, err)
+			return
+		}
+		defer rd.Release()
+		for rd.Next() {
+			statements, err := FromRecord(rd.Record())
+			if err != nil {
+				yield(ftm.Statement{}, err)
+				return
+			}
+			for _, s := range statements {
+				if !yield(s, nil) {
+					return
+				}
+			}
+		}
+		if err := rd.Err(); err != nil {
+			yield(ftm.Statement{}, err)
+		}
+	}
+}
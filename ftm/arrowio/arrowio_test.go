@@ -0,0 +1,48 @@
+package arrowio
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pedrohavay/followthemoney/ftm"
+)
+
+func TestRoundTrip(t *testing.T) {
+	m, err := ftm.NewModel("../../schema")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := ftm.NewEntityProxy(m.Get("Person"), "p1")
+	_ = e.Add("name", []string{"Ana Lima"}, false)
+	_ = e.Add("nationality", []string{"br"}, false)
+	st := ftm.StatementsFromEntity(e, "ds", "2024-01-01", "2024-02-01", true, "crawler")
+	st[0].Lang = "por"
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, 2) // several batches
+	if err := w.Write(st...); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	var back []ftm.Statement
+	for s, err := range IterStatements(&buf) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		back = append(back, s)
+	}
+	if len(back) != len(st) {
+		t.Fatalf("expected %d statements, got %d", len(st), len(back))
+	}
+	for i := range st {
+		if back[i] != st[i] {
+			t.Fatalf("statement %d: got %+v, want %+v", i, back[i], st[i])
+		}
+	}
+	es := ftm.AggregateSortedStatements(m, back)
+	if len(es) != 1 || es[0].First("nationality") != "br" {
+		t.Fatalf("unexpected aggregation %v", es)
+	}
+}
@@ -0,0 +1,244 @@
+// Package boltstore implements ftm.StatementStore on an embedded bbolt
+// database, giving crawlers durable, restart-safe statement buffering without
+// an external database.
+//
+// Statements are keyed by canonical ID and statement ID, separated by a zero
+// byte, so the statements of an entity (or of all entities sharing an ID
+// prefix) are read with a prefix scan in canonical order.
+package boltstore
+
+import (
+	"bytes"
+	"iter"
+
+	"github.com/pedrohavay/followthemoney/ftm"
+	"github.com/vmihailenco/msgpack/v5"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// statementsBucket maps canonical_id\x00statement_id to the encoded statement.
+	statementsBucket = []byte("statements")
+	// keysBucket maps statement IDs to their canonical ID.
+	keysBucket = []byte("keys")
+	// datasetsBucket indexes dataset\x00canonical_id\x00statement_id.
+	datasetsBucket = []byte("datasets")
+)
+
+// scanBatch is the number of statements read per transaction while
+// iterating, so no read transaction stays open while the caller runs.
+const scanBatch = 1000
+
+// Options configures a Store.
+type Options struct {
+	// KeyAlgorithm is the recipe for IDs of statements added without one. If
+	// nil, the recipe selected by ftm.SetStatementKeyAlgorithm is used.
+	KeyAlgorithm *ftm.StatementKeyAlgorithm
+}
+
+// Store is a StatementStore backed by a bbolt file.
+type Store struct {
+	db   *bolt.DB
+	m    *ftm.Model
+	opts Options
+}
+
+// Open opens or creates the store at path, building entities with m.
+func Open(path string, m *ftm.Model) (*Store, error) {
+	return OpenWith(path, m, Options{})
+}
+
+// OpenWith opens or creates the store at path with the given options.
+func OpenWith(path string, m *ftm.Model, opts Options) (*Store, error) {
+	db, err := bolt.Open(path, 0o644, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{statementsBucket, keysBucket, datasetsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Store{db: db, m: m, opts: opts}, nil
+}
+
+// Close closes the database file.
+func (s *Store) Close() error { return s.db.Close() }
+
+// makeKey sets the ID of st with the store's recipe.
+func (s *Store) makeKey(st *ftm.Statement) string {
+	if s.opts.KeyAlgorithm != nil {
+		return st.MakeKeyWith(*s.opts.KeyAlgorithm)
+	}
+	return st.MakeKey()
+}
+
+func join(parts ...string) []byte {
+	var b bytes.Buffer
+	for i, p := range parts {
+		if i > 0 {
+			b.WriteByte(0)
+		}
+		b.WriteString(p)
+	}
+	return b.Bytes()
+}
+
+// Add implements ftm.StatementStore. All statements are written in one
+// transaction; statements without a key are ignored.
+func (s *Store) Add(statements ...ftm.Statement) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		sb, kb, db := tx.Bucket(statementsBucket), tx.Bucket(keysBucket), tx.Bucket(datasetsBucket)
+		for _, st := range statements {
+			if st.ID == "" && s.makeKey(&st) == "" {
+				continue
+			}
+			if err := deleteKey(tx, st.ID); err != nil {
+				return err
+			}
+			gk := st.GroupKey()
+			raw, err := msgpack.Marshal(&st)
+			if err != nil {
+				return err
+			}
+			if err := sb.Put(join(gk, st.ID), raw); err != nil {
+				return err
+			}
+			if err := kb.Put([]byte(st.ID), []byte(gk)); err != nil {
+				return err
+			}
+			if err := db.Put(join(st.Dataset, gk, st.ID), nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// deleteKey removes a stored statement and its index entries, if present.
+func deleteKey(tx *bolt.Tx, id string) error {
+	sb, kb := tx.Bucket(statementsBucket), tx.Bucket(keysBucket)
+	gk := kb.Get([]byte(id))
+	if gk == nil {
+		return nil
+	}
+	key := join(string(gk), id)
+	var st ftm.Statement
+	if err := msgpack.Unmarshal(sb.Get(key), &st); err != nil {
+		return err
+	}
+	if err := tx.Bucket(datasetsBucket).Delete(join(st.Dataset, string(gk), id)); err != nil {
+		return err
+	}
+	if err := sb.Delete(key); err != nil {
+		return err
+	}
+	return kb.Delete([]byte(id))
+}
+
+// Statements implements ftm.StatementStore.
+func (s *Store) Statements() iter.Seq2[ftm.Statement, error] {
+	return s.Prefix("")
+}
+
+// Canonical implements ftm.StatementStore.
+func (s *Store) Canonical(id string) iter.Seq2[ftm.Statement, error] {
+	return s.scan(statementsBucket, append([]byte(id), 0))
+}
+
+// Prefix iterates over the statements of all canonical IDs starting with
+// prefix, ordered by canonical ID.
+func (s *Store) Prefix(prefix string) iter.Seq2[ftm.Statement, error] {
+	return s.scan(statementsBucket, []byte(prefix))
+}
+
+// Dataset implements ftm.StatementStore.
+func (s *Store) Dataset(name string) iter.Seq2[ftm.Statement, error] {
+	return s.scan(datasetsBucket, append([]byte(name), 0))
+}
+
+// scan iterates over the keys of bucket starting with prefix in batches,
+// seeking past the last key of the previous batch in a fresh transaction.
+// Keys of the dataset index are resolved to the statements they point at.
+func (s *Store) scan(bucket, prefix []byte) iter.Seq2[ftm.Statement, error] {
+	return func(yield func(ftm.Statement, error) bool) {
+		seek := prefix
+		skip := false
+		for {
+			var batch []ftm.Statement
+			err := s.db.View(func(tx *bolt.Tx) error {
+				c := tx.Bucket(bucket).Cursor()
+				k, v := c.Seek(seek)
+				if skip && bytes.Equal(k, seek) {
+					k, v = c.Next()
+				}
+				for ; k != nil && bytes.HasPrefix(k, prefix) && len(batch) < scanBatch; k, v = c.Next() {
+					seek = append(seek[:0:0], k...)
+					if bytes.Equal(bucket, datasetsBucket) {
+						v = tx.Bucket(statementsBucket).Get(k[len(prefix):])
+					}
+					var st ftm.Statement
+					if err := msgpack.Unmarshal(v, &st); err != nil {
+						return err
+					}
+					batch = append(batch, st)
+				}
+				return nil
+			})
+			if err != nil {
+				yield(ftm.Statement{}, err)
+				return
+			}
+			for _, st := range batch {
+				if !yield(st, nil) {
+					return
+				}
+			}
+			if len(batch) < scanBatch {
+				return
+			}
+			skip = true
+		}
+	}
+}
+
+// DeleteDataset implements ftm.StatementStore.
+func (s *Store) DeleteDataset(name string) error {
+	prefix := append([]byte(name), 0)
+	return s.db.Update(func(tx *bolt.Tx) error {
+		var ids []string
+		c := tx.Bucket(datasetsBucket).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			ids = append(ids, string(k[bytes.LastIndexByte(k, 0)+1:]))
+		}
+		for _, id := range ids {
+			if err := deleteKey(tx, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Entity implements ftm.StatementStore.
+func (s *Store) Entity(id string) (*ftm.EntityProxy, error) {
+	agg := ftm.NewStatementAggregator(s.m)
+	for st, err := range s.Canonical(id) {
+		if err != nil {
+			return nil, err
+		}
+		agg.Add(st)
+	}
+	e := agg.Flush()
+	if e == nil {
+		return nil, ftm.ErrEntityNotFound
+	}
+	return e, nil
+}
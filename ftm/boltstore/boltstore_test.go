@@ -0,0 +1,125 @@
+package boltstore
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/pedrohavay/followthemoney/ftm"
+)
+
+func TestStoreKeyAlgorithm(t *testing.T) {
+	m, err := ftm.NewModel("../../schema")
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := ftm.Statement{EntityID: "a", Prop: "name", PropType: "name", Schema: "Person", Value: "Alice", Dataset: "d1"}
+	alg := ftm.StatementKeyAlgorithm{SHA256: true, Schema: true, PropType: true}
+	// Two stores with different recipes in the same process.
+	for _, a := range []ftm.StatementKeyAlgorithm{ftm.DefaultStatementKeyAlgorithm, alg} {
+		s, err := OpenWith(filepath.Join(t.TempDir(), "statements.db"), m, Options{KeyAlgorithm: &a})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer s.Close()
+		if err := s.Add(st); err != nil {
+			t.Fatal(err)
+		}
+		for got, err := range s.Statements() {
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.ID != a.Key(st) {
+				t.Fatalf("%s: unexpected key %s", a, got.ID)
+			}
+		}
+	}
+}
+
+func TestStore(t *testing.T) {
+	m, err := ftm.NewModel("../../schema")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "statements.db")
+	s, err := Open(path, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mk := func(entity, canonical, prop, value, dataset string) ftm.Statement {
+		return ftm.Statement{EntityID: entity, CanonicalID: canonical, Prop: prop, Schema: "Person", Value: value, Dataset: dataset}
+	}
+	sts := []ftm.Statement{
+		mk("a", "c1", "name", "Alice", "d1"),
+		mk("a", "c1", "name", "Alice", "d1"),
+		mk("b", "c1", "nationality", "de", "d2"),
+	}
+	// enough entities to span several scan batches
+	for i := 0; i < 2*scanBatch+10; i++ {
+		sts = append(sts, mk(fmt.Sprintf("p%05d", i), "", "name", "Person", "d1"))
+	}
+	if err := s.Add(sts...); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the statements survive reopening the file
+	s, err = Open(path, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	n, last := 0, ""
+	for st, err := range s.Statements() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if st.GroupKey() < last {
+			t.Fatalf("statements out of order: %s after %s", st.GroupKey(), last)
+		}
+		last = st.GroupKey()
+		n++
+	}
+	if want := len(sts) - 1; n != want {
+		t.Fatalf("statements: got %d, want %d", n, want)
+	}
+	n = 0
+	for _, err := range s.Prefix("p001") {
+		if err != nil {
+			t.Fatal(err)
+		}
+		n++
+	}
+	if n != 100 {
+		t.Fatalf("prefix scan: got %d, want 100", n)
+	}
+
+	e, err := s.Entity("c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := e.Get("nationality"); len(got) != 1 || got[0] != "de" {
+		t.Fatalf("nationality: %v", got)
+	}
+	if err := s.DeleteDataset("d1"); err != nil {
+		t.Fatal(err)
+	}
+	n = 0
+	for st, err := range s.Statements() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if st.Dataset != "d2" {
+			t.Fatalf("statement of deleted dataset: %+v", st)
+		}
+		n++
+	}
+	if n != 1 {
+		t.Fatalf("after delete: got %d statements, want 1", n)
+	}
+	if _, err := s.Entity("p00001"); err != ftm.ErrEntityNotFound {
+		t.Fatalf("deleted entity: %v", err)
+	}
+}
@@ -0,0 +1,187 @@
+package ftm
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// Checkpoint records how far a CheckpointWriter got: the statements and
+// groups flushed to the output, its size at that point and the group key of
+// the last complete group written.
+type Checkpoint struct {
+	Offset     int64  `json:"offset"`
+	Statements int    `json:"statements"`
+	Groups     int    `json:"groups"`
+	LastGroup  string `json:"last_group"`
+	Done       bool   `json:"done"`
+}
+
+// CheckpointOptions configures a CheckpointWriter.
+type CheckpointOptions struct {
+	// Path of the checkpoint file, default the output path plus ".checkpoint".
+	Path string
+	// Every is the number of complete groups between checkpoints, default 1000.
+	Every int
+}
+
+// CheckpointWriter writes statements sorted by group key (see SortStatements)
+// as JSON lines and periodically records a Checkpoint once the output is synced
+// to disk. Opening a writer on an output with a checkpoint resumes the export:
+// the output is truncated to the checkpointed offset, dropping a partly
+// written tail, and the statements of groups up to the last checkpointed group
+// are skipped, so the same sorted input can simply be written again. The output
+// is not compressed, as resuming needs byte offsets.
+type CheckpointWriter struct {
+	checkpointPath string
+	every          int
+	file           *os.File
+	bw             *bufio.Writer
+	cp             Checkpoint // progress so far, recorded as saved on checkpoint
+	saved          Checkpoint
+	resumeAfter    string
+	group          []Statement
+	groupKey       string
+	pending        int // complete groups written since the last checkpoint
+	written        int64
+}
+
+// NewCheckpointWriter opens the output at path, resuming from its checkpoint
+// if there is one.
+func NewCheckpointWriter(path string, opts CheckpointOptions) (*CheckpointWriter, error) {
+	if opts.Path == "" {
+		opts.Path = path + ".checkpoint"
+	}
+	if opts.Every <= 0 {
+		opts.Every = 1000
+	}
+	cw := &CheckpointWriter{checkpointPath: opts.Path, every: opts.Every}
+	b, err := os.ReadFile(opts.Path)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(b, &cw.cp); err != nil {
+			return nil, fmt.Errorf("reading checkpoint %s: %w", opts.Path, err)
+		}
+		cw.resumeAfter = cw.cp.LastGroup
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Truncate(cw.cp.Offset); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if _, err := f.Seek(cw.cp.Offset, 0); err != nil {
+		f.Close()
+		return nil, err
+	}
+	cw.saved = cw.cp
+	cw.cp.Done = false
+	cw.file, cw.bw, cw.written = f, bufio.NewWriter(f), cw.cp.Offset
+	return cw, nil
+}
+
+// Resumed reports whether the writer continues an earlier export.
+func (cw *CheckpointWriter) Resumed() bool { return cw.resumeAfter != "" }
+
+// Checkpoint returns the last recorded checkpoint.
+func (cw *CheckpointWriter) Checkpoint() Checkpoint { return cw.saved }
+
+// Write adds a statement. Statements must come in group key order; groups
+// already covered by the checkpoint are skipped.
+func (cw *CheckpointWriter) Write(s Statement) error {
+	key := s.GroupKey()
+	if cw.resumeAfter != "" && key <= cw.resumeAfter {
+		return nil
+	}
+	if len(cw.group) > 0 && key != cw.groupKey {
+		if key < cw.groupKey {
+			return fmt.Errorf("statements are not sorted by group key: %q after %q", key, cw.groupKey)
+		}
+		if err := cw.writeGroup(); err != nil {
+			return err
+		}
+		if cw.pending >= cw.every {
+			if err := cw.checkpoint(); err != nil {
+				return err
+			}
+		}
+	}
+	cw.groupKey = key
+	cw.group = append(cw.group, s)
+	return nil
+}
+
+func (cw *CheckpointWriter) writeGroup() error {
+	cnt := &countingWriter{w: cw.bw}
+	err := WriteStatementsJSONL(cnt, cw.group)
+	cw.written += cnt.n
+	if err != nil {
+		return err
+	}
+	cw.pending++
+	cw.cp.Groups++
+	cw.cp.Statements += len(cw.group)
+	cw.cp.LastGroup = cw.groupKey
+	cw.group = cw.group[:0]
+	return nil
+}
+
+// checkpoint syncs the output and then replaces the checkpoint file, so a
+// recorded offset never points past data that is on disk.
+func (cw *CheckpointWriter) checkpoint() error {
+	if err := cw.bw.Flush(); err != nil {
+		return err
+	}
+	if err := cw.file.Sync(); err != nil {
+		return err
+	}
+	cw.cp.Offset = cw.written
+	b, err := json.Marshal(cw.cp)
+	if err != nil {
+		return err
+	}
+	tmp := cw.checkpointPath + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, cw.checkpointPath); err != nil {
+		return err
+	}
+	cw.saved = cw.cp
+	cw.pending = 0
+	return nil
+}
+
+// Close writes the last group, records a final checkpoint marked done and
+// closes the output.
+func (cw *CheckpointWriter) Close() error {
+	var err error
+	if len(cw.group) > 0 {
+		err = cw.writeGroup()
+	}
+	if err == nil {
+		cw.cp.Done = true
+		err = cw.checkpoint()
+	}
+	return errors.Join(err, cw.file.Close())
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
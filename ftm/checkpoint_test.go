@@ -0,0 +1,72 @@
+package ftm
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckpointWriterResume(t *testing.T) {
+	var st []Statement
+	for _, id := range []string{"a", "b", "c", "d"} {
+		st = append(st,
+			Statement{EntityID: id, Schema: "Person", Prop: "name", Value: "N " + id, Dataset: "x"},
+			Statement{EntityID: id, Schema: "Person", Prop: "country", Value: "br", Dataset: "x"},
+		)
+	}
+	dir := t.TempDir()
+	writeAll := func(path string, stmts []Statement, closeWriter bool) *CheckpointWriter {
+		cw, err := NewCheckpointWriter(path, CheckpointOptions{Every: 1})
+		if err != nil {
+			t.Fatalf("NewCheckpointWriter: %v", err)
+		}
+		for _, s := range stmts {
+			if err := cw.Write(s); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+		}
+		if closeWriter {
+			if err := cw.Close(); err != nil {
+				t.Fatalf("close: %v", err)
+			}
+		}
+		return cw
+	}
+
+	clean := filepath.Join(dir, "clean.jsonl")
+	writeAll(clean, st, true)
+	want, _ := os.ReadFile(clean)
+
+	// Interrupt an export after the third group started, leaving a torn line.
+	out := filepath.Join(dir, "out.jsonl")
+	cw := writeAll(out, st[:5], false)
+	cw.bw.WriteString(`{"id":"torn`)
+	cw.bw.Flush()
+	cw.file.Close()
+	if cp := cw.Checkpoint(); cp.Groups != 2 || cp.LastGroup != "b" || cp.Done {
+		t.Fatalf("unexpected checkpoint: %+v", cp)
+	}
+
+	cw = writeAll(out, st, true)
+	if !cw.Resumed() {
+		t.Fatal("expected the export to resume")
+	}
+	got, _ := os.ReadFile(out)
+	if !bytes.Equal(got, want) {
+		t.Fatalf("resumed output differs:\n%s\nwant:\n%s", got, want)
+	}
+	if cp := cw.Checkpoint(); cp.Statements != 8 || cp.Groups != 4 || !cp.Done || cp.Offset != int64(len(want)) {
+		t.Fatalf("unexpected final checkpoint: %+v", cp)
+	}
+
+	cw, err := NewCheckpointWriter(filepath.Join(dir, "unsorted.jsonl"), CheckpointOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cw.Close()
+	_ = cw.Write(st[2])
+	if err := cw.Write(st[0]); err == nil {
+		t.Fatal("expected an error for unsorted input")
+	}
+}
@@ -0,0 +1,101 @@
+package ftm
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+)
+
+// CleanCache is a size-bounded LRU cache of type cleaning results, keyed by
+// (type, format, language, fuzzy, raw value). Types are compared by identity,
+// so models with different registries never share results; custom types must
+// be comparable (e.g. pointers). It is safe for concurrent use.
+type CleanCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[cleanKey]*list.Element
+	hits    uint64
+	misses  uint64
+}
+
+type cleanKey struct {
+	typ    PropertyType
+	format string
+	lang   string
+	fuzzy  bool
+	raw    string
+}
+
+type cleanEntry struct {
+	key   cleanKey
+	value string
+	ok    bool
+}
+
+// proxyCleaner is implemented by types whose cleaning result depends on the
+// entity being cleaned for (e.g. phone numbers use country hints); these are never cached.
+type proxyCleaner interface {
+	usesProxy() bool
+}
+
+// NewCleanCache creates a cache holding up to size results.
+func NewCleanCache(size int) *CleanCache {
+	if size < 1 {
+		size = 1
+	}
+	return &CleanCache{size: size, order: list.New(), entries: map[cleanKey]*list.Element{}}
+}
+
+var cleanCache atomic.Pointer[CleanCache]
+
+// SetCleanCache installs a cache used by EntityProxy.Add for all property types.
+// Pass nil to disable caching.
+func SetCleanCache(c *CleanCache) { cleanCache.Store(c) }
+
+// Clean returns the cached cleaning result for a property, computing it on a miss.
+func (c *CleanCache) Clean(p *Property, raw string, fuzzy bool, lang string, proxy *EntityProxy) (string, bool) {
+	if pc, ok := p.Type.(proxyCleaner); ok && pc.usesProxy() {
+		return cleanValueUncached(p, raw, fuzzy, lang, proxy)
+	}
+	key := cleanKey{typ: p.Type, format: p.Format, lang: lang, fuzzy: fuzzy, raw: raw}
+
+	c.mu.Lock()
+	if el, ok := c.entries[key]; ok {
+		c.order.MoveToFront(el)
+		c.hits++
+		ent := el.Value.(*cleanEntry)
+		c.mu.Unlock()
+		return ent.value, ent.ok
+	}
+	c.misses++
+	c.mu.Unlock()
+
+	value, ok := cleanValueUncached(p, raw, fuzzy, lang, proxy)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.entries[key]; !exists {
+		c.entries[key] = c.order.PushFront(&cleanEntry{key: key, value: value, ok: ok})
+		if c.order.Len() > c.size {
+			oldest := c.order.Back()
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*cleanEntry).key)
+		}
+	}
+	return value, ok
+}
+
+// Stats returns the number of cache hits and misses.
+func (c *CleanCache) Stats() (hits, misses uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
+
+// Len returns the number of cached results.
+func (c *CleanCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,137 @@
+package ftm
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression names the compression of a statement or entity stream.
+type Compression string
+
+const (
+	CompressionNone Compression = ""
+	CompressionGzip Compression = "gzip"
+	CompressionZstd Compression = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// CompressionForPath picks the compression from a file extension: ".gz" for
+// gzip and ".zst" for zstd.
+func CompressionForPath(path string) Compression {
+	switch {
+	case strings.HasSuffix(path, ".gz"):
+		return CompressionGzip
+	case strings.HasSuffix(path, ".zst"):
+		return CompressionZstd
+	}
+	return CompressionNone
+}
+
+// ParseCompression parses a compression name as used by command line flags:
+// "", "none", "gzip" (or "gz") and "zstd" (or "zst").
+func ParseCompression(name string) (Compression, error) {
+	switch strings.ToLower(name) {
+	case "", "none":
+		return CompressionNone, nil
+	case "gzip", "gz":
+		return CompressionGzip, nil
+	case "zstd", "zst":
+		return CompressionZstd, nil
+	}
+	return CompressionNone, fmt.Errorf("unknown compression: %s", name)
+}
+
+// Decompress returns a reader of the decompressed content of r, detecting gzip
+// and zstd by their magic bytes; other input is passed through. Close releases
+// the decoder but not r.
+func Decompress(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(len(zstdMagic))
+	switch {
+	case bytes.HasPrefix(head, gzipMagic):
+		return gzip.NewReader(br)
+	case bytes.HasPrefix(head, zstdMagic):
+		dec, err := zstd.NewReader(br, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return dec.IOReadCloser(), nil
+	}
+	return io.NopCloser(br), nil
+}
+
+// Compress wraps w to compress with c. Close flushes the compressed stream
+// but does not close w.
+func Compress(w io.Writer, c Compression) (io.WriteCloser, error) {
+	switch c {
+	case CompressionNone:
+		return nopWriteCloser{w}, nil
+	case CompressionGzip:
+		return gzip.NewWriter(w), nil
+	case CompressionZstd:
+		return zstd.NewWriter(w)
+	}
+	return nil, fmt.Errorf("unknown compression: %s", c)
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+// OpenStream opens a file for reading, decompressing gzip or zstd content
+// transparently. Closing the stream closes the file.
+func OpenStream(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	r, err := Decompress(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &fileStream{r, f}, nil
+}
+
+// CreateStream creates a file for writing, compressed according to its
+// extension (see CompressionForPath). Closing the stream closes the file.
+func CreateStream(path string) (io.WriteCloser, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	w, err := Compress(f, CompressionForPath(path))
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &fileStream{w, f}, nil
+}
+
+// fileStream closes a (de)compressor and the file below it.
+type fileStream struct {
+	rw   io.Closer
+	file *os.File
+}
+
+func (s *fileStream) Read(p []byte) (int, error)  { return s.rw.(io.Reader).Read(p) }
+func (s *fileStream) Write(p []byte) (int, error) { return s.rw.(io.Writer).Write(p) }
+
+func (s *fileStream) Close() error {
+	err := s.rw.Close()
+	if ferr := s.file.Close(); err == nil {
+		err = ferr
+	}
+	return err
+}
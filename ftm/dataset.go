@@ -0,0 +1,255 @@
+package ftm
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DatasetPublisher is the organization publishing a dataset.
+type DatasetPublisher struct {
+	Name        string `yaml:"name" json:"name"`
+	Acronym     string `yaml:"acronym,omitempty" json:"acronym,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	URL         string `yaml:"url,omitempty" json:"url,omitempty"`
+	Country     string `yaml:"country,omitempty" json:"country,omitempty"`
+	Official    bool   `yaml:"official,omitempty" json:"official,omitempty"`
+}
+
+// DatasetCoverage describes the period and countries a dataset covers, and
+// how often it is updated.
+type DatasetCoverage struct {
+	Start     string   `yaml:"start,omitempty" json:"start,omitempty"`
+	End       string   `yaml:"end,omitempty" json:"end,omitempty"`
+	Countries []string `yaml:"countries,omitempty" json:"countries,omitempty"`
+	Frequency string   `yaml:"frequency,omitempty" json:"frequency,omitempty"`
+	Schedule  string   `yaml:"schedule,omitempty" json:"schedule,omitempty"`
+}
+
+// Dataset is the metadata of a source dataset or of a collection of datasets,
+// in the layout of nomenklatura's dataset index files. Statements and entities
+// refer to datasets by Name; a Catalog resolves the names.
+type Dataset struct {
+	Name        string            `yaml:"name" json:"name"`
+	Title       string            `yaml:"title,omitempty" json:"title,omitempty"`
+	Summary     string            `yaml:"summary,omitempty" json:"summary,omitempty"`
+	Description string            `yaml:"description,omitempty" json:"description,omitempty"`
+	URL         string            `yaml:"url,omitempty" json:"url,omitempty"`
+	License     string            `yaml:"license,omitempty" json:"license,omitempty"`
+	Category    string            `yaml:"category,omitempty" json:"category,omitempty"`
+	Tags        []string          `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Version     string            `yaml:"version,omitempty" json:"version,omitempty"`
+	UpdatedAt   string            `yaml:"updated_at,omitempty" json:"updated_at,omitempty"`
+	Publisher   *DatasetPublisher `yaml:"publisher,omitempty" json:"publisher,omitempty"`
+	Coverage    *DatasetCoverage  `yaml:"coverage,omitempty" json:"coverage,omitempty"`
+	// ChildNames lists the datasets of a collection.
+	ChildNames []string `yaml:"children,omitempty" json:"children,omitempty"`
+
+	children []*Dataset
+}
+
+// Children returns the datasets of a collection, as resolved by its catalog.
+func (d *Dataset) Children() []*Dataset { return d.children }
+
+// IsCollection reports whether the dataset groups other datasets.
+func (d *Dataset) IsCollection() bool { return len(d.ChildNames) > 0 }
+
+// Leaves returns the source datasets below the dataset, sorted by name; a
+// dataset without children is its own leaf.
+func (d *Dataset) Leaves() []*Dataset {
+	seen := map[string]*Dataset{}
+	var walk func(*Dataset)
+	walk = func(ds *Dataset) {
+		if len(ds.children) == 0 {
+			seen[ds.Name] = ds
+		}
+		for _, c := range ds.children {
+			walk(c)
+		}
+	}
+	walk(d)
+	out := make([]*Dataset, 0, len(seen))
+	for _, name := range sortedKeys(seen) {
+		out = append(out, seen[name])
+	}
+	return out
+}
+
+// LeafNames returns the names of Leaves.
+func (d *Dataset) LeafNames() []string {
+	leaves := d.Leaves()
+	names := make([]string, len(leaves))
+	for i, l := range leaves {
+		names[i] = l.Name
+	}
+	return names
+}
+
+// Contains reports whether the named dataset is d or one of its descendants.
+func (d *Dataset) Contains(name string) bool {
+	if d.Name == name {
+		return true
+	}
+	for _, c := range d.children {
+		if c.Contains(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Catalog is a set of datasets by name, with the children of collections
+// resolved.
+type Catalog struct {
+	UpdatedAt string     `yaml:"updated_at,omitempty" json:"updated_at,omitempty"`
+	Datasets  []*Dataset `yaml:"datasets" json:"datasets"`
+
+	index map[string]*Dataset
+}
+
+// NewCatalog indexes the datasets and resolves the children of collections.
+// Dataset names must be unique, children must exist and collections must not
+// contain themselves.
+func NewCatalog(datasets ...*Dataset) (*Catalog, error) {
+	c := &Catalog{Datasets: datasets, index: map[string]*Dataset{}}
+	for _, d := range datasets {
+		if d.Name == "" {
+			return nil, fmt.Errorf("dataset without name")
+		}
+		if _, ok := c.index[d.Name]; ok {
+			return nil, fmt.Errorf("duplicate dataset name: %s", d.Name)
+		}
+		c.index[d.Name] = d
+	}
+	for _, d := range datasets {
+		d.children = d.children[:0]
+		for _, name := range d.ChildNames {
+			child := c.index[name]
+			if child == nil {
+				return nil, fmt.Errorf("dataset %s: unknown child dataset: %s", d.Name, name)
+			}
+			d.children = append(d.children, child)
+		}
+	}
+	// Depth-first search for cycles: a dataset met again while its own
+	// descendants are being visited contains itself.
+	const visiting, done = 1, 2
+	state := map[string]int{}
+	var visit func(*Dataset) error
+	visit = func(d *Dataset) error {
+		switch state[d.Name] {
+		case visiting:
+			return fmt.Errorf("dataset %s contains itself", d.Name)
+		case done:
+			return nil
+		}
+		state[d.Name] = visiting
+		for _, child := range d.children {
+			if err := visit(child); err != nil {
+				return err
+			}
+		}
+		state[d.Name] = done
+		return nil
+	}
+	for _, d := range datasets {
+		if err := visit(d); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// LoadCatalog reads a YAML or JSON index: an object with a "datasets" list, as
+// written by nomenklatura, or the metadata of a single dataset.
+func LoadCatalog(r io.Reader) (*Catalog, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var doc struct {
+		Catalog `yaml:",inline"`
+		Name    string `yaml:"name"`
+	}
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("reading catalog: %w", err)
+	}
+	if doc.Name != "" && len(doc.Datasets) == 0 {
+		var d Dataset
+		if err := yaml.Unmarshal(raw, &d); err != nil {
+			return nil, fmt.Errorf("reading dataset: %w", err)
+		}
+		return NewCatalog(&d)
+	}
+	c, err := NewCatalog(doc.Datasets...)
+	if err != nil {
+		return nil, err
+	}
+	c.UpdatedAt = doc.UpdatedAt
+	return c, nil
+}
+
+// LoadCatalogFile reads a catalog from a YAML or JSON file.
+func LoadCatalogFile(path string) (*Catalog, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	c, err := LoadCatalog(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return c, nil
+}
+
+// WriteJSON writes the catalog as a JSON index in canonical form (see
+// CanonicalJSON), which LoadCatalog reads back.
+func (c *Catalog) WriteJSON(w io.Writer) error {
+	b, err := CanonicalJSON(c)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// ContentHash returns the ContentHash of the catalog index.
+func (c *Catalog) ContentHash() (string, error) { return ContentHash(c) }
+
+// Get returns the named dataset, or nil.
+func (c *Catalog) Get(name string) *Dataset { return c.index[name] }
+
+// Names returns the names of all datasets, sorted.
+func (c *Catalog) Names() []string { return sortedKeys(c.index) }
+
+// StatementDataset returns the dataset of a statement, or nil if it is not in
+// the catalog.
+func (c *Catalog) StatementDataset(s Statement) *Dataset { return c.index[s.Dataset] }
+
+// EntityDatasets returns the catalog datasets listed in the "datasets"
+// context field of an aggregated entity, sorted by name; unknown names are
+// left out.
+func (c *Catalog) EntityDatasets(e *EntityProxy) []*Dataset {
+	names := slices.Sorted(slices.Values(contextStrings(e.Context["datasets"])))
+	var out []*Dataset
+	for _, name := range names {
+		if d := c.index[name]; d != nil {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// DatasetFilter accepts statements of the named dataset or, for a collection,
+// of any of its descendants. Unknown names accept statements of that name only.
+func (c *Catalog) DatasetFilter(name string) StatementFilter {
+	d := c.Get(name)
+	if d == nil {
+		return DatasetFilter(name)
+	}
+	return func(s Statement) bool { return d.Contains(s.Dataset) }
+}
@@ -0,0 +1,103 @@
+package ftm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestCatalog(t *testing.T) {
+	index := `
+updated_at: "2025-01-01T00:00:00"
+datasets:
+  - name: sanctions
+    title: Sanctions
+    children: [us_ofac_sdn, eu_fsf]
+  - name: all
+    children: [sanctions, br_ceis]
+  - name: us_ofac_sdn
+    title: US OFAC SDN
+    publisher:
+      name: Office of Foreign Assets Control
+      acronym: OFAC
+      country: us
+      official: true
+    coverage:
+      start: "2000-01-01"
+      countries: [us]
+      frequency: daily
+  - name: eu_fsf
+  - name: br_ceis
+`
+	c, err := LoadCatalog(strings.NewReader(index))
+	if err != nil {
+		t.Fatalf("LoadCatalog: %v", err)
+	}
+	if c.UpdatedAt != "2025-01-01T00:00:00" || len(c.Names()) != 5 {
+		t.Fatalf("unexpected catalog: %v", c.Names())
+	}
+	ofac := c.Get("us_ofac_sdn")
+	if ofac.Publisher == nil || ofac.Publisher.Acronym != "OFAC" || !ofac.Publisher.Official || ofac.Coverage.Frequency != "daily" {
+		t.Fatalf("unexpected dataset: %+v", ofac)
+	}
+	all := c.Get("all")
+	if got := strings.Join(all.LeafNames(), ","); got != "br_ceis,eu_fsf,us_ofac_sdn" {
+		t.Fatalf("unexpected leaves: %s", got)
+	}
+	if !all.Contains("eu_fsf") || c.Get("sanctions").Contains("br_ceis") {
+		t.Fatal("unexpected collection membership")
+	}
+	keep := c.DatasetFilter("sanctions")
+	if !keep(Statement{Dataset: "eu_fsf"}) || keep(Statement{Dataset: "br_ceis"}) {
+		t.Fatal("unexpected dataset filter")
+	}
+	if c.StatementDataset(Statement{Dataset: "us_ofac_sdn"}) != ofac {
+		t.Fatal("statement dataset not resolved")
+	}
+
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	e := NewEntityProxy(m.Get("Person"), "p1")
+	e.Context["datasets"] = []string{"us_ofac_sdn", "unknown", "br_ceis"}
+	if ds := c.EntityDatasets(e); len(ds) != 2 || ds[0].Name != "br_ceis" || ds[1] != ofac {
+		t.Fatalf("unexpected entity datasets: %v", ds)
+	}
+
+	var buf bytes.Buffer
+	if err := c.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	again, err := LoadCatalog(bytes.NewReader(buf.Bytes()))
+	if err != nil || again.Get("us_ofac_sdn").Publisher.Acronym != "OFAC" || len(again.Get("all").ChildNames) != 2 {
+		t.Fatalf("index round trip: %v", err)
+	}
+	hash, err := c.ContentHash()
+	if err != nil {
+		t.Fatalf("ContentHash: %v", err)
+	}
+	if sum := sha256.Sum256(buf.Bytes()); hex.EncodeToString(sum[:]) != hash {
+		t.Fatalf("index is not written in canonical form")
+	}
+	if rehash, _ := again.ContentHash(); rehash != hash {
+		t.Fatalf("hash changed on round trip")
+	}
+
+	single, err := LoadCatalog(strings.NewReader(`{"name": "br_ceis", "title": "CEIS"}`))
+	if err != nil || single.Get("br_ceis").Title != "CEIS" {
+		t.Fatalf("single dataset: %v", err)
+	}
+	for _, bad := range []string{
+		"datasets: [{name: a, children: [b]}]",
+		"datasets: [{name: a}, {name: a}]",
+		"datasets: [{name: a, children: [b]}, {name: b, children: [a]}]",
+		"datasets: [{name: a, children: [b]}, {name: b, children: [c]}, {name: c, children: [b]}]",
+	} {
+		if _, err := LoadCatalog(strings.NewReader(bad)); err == nil {
+			t.Errorf("expected error for %s", bad)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package ftm
+
+// DedupeWriter drops repeated statements, as crawlers emit when they record the
+// same claims on every run. Statements are identified by ID (computed if
+// missing); a repeated statement is merged into the first one, which keeps the
+// earliest first_seen and the latest last_seen. Statements are passed to write
+// in order of first appearance once they can no longer change: on Close, or,
+// if Grouped is set, whenever the group key changes.
+type DedupeWriter struct {
+	// Grouped emits statements at the end of each group, bounding memory to
+	// one group; input must then be ordered by GroupKey (see SortStatements).
+	// Otherwise all distinct statements are held until Close.
+	Grouped bool
+	// Dropped counts the repeated statements merged away.
+	Dropped int
+
+	write   func(Statement) error
+	index   map[string]int // statement ID -> position in pending
+	pending []Statement
+	group   string
+}
+
+// NewDedupeWriter returns a writer passing distinct statements to write.
+func NewDedupeWriter(write func(Statement) error) *DedupeWriter {
+	return &DedupeWriter{write: write, index: map[string]int{}}
+}
+
+// Write adds a statement.
+func (dw *DedupeWriter) Write(s Statement) error {
+	if s.ID == "" {
+		s.MakeKey()
+	}
+	if dw.Grouped {
+		if gk := s.GroupKey(); gk != dw.group {
+			if err := dw.flush(); err != nil {
+				return err
+			}
+			dw.group = gk
+		}
+	}
+	i, ok := dw.index[s.ID]
+	if !ok {
+		dw.index[s.ID] = len(dw.pending)
+		dw.pending = append(dw.pending, s)
+		return nil
+	}
+	dw.Dropped++
+	kept := &dw.pending[i]
+	kept.LastSeen = ifEmpty(kept.LastSeen, kept.FirstSeen)
+	if s.FirstSeen != "" && (kept.FirstSeen == "" || s.FirstSeen < kept.FirstSeen) {
+		kept.FirstSeen = s.FirstSeen
+	}
+	if last := ifEmpty(s.LastSeen, s.FirstSeen); last > kept.LastSeen {
+		kept.LastSeen = last
+	}
+	return nil
+}
+
+// flush emits the pending statements.
+func (dw *DedupeWriter) flush() error {
+	for _, s := range dw.pending {
+		if err := dw.write(s); err != nil {
+			return err
+		}
+	}
+	dw.pending = dw.pending[:0]
+	clear(dw.index)
+	return nil
+}
+
+// Close emits the remaining statements.
+func (dw *DedupeWriter) Close() error { return dw.flush() }
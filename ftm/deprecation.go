@@ -0,0 +1,57 @@
+package ftm
+
+import (
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// DeprecationWarning reports the use of a deprecated schema or property.
+type DeprecationWarning struct {
+	QName  string // schema name or property qname
+	Caller string // "file:line" of the first caller outside this package, if known
+}
+
+// DeprecationHandler receives deprecation warnings. It may be called concurrently.
+type DeprecationHandler func(DeprecationWarning)
+
+// SetDeprecationHandler installs a handler called whenever an entity of a deprecated
+// schema is created with NewEntityProxy or a deprecated property is written with Add.
+// Pass nil to disable warnings.
+func (m *Model) SetDeprecationHandler(fn DeprecationHandler) {
+	if fn == nil {
+		m.deprecation.Store(nil)
+		return
+	}
+	m.deprecation.Store(&fn)
+}
+
+// warnDeprecated notifies the model's handler, if any, about a deprecated name.
+func (m *Model) warnDeprecated(qname string) {
+	if m == nil {
+		return
+	}
+	fn := m.deprecation.Load()
+	if fn == nil {
+		return
+	}
+	(*fn)(DeprecationWarning{QName: qname, Caller: externalCaller()})
+}
+
+// externalCaller finds the first stack frame outside of this package.
+func externalCaller() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	pkg := reflect.TypeOf((*Model)(nil)).Elem().PkgPath()
+	for {
+		f, more := frames.Next()
+		if !strings.HasPrefix(f.Function, pkg+".") || strings.HasSuffix(f.File, "_test.go") {
+			return f.File + ":" + strconv.Itoa(f.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
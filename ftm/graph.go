@@ -27,7 +27,7 @@ func NodeFromProxy(e *EntityProxy) *Node {
 	if e == nil || e.ID == "" {
 		return nil
 	}
-	return NewNode(registry.Entity, e.ID, e, nil)
+	return NewNode(e.Schema.Model.registry.Entity, e.ID, e, nil)
 }
 
 // Edge links two nodes. If Proxy is set, it encodes an entity-as-edge (relationship schema).
@@ -102,9 +102,11 @@ type Graph struct {
 	proxies   map[string]*EntityProxy
 }
 
+// NewGraph creates a graph linking entities through values of the matchable
+// edgeTypes; nil selects the name, URL and country types of DefaultRegistry.
 func NewGraph(edgeTypes []PropertyType) *Graph {
 	if edgeTypes == nil {
-		edgeTypes = []PropertyType{registry.Name, registry.URL, registry.Country}
+		edgeTypes = []PropertyType{defaultRegistry.Name, defaultRegistry.URL, defaultRegistry.Country}
 	}
 	g := &Graph{edgeTypes: []PropertyType{}, edges: map[string]*Edge{}, nodes: map[string]*Node{}, proxies: map[string]*EntityProxy{}}
 	for _, t := range edgeTypes {
@@ -151,7 +153,7 @@ func (g *Graph) addEdgeProxy(proxy *EntityProxy, source, target string) {
 }
 
 func (g *Graph) getNodeStub(prop *Property, value string) *Node {
-	if prop.Type.Name() == registry.Entity.Name() {
+	if prop.Type.Name() == prop.Schema.Model.registry.Entity.Name() {
 		g.Queue(value, nil)
 	}
 	n := NewNode(prop.Type, value, nil, prop.Range)
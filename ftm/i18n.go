@@ -0,0 +1,183 @@
+package ftm
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Translations maps locales (e.g. "de", "pt_BR") to message catalogs, which map
+// English source strings to their translation.
+type Translations map[string]map[string]string
+
+// LoadTranslations reads the gettext catalogs shipped with the upstream package,
+// laid out as <locale>/LC_MESSAGES/*.po. Catalogs of a locale are merged.
+func LoadTranslations(fsys fs.FS) (Translations, error) {
+	t := Translations{}
+	matches, err := fs.Glob(fsys, "*/LC_MESSAGES/*.po")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range matches {
+		f, err := fsys.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		catalog, err := ReadPO(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		locale := normalizeLocale(path.Dir(path.Dir(name)))
+		if t[locale] == nil {
+			t[locale] = map[string]string{}
+		}
+		for k, v := range catalog {
+			t[locale][k] = v
+		}
+	}
+	return t, nil
+}
+
+// ReadPO parses a gettext .po catalog. Fuzzy and untranslated entries are
+// skipped; for plural entries the singular translation is kept.
+func ReadPO(r io.Reader) (map[string]string, error) {
+	catalog := map[string]string{}
+	var msgid, msgstr, field string
+	var fuzzy, plural bool
+	flush := func() {
+		if msgid != "" && msgstr != "" && !fuzzy {
+			catalog[msgid] = msgstr
+		}
+		msgid, msgstr, field = "", "", ""
+		fuzzy, plural = false, false
+	}
+	sc := bufio.NewScanner(r)
+	line := 0
+	for sc.Scan() {
+		line++
+		text := strings.TrimSpace(sc.Text())
+		var value string
+		switch {
+		case text == "":
+			flush()
+			continue
+		case strings.HasPrefix(text, "#,"):
+			if field != "" {
+				flush()
+			}
+			fuzzy = fuzzy || strings.Contains(text, "fuzzy")
+			continue
+		case strings.HasPrefix(text, "#"):
+			continue
+		case strings.HasPrefix(text, `"`):
+			value = text
+		default:
+			key, rest, ok := strings.Cut(text, " ")
+			if !ok {
+				return nil, fmt.Errorf("line %d: malformed entry", line)
+			}
+			if (key == "msgid" || key == "msgctxt") && strings.HasPrefix(field, "msgstr") {
+				flush()
+			}
+			field, value = key, strings.TrimSpace(rest)
+			if key == "msgid_plural" {
+				plural = true
+			}
+		}
+		s, err := strconv.Unquote(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		switch field {
+		case "msgid":
+			msgid += s
+		case "msgstr", "msgstr[0]":
+			msgstr += s
+		case "msgctxt", "msgid_plural":
+		default:
+			if !plural || !strings.HasPrefix(field, "msgstr[") {
+				return nil, fmt.Errorf("line %d: unknown keyword %s", line, field)
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return catalog, nil
+}
+
+// SetTranslations installs the catalogs used by LabelIn and ToDictIn.
+func (m *Model) SetTranslations(t Translations) {
+	m.translations.Store(&t)
+}
+
+// translate returns the translation of msg for lang, falling back from a
+// regional locale to its language (pt_BR to pt), and to msg itself.
+func (m *Model) translate(msg, lang string) string {
+	t := m.translations.Load()
+	if t == nil || msg == "" {
+		return msg
+	}
+	lang = normalizeLocale(lang)
+	for lang != "" {
+		if tr, ok := (*t)[lang][msg]; ok {
+			return tr
+		}
+		i := strings.LastIndex(lang, "_")
+		if i < 0 {
+			break
+		}
+		lang = lang[:i]
+	}
+	return msg
+}
+
+func normalizeLocale(lang string) string {
+	return strings.ReplaceAll(strings.TrimSpace(lang), "-", "_")
+}
+
+// LabelIn returns the label of the schema in the given locale.
+func (s *Schema) LabelIn(lang string) string { return s.Model.translate(s.Label, lang) }
+
+// PluralIn returns the plural label of the schema in the given locale.
+func (s *Schema) PluralIn(lang string) string { return s.Model.translate(s.Plural, lang) }
+
+// LabelIn returns the label of the property in the given locale.
+func (p *Property) LabelIn(lang string) string { return p.Schema.Model.translate(p.Label, lang) }
+
+// TypeLabelIn returns the label of a property type in the given locale.
+func (m *Model) TypeLabelIn(t PropertyType, lang string) string { return m.translate(t.Label(), lang) }
+
+// TypePluralIn returns the plural label of a property type in the given locale.
+func (m *Model) TypePluralIn(t PropertyType, lang string) string {
+	return m.translate(t.Plural(), lang)
+}
+
+// ToDictIn is ToDict with schema, property and type labels in the given locale.
+func (m *Model) ToDictIn(lang string) map[string]any {
+	data := m.ToDict()
+	localize := func(d map[string]any, keys ...string) {
+		for _, key := range keys {
+			if v, ok := d[key].(string); ok {
+				d[key] = m.translate(v, lang)
+			}
+		}
+	}
+	for _, sd := range data["schemata"].(map[string]any) {
+		sd := sd.(map[string]any)
+		localize(sd, "label", "plural")
+		for _, pd := range sd["properties"].(map[string]any) {
+			localize(pd.(map[string]any), "label")
+		}
+	}
+	for _, td := range data["types"].(map[string]any) {
+		localize(td.(map[string]any), "label", "plural")
+	}
+	return data
+}
@@ -0,0 +1,182 @@
+package ftm
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ColumnGuess is the inferred property assignment of a tabular column.
+type ColumnGuess struct {
+	Column   string  `json:"column"`
+	Property string  `json:"property,omitempty"` // empty if no property was found
+	Type     string  `json:"type,omitempty"`     // detected value type, if any
+	Format   string  `json:"format,omitempty"`   // detected type format, e.g. "iban"
+	Score    float64 `json:"score"`              // share of sample values matching Type
+}
+
+// inferMinScore is the share of non-empty sample values that must match a type.
+const inferMinScore = 0.8
+
+// InferMapping guesses which property of schema each column maps to. Headers
+// matching a property name or label win; otherwise the types of sample values
+// are detected with Registry.Detect and the column is assigned to the property of the detected type that best matches
+// the header. Columns without a detected type fall back to partial header matches.
+func InferMapping(s *Schema, header []string, rows [][]string) []ColumnGuess {
+	props := s.SortedProperties()
+	byLabel := map[string]*Property{}
+	for _, p := range props {
+		if p.Type.Name() == s.Model.registry.Entity.Name() {
+			continue
+		}
+		byLabel[normalizeHeader(p.Name)] = p
+		byLabel[normalizeHeader(p.Label)] = p
+	}
+
+	out := make([]ColumnGuess, len(header))
+	for i, col := range header {
+		g := ColumnGuess{Column: col}
+		var samples []string
+		for _, row := range rows {
+			if i < len(row) && strings.TrimSpace(row[i]) != "" {
+				samples = append(samples, row[i])
+			}
+		}
+		if tg, ok := detectColumn(s.Model.registry, samples); ok {
+			g.Type, g.Format, g.Score = tg.Name, tg.Format, tg.Score
+		}
+		h := normalizeHeader(col)
+		switch {
+		case byLabel[h] != nil:
+			g.Property = byLabel[h].Name
+		case isIDHeader(col):
+			// identifier columns are used as keys, not values
+		case g.Type != "":
+			g.Property = firstOfType(props, g.Type, g.Format, h)
+		default:
+			g.Property = partialHeaderMatch(byLabel, h)
+		}
+		out[i] = g
+	}
+	return out
+}
+
+// firstOfType picks the property of the given type (and format, if any) whose
+// name best matches the header, or the first displayed property of that type.
+func firstOfType(props []*Property, typeName, format, header string) string {
+	var first string
+	for _, p := range props {
+		if p.Type.Name() != typeName || (format != "" && p.Format != format) {
+			continue
+		}
+		if headerMatches(header, normalizeHeader(p.Name)) {
+			return p.Name
+		}
+		if first == "" {
+			first = p.Name
+		}
+	}
+	return first
+}
+
+// partialHeaderMatch finds the property whose name or label is contained in the
+// header (or vice versa), preferring the longest match.
+func partialHeaderMatch(byLabel map[string]*Property, header string) string {
+	best := ""
+	for label := range byLabel {
+		if headerMatches(header, label) && (len(label) > len(best) || (len(label) == len(best) && label < best)) {
+			best = label
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return byLabel[best].Name
+}
+
+// headerMatches reports whether one normalized string contains the other, ignoring
+// fragments too short to be meaningful.
+func headerMatches(header, label string) bool {
+	if len(header) < 4 || len(label) < 4 {
+		return false
+	}
+	return strings.Contains(header, label) || strings.Contains(label, header)
+}
+
+// detectColumn returns the type (and format) detected for most sample values,
+// preferring the more specific type when several match equally often.
+func detectColumn(reg *Registry, samples []string) (TypeGuess, bool) {
+	type tally struct {
+		guess TypeGuess
+		count int
+		score float64
+	}
+	var tallies []*tally
+	byKey := map[string]*tally{}
+	for _, v := range samples {
+		for _, g := range reg.Detect(v) {
+			key := g.Name + ":" + g.Format
+			t := byKey[key]
+			if t == nil {
+				t = &tally{guess: g}
+				byKey[key] = t
+				tallies = append(tallies, t)
+			}
+			t.count++
+			t.score += g.Score
+		}
+	}
+	var best *tally
+	for _, t := range tallies {
+		if float64(t.count)/float64(len(samples)) < inferMinScore {
+			continue
+		}
+		if best == nil || t.count > best.count || (t.count == best.count && t.score > best.score) {
+			best = t
+		}
+	}
+	if best == nil {
+		return TypeGuess{}, false
+	}
+	g := best.guess
+	g.Value, g.Score = "", float64(best.count)/float64(len(samples))
+	return g, true
+}
+
+// normalizeHeader lowercases a header and strips everything but letters and digits,
+// so "Date of Birth", "date_of_birth" and "dateOfBirth" compare equal.
+func normalizeHeader(h string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(h) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// InferKeys picks the columns to use as entity keys: ID-like columns if present,
+// otherwise the columns assigned to the schema's caption properties.
+func InferKeys(s *Schema, guesses []ColumnGuess) []string {
+	var keys []string
+	for _, g := range guesses {
+		if isIDHeader(g.Column) {
+			keys = append(keys, g.Column)
+		}
+	}
+	if len(keys) == 0 {
+		for _, g := range guesses {
+			if g.Property != "" && indexOf(s.Caption, g.Property) < len(s.Caption) {
+				keys = append(keys, g.Column)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isIDHeader reports whether a header names an identifier column ("id", "person_id", "Company ID").
+func isIDHeader(h string) bool {
+	h = strings.ToLower(strings.TrimSpace(h))
+	return h == "id" || strings.HasSuffix(h, "_id") || strings.HasSuffix(h, " id") || strings.HasSuffix(h, "-id")
+}
@@ -0,0 +1,45 @@
+package ftm
+
+// JoinIndex indexes lookup entities by the values of one property type (the pivot,
+// e.g. email) so their properties can be merged onto matching entities.
+type JoinIndex struct {
+	pivot PropertyType
+	index map[string][]*EntityProxy
+}
+
+// NewJoinIndex creates an empty index keyed by values of the given type.
+func NewJoinIndex(pivot PropertyType) *JoinIndex {
+	return &JoinIndex{pivot: pivot, index: map[string][]*EntityProxy{}}
+}
+
+// Add indexes a lookup entity under each of its pivot values.
+func (j *JoinIndex) Add(e *EntityProxy) {
+	for _, v := range e.GetTypeValues(j.pivot, false) {
+		j.index[v] = append(j.index[v], e)
+	}
+}
+
+// Len returns the number of distinct pivot values indexed.
+func (j *JoinIndex) Len() int { return len(j.index) }
+
+// Enrich adds the property values of all lookup entities sharing a pivot value
+// with e, for properties that exist on e's schema. It returns the number of
+// lookup entities joined.
+func (j *JoinIndex) Enrich(e *EntityProxy) int {
+	joined := map[*EntityProxy]struct{}{}
+	for _, v := range e.GetTypeValues(j.pivot, false) {
+		for _, other := range j.index[v] {
+			if _, done := joined[other]; done {
+				continue
+			}
+			joined[other] = struct{}{}
+			for _, p := range other.IterProps() {
+				if target := e.Schema.Get(p.Name); target == nil || target.Stub {
+					continue
+				}
+				_ = e.Add(p.Name, other.props[p.Name], true)
+			}
+		}
+	}
+	return len(joined)
+}
@@ -0,0 +1,52 @@
+package ftm
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestJoinIndex(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	person := NewEntityProxy(m.Get("Person"), "l1")
+	_ = person.Add("email", []string{"ana@example.com", "ana.lima@example.com"}, false)
+	_ = person.Add("phone", []string{"+5511987654321"}, false)
+	company := NewEntityProxy(m.Get("Company"), "l2")
+	_ = company.Add("email", []string{"ana@example.com"}, false)
+	_ = company.Add("capital", []string{"1000"}, false)
+	_ = company.Add("name", []string{"Lima Ltda"}, false)
+	other := NewEntityProxy(m.Get("Person"), "l3")
+	_ = other.Add("email", []string{"bob@example.com"}, false)
+	_ = other.Add("phone", []string{"+5511912345678"}, false)
+
+	idx := NewJoinIndex(m.Registry().Email)
+	for _, e := range []*EntityProxy{person, company, other} {
+		idx.Add(e)
+	}
+	if idx.Len() != 3 {
+		t.Fatalf("expected 3 pivot values, got %d", idx.Len())
+	}
+
+	target := NewEntityProxy(m.Get("Person"), "p1")
+	_ = target.Add("email", []string{"ana@example.com", "ana.lima@example.com"}, false)
+	if n := idx.Enrich(target); n != 2 {
+		t.Fatalf("expected 2 joined entities, got %d", n)
+	}
+	if got := target.Get("phone"); !slices.Equal(got, []string{"+5511987654321"}) {
+		t.Fatalf("phone: %v", got)
+	}
+	if got := target.Get("name"); !slices.Equal(got, []string{"Lima Ltda"}) {
+		t.Fatalf("shared properties should be joined: %v", got)
+	}
+	if target.Has("capital") || len(target.Get("email")) != 2 {
+		t.Fatalf("unexpected properties: %v", target.ToDict())
+	}
+
+	stranger := NewEntityProxy(m.Get("Person"), "p2")
+	_ = stranger.Add("email", []string{"eve@example.com"}, false)
+	if n := idx.Enrich(stranger); n != 0 || stranger.PropertyCount() != 1 {
+		t.Fatalf("unmatched entity should be unchanged: %d", n)
+	}
+}
@@ -0,0 +1,147 @@
+package ftm
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// JSONSchemaDialect is the JSON Schema draft used by Schema.JSONSchema.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema describes the JSON payload of an entity of this schema, as produced
+// by EntityProxy.ToDict, as a JSON Schema (draft 2020-12) document. Property
+// values are arrays of strings constrained by their type (date patterns,
+// country codes, topic enums, ...) and maximum length; required properties must
+// have at least one value. Unknown properties are rejected, while additional
+// top-level fields (datasets, referents, ...) are allowed.
+func (s *Schema) JSONSchema() map[string]any {
+	doc := schemaObject(s)
+	doc["$schema"] = JSONSchemaDialect
+	doc["$id"] = s.Name + ".json"
+	return doc
+}
+
+// JSONSchemas returns a JSON Schema document for each concrete schema, by name.
+func (m *Model) JSONSchemas() map[string]map[string]any {
+	out := map[string]map[string]any{}
+	for name, s := range m.Schemata {
+		if !s.Abstract {
+			out[name] = s.JSONSchema()
+		}
+	}
+	return out
+}
+
+// schemaObject describes the entity payload of s without document metadata, so
+// it can be embedded in other specifications.
+func schemaObject(s *Schema) map[string]any {
+	props := map[string]any{}
+	for name, p := range s.Properties {
+		props[name] = propertyValuesSchema(p)
+	}
+	properties := map[string]any{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+	var required []string
+	for _, name := range s.Required {
+		if _, ok := s.Properties[name]; ok {
+			required = append(required, name)
+		}
+	}
+	if len(required) > 0 {
+		properties["required"] = required
+	}
+	obj := map[string]any{
+		"title": s.Label,
+		"type":  "object",
+		"properties": map[string]any{
+			"id":         map[string]any{"type": "string", "minLength": 1},
+			"schema":     map[string]any{"const": s.Name},
+			"properties": properties,
+		},
+		"required": []string{"id", "schema", "properties"},
+	}
+	if s.Description != "" {
+		obj["description"] = strings.TrimSpace(s.Description)
+	}
+	if s.Deprecated {
+		obj["deprecated"] = true
+	}
+	return obj
+}
+
+// propertyValuesSchema describes the value array of a property.
+func propertyValuesSchema(p *Property) map[string]any {
+	out := map[string]any{
+		"title": p.Label,
+		"type":  "array",
+		"items": valueSchema(p),
+	}
+	if p.Description != "" {
+		out["description"] = strings.TrimSpace(p.Description)
+	}
+	if slices.Contains(p.Schema.Required, p.Name) {
+		out["minItems"] = 1
+	}
+	if p.Deprecated {
+		out["deprecated"] = true
+	}
+	if p.Stub {
+		out["readOnly"] = true // reverse stubs are derived from the forward property
+	}
+	return out
+}
+
+// valueSchema describes a single value of a property.
+func valueSchema(p *Property) map[string]any {
+	out := map[string]any{"type": "string"}
+	if n := p.maxLength(); n > 0 {
+		out["maxLength"] = n
+	}
+	if len(p.Values) > 0 {
+		out["enum"] = p.Values
+		return out
+	}
+	reg := p.Schema.Model.registry
+	switch p.Type.Name() {
+	case reg.Date.Name():
+		out["pattern"] = `^\d{4}(-\d{2}(-\d{2})?)?$`
+	case reg.Country.Name():
+		out["pattern"] = `^[a-z]{2}$`
+	case reg.Language.Name():
+		out["enum"] = sortedKeys(languageWhitelist)
+	case reg.Gender.Name():
+		out["enum"] = sortedKeys(reg.Gender.values)
+	case reg.Topic.Name():
+		out["enum"] = sortedKeys(reg.Topic.values)
+	case reg.Email.Name():
+		out["format"] = "email"
+	case reg.URL.Name():
+		out["format"] = "uri"
+	case reg.IP.Name():
+		out["anyOf"] = []any{map[string]any{"format": "ipv4"}, map[string]any{"format": "ipv6"}}
+	case reg.Phone.Name():
+		out["pattern"] = `^\+[1-9]\d{1,14}$`
+	case reg.Number.Name():
+		out["pattern"] = `^-?\d+(\.\d+)?([eE][-+]?\d+)?$`
+	case reg.Checksum.Name():
+		out["pattern"] = `^[0-9a-f]{40}$`
+	case reg.Mime.Name():
+		out["pattern"] = mimeRe.String()
+	case reg.Entity.Name():
+		out["minLength"] = 1
+	}
+	return out
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
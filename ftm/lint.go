@@ -0,0 +1,89 @@
+package ftm
+
+import (
+	"fmt"
+	"sort"
+)
+
+// LintIssue is a problem found in the model definition.
+type LintIssue struct {
+	Kind    string `json:"kind"`    // e.g. "dangling-range", "unknown-type"
+	Subject string `json:"subject"` // schema name or property qname
+	Message string `json:"message"`
+}
+
+func (li LintIssue) String() string {
+	return fmt.Sprintf("%s [%s]: %s", li.Subject, li.Kind, li.Message)
+}
+
+// Lint checks the model for definition problems that do not prevent loading but
+// lead to subtle bugs downstream: dangling property ranges, conflicting reverse
+// properties, missing labels and plurals of concrete schemata, abstract schemata without concrete
+// descendants, and properties whose unknown type fell back to string.
+// Issues are sorted by subject and kind.
+func (m *Model) Lint() []LintIssue {
+	var issues []LintIssue
+	add := func(kind, subject, format string, args ...any) {
+		issues = append(issues, LintIssue{Kind: kind, Subject: subject, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for qname, rng := range m.rangeIndex {
+		if m.Schemata[rng] == nil {
+			add("dangling-range", qname, "range schema %s does not exist", rng)
+		}
+	}
+	for qname, rs := range m.reverseIndex {
+		p := m.QNames[qname]
+		switch {
+		case p == nil:
+			continue
+		case p.Reverse == nil:
+			add("reverse-conflict", qname, "reverse %s could not be created without a valid range", rs.Name)
+		case !p.Reverse.Stub:
+			add("reverse-conflict", qname, "reverse %s collides with property %s", rs.Name, p.Reverse.QName)
+		case p.Reverse.Range != p.Schema:
+			add("reverse-conflict", qname, "reverse %s is already claimed by %s", p.Reverse.QName, p.Reverse.Range.Name)
+		}
+	}
+
+	for name, s := range m.Schemata {
+		if !s.Abstract && !s.hasLabel {
+			add("missing-label", name, "schema has no label")
+		}
+		if !s.Abstract && !s.hasPlural {
+			add("missing-plural", name, "schema has no plural label")
+		}
+		if s.Abstract && !hasConcreteDescendant(s) {
+			add("unreachable", name, "abstract schema has no concrete descendants")
+		}
+		for _, p := range s.Properties {
+			if p.Schema != s || p.Stub {
+				continue
+			}
+			if p.Label == "" {
+				add("missing-label", p.QName, "property has no label")
+			}
+			if m.registry.Get(p.typeName) == nil {
+				add("unknown-type", p.QName, "unknown type %q, using string", p.typeName)
+			}
+		}
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Subject != issues[j].Subject {
+			return issues[i].Subject < issues[j].Subject
+		}
+		return issues[i].Kind < issues[j].Kind
+	})
+	return issues
+}
+
+// hasConcreteDescendant reports whether any descendant of s can be instantiated.
+func hasConcreteDescendant(s *Schema) bool {
+	for _, d := range s.Descendants {
+		if !d.Abstract {
+			return true
+		}
+	}
+	return false
+}
@@ -5,11 +5,12 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	ftmschema "github.com/pedrohavay/followthemoney/schema"
-	"gopkg.in/yaml.v3"
 )
 
 // Model holds all schema definitions and helpers.
@@ -26,39 +27,46 @@ type Model struct {
 	reverseIndex map[string]reverseSpec // prop.qname -> reverseSpec
 	extendsNames map[string][]string    // temporary: child -> parent names
 
-	once sync.Once
+	genMu        sync.Mutex // serializes Generate
+	upstream     string     // upstream release, see Version
+	versionOnce  sync.Once
+	versionHash  string
+	deprecation  atomic.Pointer[DeprecationHandler]
+	resolver     atomic.Pointer[SchemaResolver]
+	translations atomic.Pointer[Translations]
+	registry     *Registry
+}
+
+// ModelOptions configures the *With model constructors.
+type ModelOptions struct {
+	// Registry resolves the property types named in the schemata. Defaults to
+	// DefaultRegistry; give each model its own registry to use custom types
+	// or enum tables without affecting other models in the process.
+	Registry *Registry
 }
 
 // NewModel loads the model from filesystem path.
 func NewModel(path string) (*Model, error) {
-	m := &Model{
-		Path:         ".",
-		fsys:         os.DirFS(path),
-		Schemata:     map[string]*Schema{},
-		Properties:   map[string]*Property{},
-		QNames:       map[string]*Property{},
-		extendsIndex: map[string][]*Schema{},
-		rangeIndex:   map[string]string{},
-		reverseIndex: map[string]reverseSpec{},
-		extendsNames: map[string][]string{},
-	}
+	return loadModel(newModel(os.DirFS(path), "."))
+}
 
-	// Load all schemata from YAML files in the path
-	if err := m.loadAll(); err != nil {
-		return nil, err
-	}
+// NewModelFS loads the model from a generic filesystem, rooted at `root`.
+func NewModelFS(fsys fs.FS, root string) (*Model, error) {
+	return loadModel(newModel(fsys, root))
+}
 
-	// Resolve cross-references and inheritance
-	if err := m.Generate(); err != nil {
-		return nil, err
+// NewModelFSWith is NewModelFS with options.
+func NewModelFSWith(fsys fs.FS, root string, opts ModelOptions) (*Model, error) {
+	m := newModel(fsys, root)
+	if opts.Registry != nil {
+		m.registry = opts.Registry
 	}
-
-	return m, nil
+	return loadModel(m)
 }
 
-// NewModelFS loads the model from a generic filesystem, rooted at `root`.
-func NewModelFS(fsys fs.FS, root string) (*Model, error) {
-	m := &Model{
+// newModel creates an empty model reading YAML files from fsys.
+func newModel(fsys fs.FS, root string) *Model {
+	return &Model{
 		Path:         root,
 		fsys:         fsys,
 		Schemata:     map[string]*Schema{},
@@ -68,7 +76,13 @@ func NewModelFS(fsys fs.FS, root string) (*Model, error) {
 		rangeIndex:   map[string]string{},
 		reverseIndex: map[string]reverseSpec{},
 		extendsNames: map[string][]string{},
+		registry:     defaultRegistry,
 	}
+}
+
+// loadModel loads all schemata from the YAML files and resolves cross-references
+// and inheritance.
+func loadModel(m *Model) (*Model, error) {
 	if err := m.loadAll(); err != nil {
 		return nil, err
 	}
@@ -78,39 +92,87 @@ func NewModelFS(fsys fs.FS, root string) (*Model, error) {
 	return m, nil
 }
 
-// Instance returns a singleton model, loading from env FTM_MODEL_PATH or default schemas.
-var defaultModel *Model
+// defaultModel holds the singleton model; it can be swapped by SetDefault.
+var (
+	defaultModel atomic.Pointer[Model]
+	defaultOnce  sync.Once
+)
 
-// Default returns the singleton model instance.
+// Default returns the singleton model instance, loading it from env
+// FTM_MODEL_PATH or the embedded schemata on first use. It is safe to call from
+// many goroutines; the model is loaded exactly once.
 func Default() *Model {
-	var err error
-
-	if defaultModel == nil {
-		path := os.Getenv("FTM_MODEL_PATH")
-		if path != "" {
-			defaultModel, err = NewModel(path)
-			if err == nil {
-				return defaultModel
-			}
+	defaultOnce.Do(func() {
+		if defaultModel.Load() != nil {
+			return // already set by SetDefault
 		}
+		defaultModel.CompareAndSwap(nil, loadDefault())
+	})
+	return defaultModel.Load()
+}
 
-		// Try embedded schema files
-		defaultModel, err = NewModelFS(ftmschema.Files, ".")
+func loadDefault() *Model {
+	var m *Model
+	path := os.Getenv("FTM_MODEL_PATH")
+	if path != "" {
+		m, _ = newModelFromPath(path)
+	}
+	if m != nil {
+		return m
+	}
+	// Try embedded schema files, through the binary cache if configured
+	var err error
+	if cache := os.Getenv("FTM_MODEL_CACHE"); cache != "" {
+		m, err = NewModelCached(ftmschema.Files, ".", cache)
+	} else {
+		m, err = NewModelFS(ftmschema.Files, ".")
+	}
+	if err != nil {
+		// Fallback for development: local folder named "schema"
+		m, err = NewModel("schema")
 		if err != nil {
-			// Fallback for development: local folder named "schema"
-			defaultModel, err = NewModel("schema")
-			if err != nil {
-				panic(fmt.Errorf("failed to load FtM model: %w", err))
-			}
+			panic(fmt.Errorf("failed to load FtM model: %w", err))
 		}
 	}
+	return m
+}
 
-	return defaultModel
+// SetDefault replaces the model returned by Default.
+func SetDefault(m *Model) {
+	defaultModel.Store(m)
+}
+
+// newModelFromPath loads a JSON model dump if path names a .json file, or the
+// YAML schema tree in the directory otherwise.
+func newModelFromPath(path string) (*Model, error) {
+	if !strings.HasSuffix(path, ".json") {
+		return NewModel(path)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return NewModelFromJSON(f)
 }
 
 // loadAll walks the filesystem and loads all YAML schema files.
 func (m *Model) loadAll() error {
-	// Walk all YAML files and load schemata into the model
+	err := readSpecFiles(m.fsys, m.Path, func(_ string, defs map[string]schemaSpec) error {
+		return m.addSpecs(defs)
+	})
+	if err != nil {
+		return err
+	}
+	m.upstream = readUpstreamVersion(m.fsys, m.Path)
+	return m.resolveExtends()
+}
+
+// readSpecFiles parses every YAML file below root, each a map of schema specs by
+// name. Problems in all files are collected, as *SchemaFileError values joined
+// with errors.Join; fn is not called for files with problems.
+func readSpecFiles(fsys fs.FS, root string, fn func(path string, defs map[string]schemaSpec) error) error {
+	var errs []error
 	walk := func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
@@ -127,56 +189,65 @@ func (m *Model) loadAll() error {
 		}
 
 		// Parse yaml file
-		raw, err := fs.ReadFile(m.fsys, path)
+		raw, err := fs.ReadFile(fsys, path)
 		if err != nil {
 			return err
 		}
 
 		// Each file is a map[name]schemaSpec
-		fileDefs := map[string]schemaSpec{}
+		fileDefs, fileErrs := parseSpecFile(path, raw)
+		if len(fileErrs) > 0 {
+			errs = append(errs, fileErrs...)
+			return nil
+		}
+		if err := fn(path, fileDefs); err != nil {
+			errs = append(errs, &SchemaFileError{Path: path, Err: err})
+		}
+		return nil
+	}
+	if err := fs.WalkDir(fsys, root, walk); err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
 
-		if err := yaml.Unmarshal(raw, &fileDefs); err != nil {
+// addSpecs registers schema specs and indexes their extends, ranges and reverses.
+func (m *Model) addSpecs(defs map[string]schemaSpec) error {
+	for name, spec := range defs {
+		sc, err := newSchema(m, name, spec)
+		if err != nil {
 			return err
 		}
 
-		for name, spec := range fileDefs {
-			sc, err := newSchema(m, name, spec)
-			if err != nil {
-				return err
-			}
-
-			// Register schema
-			if _, ok := m.Schemata[name]; ok {
-				return fmt.Errorf("duplicate schema name: %s", name)
-			}
-			m.Schemata[name] = sc
+		// Register schema
+		if _, ok := m.Schemata[name]; ok {
+			return fmt.Errorf("duplicate schema name: %s", name)
+		}
+		m.Schemata[name] = sc
 
-			// Capture extends relations (names only; resolved later)
-			if len(spec.Extends) > 0 {
-				m.extendsNames[name] = append(m.extendsNames[name], spec.Extends...)
-			}
+		// Capture extends relations (names only; resolved later)
+		if len(spec.Extends) > 0 {
+			m.extendsNames[name] = append(m.extendsNames[name], spec.Extends...)
+		}
 
-			// Prepare per-property range and reverse indexes
-			for pn, ps := range spec.Properties {
-				qname := name + ":" + pn
+		// Prepare per-property range and reverse indexes
+		for pn, ps := range spec.Properties {
+			qname := name + ":" + pn
 
-				if ps.Range != "" {
-					m.rangeIndex[qname] = ps.Range
-				}
+			if ps.Range != "" {
+				m.rangeIndex[qname] = ps.Range
+			}
 
-				if ps.Reverse != nil {
-					m.reverseIndex[qname] = *ps.Reverse
-				}
+			if ps.Reverse != nil {
+				m.reverseIndex[qname] = *ps.Reverse
 			}
 		}
-
-		return nil
-	}
-	if err := fs.WalkDir(m.fsys, m.Path, walk); err != nil {
-		return err
 	}
+	return nil
+}
 
-	// Resolve extends names into schema pointers now that all schemata are loaded
+// resolveExtends turns extends names into schema pointers once all schemata are loaded.
+func (m *Model) resolveExtends() error {
 	for child, parents := range m.extendsNames {
 		for _, parentName := range parents {
 			parent := m.Schemata[parentName]
@@ -194,13 +265,41 @@ func (m *Model) loadAll() error {
 
 // Generate resolves cross-references and inheritance.
 func (m *Model) Generate() error {
-	// Resolve schemata inheritance and property reverses/ranges
-	for _, s := range m.Schemata {
-		if err := s.Generate(); err != nil {
+	m.genMu.Lock()
+	defer m.genMu.Unlock()
+
+	// Resolve schemata inheritance and property reverses/ranges, in name order
+	// so the model does not depend on map iteration
+	for _, name := range sortedKeys(m.Schemata) {
+		if err := m.Schemata[name].generate(); err != nil {
 			return err
 		}
 	}
 
+	// Reverse stubs may be added to an ancestor after its descendants were
+	// generated; propagate them, preferring the most specific ancestor.
+	for _, s := range m.SchemataSorted() {
+		ancestors := make([]*Schema, 0, len(s.Schemata))
+		for _, anc := range s.Schemata {
+			if anc != s {
+				ancestors = append(ancestors, anc)
+			}
+		}
+		slices.SortFunc(ancestors, func(a, b *Schema) int {
+			if d := len(b.Schemata) - len(a.Schemata); d != 0 {
+				return d
+			}
+			return strings.Compare(a.Name, b.Name)
+		})
+		for _, anc := range ancestors {
+			for name, prop := range anc.Properties {
+				if _, ok := s.Properties[name]; !ok {
+					s.Properties[name] = prop
+				}
+			}
+		}
+	}
+
 	// Build QName index and ensure children inherit properties defined on ancestors (already done in Generate)
 	for _, s := range m.Schemata {
 		for _, p := range s.Properties {
@@ -226,8 +325,113 @@ func (m *Model) CommonSchema(left, right *Schema) (*Schema, error) {
 		return right, nil
 	}
 
+	if fn := m.resolver.Load(); fn != nil {
+		return (*fn)(m, left, right)
+	}
+	return nil, fmt.Errorf("no common schema: %s and %s", left.Name, right.Name)
+}
+
+// SchemaResolver picks the schema for two schemata neither of which extends the
+// other, or fails.
+type SchemaResolver func(m *Model, left, right *Schema) (*Schema, error)
+
+// SetSchemaResolver installs the policy CommonSchema (and thus Merge) applies to
+// unrelated schemata, such as Person and Company. Pass nil to restore the
+// default of returning an error.
+func (m *Model) SetSchemaResolver(fn SchemaResolver) {
+	if fn == nil {
+		m.resolver.Store(nil)
+		return
+	}
+	m.resolver.Store(&fn)
+}
+
+// ResolveCommonAncestor is a SchemaResolver that falls back to the nearest
+// common ancestor which is not abstract, e.g. LegalEntity for Person and
+// Company, as done when deduplicating mixed-schema clusters.
+func ResolveCommonAncestor(m *Model, left, right *Schema) (*Schema, error) {
+	if best := nearestAncestor(left, right, true); best != nil {
+		return best, nil
+	}
 	return nil, fmt.Errorf("no common schema: %s and %s", left.Name, right.Name)
 }
 
+// CommonAncestor returns the most specific schema both left and right extend,
+// e.g. LegalEntity for Person and Company. Ties are broken by name.
+func (m *Model) CommonAncestor(left, right *Schema) (*Schema, error) {
+	if left == nil || right == nil {
+		return nil, errors.New("invalid schema")
+	}
+	if best := nearestAncestor(left, right, false); best != nil {
+		return best, nil
+	}
+	return nil, fmt.Errorf("no common ancestor: %s and %s", left.Name, right.Name)
+}
+
+// nearestAncestor returns the most specific common ancestor of left and right,
+// optionally skipping abstract schemata.
+func nearestAncestor(left, right *Schema, concrete bool) *Schema {
+	var best *Schema
+	for _, anc := range left.Schemata {
+		if (concrete && anc.Abstract) || !right.IsA(anc.Name) {
+			continue
+		}
+		if best == nil || len(anc.Schemata) > len(best.Schemata) ||
+			(len(anc.Schemata) == len(best.Schemata) && anc.Name < best.Name) {
+			best = anc
+		}
+	}
+	return best
+}
+
+// MatchableSchemata returns all matchable schemata in the model, sorted by name.
+func (m *Model) MatchableSchemata() []*Schema {
+	out := make([]*Schema, 0)
+	for _, s := range m.Schemata {
+		if s.Matchable {
+			out = append(out, s)
+		}
+	}
+	slices.SortFunc(out, func(a, b *Schema) int { return strings.Compare(a.Name, b.Name) })
+	return out
+}
+
+// ToDict serializes the model (schemata and property types) in the structure
+// of the upstream model dump.
+func (m *Model) ToDict() map[string]any {
+	schemata := map[string]any{}
+	for name, s := range m.Schemata {
+		schemata[name] = s.ToDict()
+	}
+	types := map[string]any{}
+	for name, t := range m.registry.types {
+		data := map[string]any{
+			"label":     t.Label(),
+			"plural":    t.Plural(),
+			"matchable": t.Matchable(),
+			"pivot":     t.Pivot(),
+			"maxLength": t.MaxLength(),
+		}
+		if g := t.Group(); g != "" {
+			data["group"] = g
+		}
+		types[name] = data
+	}
+	return map[string]any{"schemata": schemata, "types": types, "version": m.Version()}
+}
+
 // Get returns the schema by name, or nil if not found.
 func (m *Model) Get(name string) *Schema { return m.Schemata[name] }
+
+// SchemataSorted returns all schemata sorted by name, for output that must be
+// identical across runs.
+func (m *Model) SchemataSorted() []*Schema {
+	out := make([]*Schema, 0, len(m.Schemata))
+	for _, name := range sortedKeys(m.Schemata) {
+		out = append(out, m.Schemata[name])
+	}
+	return out
+}
+
+// Registry returns the property types used by the model.
+func (m *Model) Registry() *Registry { return m.registry }
@@ -0,0 +1,140 @@
+package ftm
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// modelCacheFormat is bumped whenever the cached spec structures change, which
+// invalidates existing cache files.
+const modelCacheFormat = 1
+
+// NewModelCached loads the model from the YAML files below root like
+// NewModelFS, but keeps the parsed schemata in a binary cache file, so later
+// loads skip YAML parsing, which dominates startup time. The cache is keyed by
+// a hash of the schema files and rewritten whenever they change; a missing,
+// unreadable or unwritable cache only costs a regular load. Schema files are
+// parsed, and their problems reported, as by NewModelFS.
+func NewModelCached(fsys fs.FS, root, cachePath string) (*Model, error) {
+	files, err := readSchemaFiles(fsys, root)
+	if err != nil {
+		return nil, err
+	}
+	hash := schemaFilesHash(files)
+	if specs, upstream, err := readModelCache(cachePath, hash); err == nil {
+		return newModelFromSpecs(fsys, root, upstream, specs)
+	}
+
+	specs := map[string]schemaSpec{}
+	err = readSpecFiles(fsys, root, func(_ string, defs map[string]schemaSpec) error {
+		for sn, spec := range defs {
+			if _, ok := specs[sn]; ok {
+				return fmt.Errorf("duplicate schema name: %s", sn)
+			}
+			specs[sn] = spec
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	upstream := readUpstreamVersion(fsys, root)
+	m, err := newModelFromSpecs(fsys, root, upstream, specs)
+	if err != nil {
+		return nil, err
+	}
+	_ = writeModelCache(cachePath, hash, upstream, specs) // retried on the next load
+	return m, nil
+}
+
+func newModelFromSpecs(fsys fs.FS, root, upstream string, specs map[string]schemaSpec) (*Model, error) {
+	m := newModel(fsys, root)
+	m.upstream = upstream
+	if err := m.addSpecs(specs); err != nil {
+		return nil, err
+	}
+	if err := m.resolveExtends(); err != nil {
+		return nil, err
+	}
+	if err := m.Generate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// readSchemaFiles reads the YAML files and the VERSION file below root, by path.
+func readSchemaFiles(fsys fs.FS, root string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	err := fs.WalkDir(fsys, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if !isYAMLFile(p) && p != path.Join(root, "VERSION") {
+			return nil
+		}
+		raw, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		files[p] = raw
+		return nil
+	})
+	return files, err
+}
+
+func schemaFilesHash(files map[string][]byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "ftm-model-cache:%d\n", modelCacheFormat)
+	for _, name := range sortedKeys(files) {
+		fmt.Fprintf(h, "%s\x00%d\x00", name, len(files[name]))
+		h.Write(files[name])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// modelCache is the payload of a cache file, written after its hash.
+type modelCache struct {
+	Upstream string
+	Specs    map[string]schemaSpec
+}
+
+func readModelCache(cachePath, hash string) (map[string]schemaSpec, string, error) {
+	f, err := os.Open(cachePath)
+	if err != nil {
+		return nil, "", err
+	}
+	defer f.Close()
+	dec := msgpack.NewDecoder(bufio.NewReader(f))
+	got, err := dec.DecodeString()
+	if err != nil {
+		return nil, "", err
+	}
+	if got != hash {
+		return nil, "", fmt.Errorf("model cache is stale")
+	}
+	var c modelCache
+	if err := dec.Decode(&c); err != nil {
+		return nil, "", err
+	}
+	return c.Specs, c.Upstream, nil
+}
+
+func writeModelCache(cachePath, hash, upstream string, specs map[string]schemaSpec) error {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	if err := enc.EncodeString(hash); err != nil {
+		return err
+	}
+	if err := enc.Encode(modelCache{Upstream: upstream, Specs: specs}); err != nil {
+		return err
+	}
+	return writeFileAtomic(cachePath, buf.Bytes())
+}
@@ -0,0 +1,166 @@
+package ftm
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// FieldChange records a changed attribute of a schema or property.
+type FieldChange struct {
+	Subject string `json:"subject"` // schema name or property qname
+	Field   string `json:"field"`   // e.g. "type", "range", "extends"
+	Old     string `json:"old"`
+	New     string `json:"new"`
+}
+
+func (c FieldChange) String() string {
+	return fmt.Sprintf("%s %s: %q -> %q", c.Subject, c.Field, c.Old, c.New)
+}
+
+// ModelDiff lists the differences between two model versions. Names are sorted.
+type ModelDiff struct {
+	AddedSchemata     []string      `json:"added_schemata"`
+	RemovedSchemata   []string      `json:"removed_schemata"`
+	AddedProperties   []string      `json:"added_properties"`
+	RemovedProperties []string      `json:"removed_properties"`
+	Changes           []FieldChange `json:"changes"`
+}
+
+// Empty reports whether the models are equivalent as far as the diff is concerned.
+func (d ModelDiff) Empty() bool {
+	return len(d.AddedSchemata)+len(d.RemovedSchemata)+len(d.AddedProperties)+len(d.RemovedProperties)+len(d.Changes) == 0
+}
+
+// Breaking reports whether existing entity data may no longer load or validate:
+// removed schemata or properties, or changed property types, ranges, inheritance
+// or required properties.
+func (d ModelDiff) Breaking() bool {
+	if len(d.RemovedSchemata) > 0 || len(d.RemovedProperties) > 0 {
+		return true
+	}
+	for _, c := range d.Changes {
+		switch c.Field {
+		case "type", "range", "extends", "required", "abstract":
+			return true
+		}
+	}
+	return false
+}
+
+// DiffModels compares two models: schemata and (non-stub) properties added or
+// removed, and changes to schema inheritance, flags and required properties, and
+// to property types, ranges, reverses, formats and flags.
+func DiffModels(from, to *Model) ModelDiff {
+	var d ModelDiff
+	for name, fs := range from.Schemata {
+		ts := to.Schemata[name]
+		if ts == nil {
+			d.RemovedSchemata = append(d.RemovedSchemata, name)
+			continue
+		}
+		d.diffSchema(fs, ts)
+	}
+	for name := range to.Schemata {
+		if from.Schemata[name] == nil {
+			d.AddedSchemata = append(d.AddedSchemata, name)
+		}
+	}
+	for qname, fp := range from.QNames {
+		if fp.Stub {
+			continue
+		}
+		tp := to.QNames[qname]
+		if tp == nil || tp.Stub {
+			if to.Schemata[fp.Schema.Name] != nil {
+				d.RemovedProperties = append(d.RemovedProperties, qname)
+			}
+			continue
+		}
+		d.diffProperty(fp, tp)
+	}
+	for qname, tp := range to.QNames {
+		if tp.Stub {
+			continue
+		}
+		if fp := from.QNames[qname]; (fp == nil || fp.Stub) && from.Schemata[tp.Schema.Name] != nil {
+			d.AddedProperties = append(d.AddedProperties, qname)
+		}
+	}
+
+	sort.Strings(d.AddedSchemata)
+	sort.Strings(d.RemovedSchemata)
+	sort.Strings(d.AddedProperties)
+	sort.Strings(d.RemovedProperties)
+	sort.Slice(d.Changes, func(i, j int) bool {
+		if d.Changes[i].Subject != d.Changes[j].Subject {
+			return d.Changes[i].Subject < d.Changes[j].Subject
+		}
+		return d.Changes[i].Field < d.Changes[j].Field
+	})
+	return d
+}
+
+func (d *ModelDiff) change(subject, field, from, to string) {
+	if from != to {
+		d.Changes = append(d.Changes, FieldChange{Subject: subject, Field: field, Old: from, New: to})
+	}
+}
+
+func (d *ModelDiff) diffSchema(fs, ts *Schema) {
+	d.change(fs.Name, "extends", joinSchemaNames(fs.Extends), joinSchemaNames(ts.Extends))
+	d.change(fs.Name, "required", joinSorted(fs.Required), joinSorted(ts.Required))
+	d.change(fs.Name, "abstract", fmt.Sprint(fs.Abstract), fmt.Sprint(ts.Abstract))
+	d.change(fs.Name, "matchable", fmt.Sprint(fs.Matchable), fmt.Sprint(ts.Matchable))
+	d.change(fs.Name, "deprecated", fmt.Sprint(fs.Deprecated), fmt.Sprint(ts.Deprecated))
+	d.change(fs.Name, "label", fs.Label, ts.Label)
+}
+
+func (d *ModelDiff) diffProperty(fp, tp *Property) {
+	d.change(fp.QName, "type", fp.declaredType(), tp.declaredType())
+	d.change(fp.QName, "range", schemaName(fp.Range), schemaName(tp.Range))
+	d.change(fp.QName, "reverse", propertyName(fp.Reverse), propertyName(tp.Reverse))
+	d.change(fp.QName, "format", fp.Format, tp.Format)
+	d.change(fp.QName, "maxLength", fmt.Sprint(fp.maxLength()), fmt.Sprint(tp.maxLength()))
+	d.change(fp.QName, "matchable", fmt.Sprint(fp.Matchable), fmt.Sprint(tp.Matchable))
+	d.change(fp.QName, "deprecated", fmt.Sprint(fp.Deprecated), fmt.Sprint(tp.Deprecated))
+	d.change(fp.QName, "label", fp.Label, tp.Label)
+}
+
+func joinSchemaNames(xs []*Schema) string {
+	names := make([]string, len(xs))
+	for i, s := range xs {
+		names[i] = s.Name
+	}
+	return joinSorted(names)
+}
+
+func joinSorted(xs []string) string {
+	xs = slices.Clone(xs)
+	sort.Strings(xs)
+	return strings.Join(xs, ",")
+}
+
+func schemaName(s *Schema) string {
+	if s == nil {
+		return ""
+	}
+	return s.Name
+}
+
+func propertyName(p *Property) string {
+	if p == nil {
+		return ""
+	}
+	return p.Name
+}
+
+// declaredType is the type name from the spec if the registry does not know it,
+// since such properties fall back to string.
+func (p *Property) declaredType() string {
+	if p.typeName != "" && p.Schema.Model.registry.Get(p.typeName) == nil {
+		return p.typeName
+	}
+	return p.Type.Name()
+}
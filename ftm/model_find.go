@@ -0,0 +1,69 @@
+package ftm
+
+import (
+	"slices"
+	"strings"
+)
+
+// PropertyQuery selects properties in Model.FindProperties. Empty fields match
+// any property.
+type PropertyQuery struct {
+	Name   string // property name, e.g. "country"
+	Type   string // type name, e.g. "entity"
+	Group  string // type group, e.g. "names"
+	Range  string // range schema or an ancestor of it, e.g. "Asset"
+	Schema string // schema the property is defined on or inherited by
+	// IncludeStubs also returns reverse stub properties.
+	IncludeStubs bool
+}
+
+// FindProperties returns the properties matching q across all schemata, sorted
+// by qname. Each property is returned once, under the schema defining it.
+// For example, PropertyQuery{Type: "entity", Range: "Asset"} finds all entity
+// properties pointing at Asset or its descendants.
+func (m *Model) FindProperties(q PropertyQuery) []*Property {
+	var schema *Schema
+	if q.Schema != "" {
+		if schema = m.Get(q.Schema); schema == nil {
+			return nil
+		}
+	}
+	var out []*Property
+	for _, p := range m.Properties {
+		switch {
+		case p.Stub && !q.IncludeStubs:
+		case q.Name != "" && p.Name != q.Name:
+		case q.Type != "" && p.Type.Name() != q.Type:
+		case q.Group != "" && p.Type.Group() != q.Group:
+		case q.Range != "" && (p.Range == nil || !p.Range.IsA(q.Range)):
+		case schema != nil && schema.Properties[p.Name] != p:
+		default:
+			out = append(out, p)
+		}
+	}
+	slices.SortFunc(out, func(a, b *Property) int { return strings.Compare(a.QName, b.QName) })
+	return out
+}
+
+// ReverseOf returns the forward property a reverse stub was derived from, or
+// nil if p is not a stub.
+func (p *Property) ReverseOf() *Property {
+	if !p.Stub {
+		return nil
+	}
+	return p.Reverse
+}
+
+// InboundProperties returns the forward entity properties that can reference
+// an entity of schema s, i.e. whose range is s or one of its ancestors, sorted
+// by qname. For Company this includes Ownership:asset and Directorship:organization.
+func (m *Model) InboundProperties(s *Schema) []*Property {
+	var out []*Property
+	for _, p := range m.Properties {
+		if !p.Stub && p.Range != nil && s.IsA(p.Range.Name) {
+			out = append(out, p)
+		}
+	}
+	slices.SortFunc(out, func(a, b *Property) int { return strings.Compare(a.QName, b.QName) })
+	return out
+}
@@ -0,0 +1,82 @@
+package ftm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// dumpPropertySpec is a property in the JSON model dump. Unlike the YAML spec, the
+// reverse is given by name and reverse stubs are listed on the range schema.
+type dumpPropertySpec struct {
+	propertySpec
+	Reverse string `json:"reverse"`
+	Stub    bool   `json:"stub"`
+}
+
+type dumpSchemaSpec struct {
+	schemaSpec
+	Properties map[string]dumpPropertySpec `json:"properties"`
+}
+
+// NewModelFromJSON loads a model from the single JSON dump produced by the Python
+// package (and by Model.ToDict), so both implementations can pin the same artifact.
+func NewModelFromJSON(r io.Reader) (*Model, error) {
+	return NewModelFromJSONWith(r, ModelOptions{})
+}
+
+// NewModelFromJSONWith is NewModelFromJSON with options.
+func NewModelFromJSONWith(r io.Reader, opts ModelOptions) (*Model, error) {
+	var dump struct {
+		Schemata map[string]dumpSchemaSpec `json:"schemata"`
+		Version  ModelVersion              `json:"version"`
+	}
+	if err := json.NewDecoder(r).Decode(&dump); err != nil {
+		return nil, fmt.Errorf("decoding model dump: %w", err)
+	}
+	if len(dump.Schemata) == 0 {
+		return nil, fmt.Errorf("model dump has no schemata")
+	}
+
+	specs := make(map[string]schemaSpec, len(dump.Schemata))
+	for name, ds := range dump.Schemata {
+		spec := ds.schemaSpec
+		spec.Properties = map[string]propertySpec{}
+		for pn, dp := range ds.Properties {
+			// Reverse stubs are recreated from the forward property
+			if dp.Stub {
+				continue
+			}
+			ps := dp.propertySpec
+			// The dump omits false flags, so absence must not fall back to type defaults
+			matchable := ps.Matchable != nil && *ps.Matchable
+			ps.Matchable = &matchable
+			if dp.Reverse != "" {
+				ps.Reverse = &reverseSpec{Name: dp.Reverse}
+				if stub, ok := dump.Schemata[ps.Range].Properties[dp.Reverse]; ok {
+					hidden := stub.Hidden != nil && *stub.Hidden
+					ps.Reverse.Label = stub.Label
+					ps.Reverse.Hidden = &hidden
+				}
+			}
+			spec.Properties[pn] = ps
+		}
+		specs[name] = spec
+	}
+
+	m := newModel(nil, "")
+	if opts.Registry != nil {
+		m.registry = opts.Registry
+	}
+	m.upstream = dump.Version.Upstream
+	if err := m.addSpecs(specs); err != nil {
+		return nil, err
+	}
+	if err := m.resolveExtends(); err != nil {
+		return nil, err
+	}
+	if err := m.Generate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
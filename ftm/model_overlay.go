@@ -0,0 +1,205 @@
+package ftm
+
+import (
+	"fmt"
+	"io/fs"
+	"slices"
+)
+
+// NewModelWithOverlays loads the YAML schemata of base and layers each overlay
+// on top, in order, so internal schemata can be added without forking the
+// embedded files (e.g. NewModelWithOverlays(ftmschema.Files, os.DirFS("schemata"))).
+//
+// A schema name that is new in an overlay adds a schema. A known name extends
+// the existing schema:
+//   - label, plural, description and flags (abstract, hidden, matchable, ...)
+//     replace the previous values when set;
+//   - extends adds parents to the existing ones;
+//   - featured, required, requiredOneOf, caption, edge and temporalExtent
+//     replace the previous values when set;
+//   - new properties are added, and fields set on a known property replace
+//     its previous values, except that type, range and reverse name cannot be
+//     changed, since that would invalidate existing data. Refining an
+//     inherited property redefines it on the schema, based on the ancestor's
+//     definition.
+func NewModelWithOverlays(base fs.FS, overlays ...fs.FS) (*Model, error) {
+	specs := map[string]schemaSpec{}
+	err := readSpecFiles(base, ".", func(path string, defs map[string]schemaSpec) error {
+		for name, spec := range defs {
+			if _, ok := specs[name]; ok {
+				return fmt.Errorf("duplicate schema name: %s", name)
+			}
+			specs[name] = spec
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, overlay := range overlays {
+		err := readSpecFiles(overlay, ".", func(path string, defs map[string]schemaSpec) error {
+			for name, spec := range defs {
+				prev, ok := specs[name]
+				if !ok {
+					specs[name] = spec
+					continue
+				}
+				merged, err := overlaySchemaSpec(specs, name, prev, spec)
+				if err != nil {
+					return fmt.Errorf("overlay %d, %s: %w", i+1, path, err)
+				}
+				specs[name] = merged
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	m := newModel(base, ".")
+	m.upstream = readUpstreamVersion(base, ".")
+	if err := m.addSpecs(specs); err != nil {
+		return nil, err
+	}
+	if err := m.resolveExtends(); err != nil {
+		return nil, err
+	}
+	if err := m.Generate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// overlaySchemaSpec applies the fields set in over to base. specs holds the
+// schemata loaded so far, to look up inherited properties.
+func overlaySchemaSpec(specs map[string]schemaSpec, name string, base, over schemaSpec) (schemaSpec, error) {
+	out := base
+	out.Label = firstNonEmpty(over.Label, base.Label)
+	out.Plural = firstNonEmpty(over.Plural, base.Plural)
+	out.Description = firstNonEmpty(over.Description, base.Description)
+	out.RDF = firstNonEmpty(over.RDF, base.RDF)
+	for _, flag := range []struct{ dst, src **bool }{
+		{&out.Abstract, &over.Abstract},
+		{&out.Hidden, &over.Hidden},
+		{&out.Generated, &over.Generated},
+		{&out.Matchable, &over.Matchable},
+		{&out.Deprecated, &over.Deprecated},
+	} {
+		if *flag.src != nil {
+			*flag.dst = *flag.src
+		}
+	}
+	out.Extends = slices.Clone(base.Extends)
+	for _, parent := range over.Extends {
+		if !slices.Contains(out.Extends, parent) {
+			out.Extends = append(out.Extends, parent)
+		}
+	}
+	if len(over.Featured) > 0 {
+		out.Featured = over.Featured
+	}
+	if len(over.Required) > 0 {
+		out.Required = over.Required
+	}
+	if len(over.RequiredOneOf) > 0 {
+		out.RequiredOneOf = over.RequiredOneOf
+	}
+	if len(over.Caption) > 0 {
+		out.Caption = over.Caption
+	}
+	if over.Edge.Source != "" || over.Edge.Target != "" {
+		out.Edge = over.Edge
+	}
+	if len(over.Temporal.Start) > 0 || len(over.Temporal.End) > 0 {
+		out.Temporal = over.Temporal
+	}
+
+	out.Properties = make(map[string]propertySpec, len(base.Properties)+len(over.Properties))
+	for pn, ps := range base.Properties {
+		out.Properties[pn] = ps
+	}
+	for pn, ps := range over.Properties {
+		prev, ok := out.Properties[pn]
+		if !ok {
+			// Refining an inherited property redefines it on this schema
+			if prev, ok = inheritedPropertySpec(specs, base.Extends, pn); !ok {
+				out.Properties[pn] = ps
+				continue
+			}
+		}
+		merged, err := overlayPropertySpec(prev, ps)
+		if err != nil {
+			return out, fmt.Errorf("property %s:%s: %w", name, pn, err)
+		}
+		out.Properties[pn] = merged
+	}
+	return out, nil
+}
+
+// inheritedPropertySpec finds the nearest definition of a property among the
+// ancestors named by extends.
+func inheritedPropertySpec(specs map[string]schemaSpec, extends []string, name string) (propertySpec, bool) {
+	queue := slices.Clone(extends)
+	seen := map[string]bool{}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if seen[cur] {
+			continue
+		}
+		seen[cur] = true
+		spec := specs[cur]
+		if ps, ok := spec.Properties[name]; ok {
+			return ps, true
+		}
+		queue = append(queue, spec.Extends...)
+	}
+	return propertySpec{}, false
+}
+
+// overlayPropertySpec applies the fields set in over to base.
+func overlayPropertySpec(base, over propertySpec) (propertySpec, error) {
+	if over.Type != "" && over.Type != base.Type {
+		return base, fmt.Errorf("cannot change type from %s to %s", base.Type, over.Type)
+	}
+	if over.Range != "" && over.Range != base.Range {
+		return base, fmt.Errorf("cannot change range from %s to %s", base.Range, over.Range)
+	}
+	out := base
+	if over.Reverse != nil {
+		if base.Reverse != nil && over.Reverse.Name != "" && over.Reverse.Name != base.Reverse.Name {
+			return base, fmt.Errorf("cannot rename reverse %s to %s", base.Reverse.Name, over.Reverse.Name)
+		}
+		rev := reverseSpec{}
+		if base.Reverse != nil {
+			rev = *base.Reverse
+		}
+		rev.Name = firstNonEmpty(over.Reverse.Name, rev.Name)
+		rev.Label = firstNonEmpty(over.Reverse.Label, rev.Label)
+		if over.Reverse.Hidden != nil {
+			rev.Hidden = over.Reverse.Hidden
+		}
+		out.Reverse = &rev
+	}
+	out.Label = firstNonEmpty(over.Label, base.Label)
+	out.Description = firstNonEmpty(over.Description, base.Description)
+	out.Format = firstNonEmpty(over.Format, base.Format)
+	out.RDF = firstNonEmpty(over.RDF, base.RDF)
+	if over.Hidden != nil {
+		out.Hidden = over.Hidden
+	}
+	if over.Matchable != nil {
+		out.Matchable = over.Matchable
+	}
+	if over.Deprecated != nil {
+		out.Deprecated = over.Deprecated
+	}
+	if over.MaxLength != nil {
+		out.MaxLength = over.MaxLength
+	}
+	if len(over.Values) > 0 || len(over.Enum) > 0 {
+		out.Values, out.Enum = over.Values, over.Enum
+	}
+	return out, nil
+}
@@ -0,0 +1,79 @@
+package ftm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// ParityReport compares the local model with an upstream model dump. Missing
+// names exist only upstream, extra names only locally. In Changes, Old is the
+// upstream and New the local value. Names are sorted.
+type ParityReport struct {
+	MissingSchemata   []string      `json:"missing_schemata"`
+	ExtraSchemata     []string      `json:"extra_schemata"`
+	MissingProperties []string      `json:"missing_properties"`
+	ExtraProperties   []string      `json:"extra_properties"`
+	MissingTypes      []string      `json:"missing_types"`
+	ExtraTypes        []string      `json:"extra_types"`
+	Changes           []FieldChange `json:"changes"`
+}
+
+// Complete reports whether the local model covers the upstream one: no missing
+// schemata, properties or types, and no property with a different type or range.
+// Local additions and changed labels or flags are allowed.
+func (r ParityReport) Complete() bool {
+	if len(r.MissingSchemata)+len(r.MissingProperties)+len(r.MissingTypes) > 0 {
+		return false
+	}
+	for _, c := range r.Changes {
+		if c.Field == "type" || c.Field == "range" {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckParity reads the model dump of the upstream Python package (python -m
+// followthemoney dump-model) and compares it with local, so the port can be
+// verified against each upstream release. Property types the local registry
+// does not know are reported in MissingTypes and as type changes.
+func CheckParity(upstream io.Reader, local *Model) (ParityReport, error) {
+	var r ParityReport
+	data, err := io.ReadAll(upstream)
+	if err != nil {
+		return r, err
+	}
+	var dump struct {
+		Types map[string]json.RawMessage `json:"types"`
+	}
+	if err := json.Unmarshal(data, &dump); err != nil {
+		return r, fmt.Errorf("decoding model dump: %w", err)
+	}
+	up, err := NewModelFromJSONWith(bytes.NewReader(data), ModelOptions{Registry: local.registry})
+	if err != nil {
+		return r, err
+	}
+
+	d := DiffModels(up, local)
+	r.MissingSchemata, r.ExtraSchemata = d.RemovedSchemata, d.AddedSchemata
+	r.MissingProperties, r.ExtraProperties = d.RemovedProperties, d.AddedProperties
+	r.Changes = d.Changes
+	for name := range dump.Types {
+		if local.registry.Get(name) == nil {
+			r.MissingTypes = append(r.MissingTypes, name)
+		}
+	}
+	if len(dump.Types) > 0 {
+		for name := range local.registry.types {
+			if _, ok := dump.Types[name]; !ok {
+				r.ExtraTypes = append(r.ExtraTypes, name)
+			}
+		}
+	}
+	sort.Strings(r.MissingTypes)
+	sort.Strings(r.ExtraTypes)
+	return r, nil
+}
@@ -1,8 +1,18 @@
 package ftm
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
+	"time"
 )
 
 func TestDefaultLoadsLocalSchema(t *testing.T) {
@@ -37,3 +47,1167 @@ func TestNewModelFromPath(t *testing.T) {
 		t.Fatalf("expected Organization schema")
 	}
 }
+
+func TestSchemaCanMatch(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	le, company, person, vessel := m.Get("LegalEntity"), m.Get("Company"), m.Get("Person"), m.Get("Vessel")
+	if !company.CanMatch(le) || !le.CanMatch(person) {
+		t.Fatalf("expected LegalEntity to match Company and Person")
+	}
+	if company.CanMatch(person) || person.CanMatch(vessel) {
+		t.Fatalf("unexpected match between unrelated schemata")
+	}
+	if len(m.MatchableSchemata()) == 0 {
+		t.Fatalf("expected matchable schemata")
+	}
+}
+
+func TestSchemaDescendants(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	names := func(xs []*Schema) []string {
+		out := make([]string, len(xs))
+		for i, sc := range xs {
+			out[i] = sc.Name
+		}
+		return out
+	}
+	if got := names(m.Get("Organization").DescendantsSorted()); !slices.Equal(got, []string{"Company", "PublicBody"}) {
+		t.Fatalf("Organization descendants: %v", got)
+	}
+	if got := m.Get("Person").DescendantsSorted(); len(got) != 0 {
+		t.Fatalf("Person has no descendants: %v", names(got))
+	}
+
+	thing := m.Get("Thing")
+	all := thing.DescendantsSorted()
+	matchable := thing.MatchableDescendants()
+	if !slices.IsSorted(names(all)) || slices.Contains(names(all), "Thing") {
+		t.Fatalf("descendants should be sorted and exclude the schema: %v", names(all))
+	}
+	if !slices.Contains(names(all), "Asset") || slices.Contains(names(matchable), "Asset") {
+		t.Fatalf("Asset is a non-matchable descendant of Thing")
+	}
+	want := 0
+	for _, sc := range all {
+		if !sc.IsA("Thing") {
+			t.Fatalf("%s does not extend Thing", sc.Name)
+		}
+		if sc.Matchable {
+			want++
+		}
+	}
+	if len(matchable) != want || !slices.IsSorted(names(matchable)) || !slices.Contains(names(matchable), "Company") {
+		t.Fatalf("matchable descendants: %v", names(matchable))
+	}
+}
+
+func TestSchemaPropertiesByType(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	names := func(xs []*Property) []string {
+		out := make([]string, len(xs))
+		for i, p := range xs {
+			out[i] = p.Name
+		}
+		return out
+	}
+	person := m.Get("Person")
+	countries := person.PropertiesByType(m.Registry().Country)
+	got := names(countries)
+	// nationality is declared on Person, country inherited from Thing.
+	if !slices.Contains(got, "nationality") || !slices.Contains(got, "country") || !slices.IsSorted(got) {
+		t.Fatalf("country properties: %v", got)
+	}
+	for _, p := range countries {
+		if p.Type.Name() != "country" {
+			t.Fatalf("%s is a %s property", p.Name, p.Type.Name())
+		}
+	}
+	if byGroup := names(person.PropertiesByGroup("countries")); !slices.Equal(byGroup, got) {
+		t.Fatalf("countries group: %v", byGroup)
+	}
+	// firstName is deliberately a string, not a name.
+	if got := names(person.PropertiesByGroup("names")); !slices.Contains(got, "name") || slices.Contains(got, "firstName") {
+		t.Fatalf("names group: %v", got)
+	}
+	// Types without a group, such as text, are never matched by the empty group.
+	if got := person.PropertiesByGroup(""); len(got) != 0 {
+		t.Fatalf("empty group: %v", names(got))
+	}
+	if got := m.Get("Thing").PropertiesByType(m.Registry().Gender); len(got) != 0 {
+		t.Fatalf("Thing has no gender: %v", names(got))
+	}
+}
+
+func TestSchemaValidateAggregatesErrors(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	err = m.Get("Ownership").Validate(map[string][]string{"startDate": {"not a date"}})
+	var ve *ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if len(ve.Errors) != 3 {
+		t.Fatalf("expected 3 errors (owner, asset, startDate), got %v", ve.Errors)
+	}
+}
+
+func TestSchemaRequiredOneOf(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	le := m.Get("LegalEntity")
+	le.RequiredOneOf = [][]string{{"email", "phone"}}
+	defer func() { le.RequiredOneOf = nil }()
+
+	person := m.Get("Person")
+	if err := person.Validate(map[string][]string{"name": {"Ana"}}); err == nil {
+		t.Fatalf("expected inherited required-one-of group to fail")
+	}
+	if err := person.Validate(map[string][]string{"name": {"Ana"}, "phone": {"+12025557612"}}); err != nil {
+		t.Fatalf("expected valid person, got %v", err)
+	}
+}
+
+func TestSchemaToDict(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	data := m.Get("Ownership").ToDict()
+	edge, ok := data["edge"].(map[string]any)
+	if !ok || edge["source"] != "owner" || edge["target"] != "asset" {
+		t.Fatalf("unexpected edge: %v", data["edge"])
+	}
+	props := data["properties"].(map[string]any)
+	if _, ok := props["name"]; ok {
+		t.Fatalf("inherited property should not be serialized")
+	}
+	owner := props["owner"].(map[string]any)
+	if owner["qname"] != "Ownership:owner" || owner["range"] != "LegalEntity" || owner["type"] != "entity" || owner["reverse"] != "ownershipOwner" {
+		t.Fatalf("unexpected owner property: %v", owner)
+	}
+	iban := m.Get("BankAccount").ToDict()["properties"].(map[string]any)["iban"].(map[string]any)
+	if iban["format"] != "iban" || iban["maxLength"] != 64 {
+		t.Fatalf("unexpected iban property: %v", iban)
+	}
+	if _, ok := m.ToDict()["types"].(map[string]any)["entity"]; !ok {
+		t.Fatalf("expected entity type in model dump")
+	}
+}
+
+func TestSchemaCaptionInherited(t *testing.T) {
+	dir := t.TempDir()
+	spec := `Base:
+  abstract: true
+  caption:
+    - title
+  properties:
+    title:
+      label: Title
+Leaf:
+  extends:
+    - Base
+  properties:
+    code:
+      label: Code
+`
+	if err := os.WriteFile(dir+"/test.yaml", []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewModel(dir)
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	e := NewEntityProxy(m.Get("Leaf"), "x")
+	_ = e.Add("title", []string{"Tender 42"}, false)
+	if got := e.Caption(); got != "Tender 42" {
+		t.Fatalf("expected inherited caption, got %q", got)
+	}
+}
+
+func TestSchemaSortedProperties(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	person := m.Get("Person")
+	props := person.SortedProperties()
+	if props[0].Name != person.Caption[0] {
+		t.Fatalf("expected first caption property first, got %s", props[0].Name)
+	}
+	for _, p := range props {
+		if p.Hidden || p.Stub {
+			t.Fatalf("unexpected hidden or stub property %s", p.Name)
+		}
+	}
+	all := person.SortedPropertiesWith(PropertySortOptions{IncludeHidden: true, IncludeStubs: true})
+	if len(all) != len(person.Properties) {
+		t.Fatalf("expected %d properties, got %d", len(person.Properties), len(all))
+	}
+}
+
+func TestSchemaEdgeLabels(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	own := m.Get("Ownership")
+	if own.EdgeLabel() != "owns" || own.ReverseEdgeLabel() != "Owners" {
+		t.Fatalf("unexpected ownership labels: %q / %q", own.EdgeLabel(), own.ReverseEdgeLabel())
+	}
+	fam := m.Get("Family")
+	if fam.ReverseEdgeLabel() != fam.EdgeLabel() {
+		t.Fatalf("undirected edge labels should match")
+	}
+	if m.Get("Person").EdgeLabel() != "" {
+		t.Fatalf("expected no edge label for node schema")
+	}
+}
+
+func TestDeprecationHandler(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	var warnings []DeprecationWarning
+	m.SetDeprecationHandler(func(w DeprecationWarning) { warnings = append(warnings, w) })
+	e := NewEntityProxy(m.Get("Company"), "c1")
+	_ = e.Add("name", []string{"ACME"}, false)
+	_ = e.Add("parent", []string{"c2"}, false)
+	if len(warnings) != 1 || warnings[0].QName != "LegalEntity:parent" {
+		t.Fatalf("expected one warning for LegalEntity:parent, got %v", warnings)
+	}
+	if !strings.Contains(warnings[0].Caller, "model_test.go") {
+		t.Fatalf("expected caller in test file, got %q", warnings[0].Caller)
+	}
+	m.SetDeprecationHandler(nil)
+	_ = e.Add("parent", []string{"c3"}, false)
+	if len(warnings) != 1 {
+		t.Fatalf("expected handler to be removed")
+	}
+}
+
+func TestPropertyEnumValues(t *testing.T) {
+	dir := t.TempDir()
+	spec := `Case:
+  properties:
+    status:
+      label: Status
+      values: [open, closed]
+`
+	if err := os.WriteFile(dir+"/case.yaml", []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewModel(dir)
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	e := NewEntityProxy(m.Get("Case"), "c1")
+	_ = e.Add("status", []string{"OPEN", "pending"}, false)
+	if got := e.Get("status"); len(got) != 1 || got[0] != "open" {
+		t.Fatalf("expected only the allowed value, got %v", got)
+	}
+	status := m.Get("Case").Get("status")
+	if v, ok := e.UnsafeAdd(status, "pending", false); ok || v != "" {
+		t.Fatalf("UnsafeAdd should reject values outside the enum, got %q", v)
+	}
+	if v, ok := e.UnsafeAdd(status, "Closed", false); !ok || v != "closed" {
+		t.Fatalf("UnsafeAdd should store the declared spelling, got %q", v)
+	}
+	err = m.Get("Case").Validate(map[string][]string{"status": {"pending"}})
+	if err == nil || !strings.Contains(err.Error(), "not an allowed value") {
+		t.Fatalf("expected enum validation error, got %v", err)
+	}
+}
+
+func TestModelLint(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	if issues := m.Lint(); len(issues) != 0 {
+		t.Fatalf("expected default model to be clean, got %v", issues)
+	}
+
+	dir := t.TempDir()
+	spec := `Leaf:
+  label: Leaf
+Base:
+  label: Base
+  abstract: true
+  properties:
+    link:
+      label: Link
+      type: entity
+      range: Missing
+    score:
+      type: percentage
+`
+	if err := os.WriteFile(dir+"/lint.yaml", []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, err = NewModel(dir)
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	kinds := map[string]bool{}
+	for _, issue := range m.Lint() {
+		kinds[issue.Kind] = true
+	}
+	for _, kind := range []string{"dangling-range", "missing-plural", "unreachable", "missing-label", "unknown-type"} {
+		if !kinds[kind] {
+			t.Fatalf("expected %s issue, got %v", kind, m.Lint())
+		}
+	}
+}
+
+func TestNewModelFromJSONRoundTrip(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	dump, err := json.Marshal(m.ToDict())
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := NewModelFromJSON(bytes.NewReader(dump))
+	if err != nil {
+		t.Fatalf("NewModelFromJSON: %v", err)
+	}
+	again, _ := json.Marshal(loaded.ToDict())
+	if !bytes.Equal(dump, again) {
+		t.Fatalf("model dump changed after round trip")
+	}
+	owner := loaded.Get("Ownership").Get("owner")
+	if owner.Reverse == nil || owner.Reverse.Label != "Assets and shares" {
+		t.Fatalf("expected reverse stub to be restored, got %#v", owner.Reverse)
+	}
+}
+
+func TestDiffModels(t *testing.T) {
+	from, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	to, _ := NewModel("../schema")
+	if d := DiffModels(from, to); !d.Empty() {
+		t.Fatalf("expected no differences, got %+v", d)
+	}
+	delete(to.QNames, "Person:birthDate")
+	to.QNames["Person:nationality"].Type = defaultRegistry.String
+	d := DiffModels(from, to)
+	if len(d.RemovedProperties) != 1 || d.RemovedProperties[0] != "Person:birthDate" {
+		t.Fatalf("expected removed birthDate, got %v", d.RemovedProperties)
+	}
+	if len(d.Changes) != 2 || d.Changes[0].Field != "maxLength" || d.Changes[1].Field != "type" || !d.Breaking() {
+		t.Fatalf("expected breaking type change, got %v", d.Changes)
+	}
+}
+
+func TestWatchModelReloads(t *testing.T) {
+	dir := t.TempDir()
+	write := func(label string) {
+		spec := "Thing:\n  label: " + label + "\n  properties:\n    name:\n      label: Name\n"
+		if err := os.WriteFile(dir+"/thing.yaml", []byte(spec), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("First")
+	mw, err := WatchModel(dir, false)
+	if err != nil {
+		t.Fatalf("WatchModel: %v", err)
+	}
+	defer mw.Close()
+	reloaded := make(chan *Model, 1)
+	mw.OnReload(func(m *Model, err error) {
+		if err == nil {
+			reloaded <- m
+		}
+	})
+	write("Second")
+	select {
+	case m := <-reloaded:
+		if m.Get("Thing").Label != "Second" || mw.Model() != m {
+			t.Fatalf("expected reloaded model, got label %q", m.Get("Thing").Label)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("model was not reloaded")
+	}
+}
+
+func TestNewModelURLCachesWithETag(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	dump, _ := json.Marshal(m.ToDict())
+	requests, served := 0, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		served++
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write(dump)
+	}))
+	defer srv.Close()
+
+	opts := ModelURLOptions{CacheDir: t.TempDir()}
+	for i := 0; i < 2; i++ {
+		loaded, err := NewModelURLWith(srv.URL, opts)
+		if err != nil {
+			t.Fatalf("NewModelURLWith: %v", err)
+		}
+		if loaded.Get("Person") == nil {
+			t.Fatalf("expected Person schema")
+		}
+	}
+	if requests != 2 || served != 1 {
+		t.Fatalf("expected one download and one revalidation, got %d/%d", served, requests)
+	}
+	opts.SHA256 = strings.Repeat("0", 64)
+	if _, err := NewModelURLWith(srv.URL, opts); err == nil {
+		t.Fatalf("expected digest mismatch")
+	}
+}
+
+func TestConcurrentGenerate(t *testing.T) {
+	m := newModel(os.DirFS("../schema"), ".")
+	if err := m.loadAll(); err != nil {
+		t.Fatalf("loadAll: %v", err)
+	}
+	var wg sync.WaitGroup
+	for _, name := range []string{"Person", "Company", "Ownership", "Payment"} {
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := m.Schemata[name].Generate(); err != nil {
+					t.Errorf("Generate %s: %v", name, err)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+	if err := m.Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if !m.Get("Person").IsA("LegalEntity") || m.Get("LegalEntity").Properties["ownershipOwner"] == nil {
+		t.Fatalf("inheritance or reverse properties not resolved")
+	}
+}
+
+func TestModelView(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	v, err := m.View(ViewOptions{})
+	if err != nil {
+		t.Fatalf("View: %v", err)
+	}
+	if v.Get("Thing") != nil || v.Get("Person") == nil {
+		t.Fatalf("expected abstract schemata to be removed")
+	}
+	for _, s := range v.Schemata {
+		if s.Abstract || s.Hidden || s.Deprecated {
+			t.Fatalf("unexpected schema %s in view", s.Name)
+		}
+		for _, p := range s.Properties {
+			if p.Hidden || p.Deprecated {
+				t.Fatalf("unexpected property %s in view", p.QName)
+			}
+			if p.Range != nil && v.Get(p.Range.Name) != p.Range {
+				t.Fatalf("range of %s not linked into view", p.QName)
+			}
+		}
+	}
+	person := v.Get("Person")
+	if !person.IsA("LegalEntity") || person.Get("name") == nil || person.Get("ownershipOwner") == nil {
+		t.Fatalf("expected inherited properties on Person")
+	}
+	if m.Get("Thing") == nil || m.Get("Person").Get("name").QName != "Thing:name" {
+		t.Fatalf("source model was modified")
+	}
+	full, err := m.View(ViewOptions{IncludeHidden: true, IncludeAbstract: true, IncludeDeprecated: true})
+	if err != nil {
+		t.Fatalf("View: %v", err)
+	}
+	if len(full.Schemata) != len(m.Schemata) || len(full.QNames) != len(m.QNames) {
+		t.Fatalf("expected full view to match model: %d/%d qnames", len(full.QNames), len(m.QNames))
+	}
+	entities, err := m.View(ViewOptions{ExcludeGenerated: true})
+	if err != nil {
+		t.Fatalf("View: %v", err)
+	}
+	if entities.Get("Document") != nil || entities.Get("Person") == nil {
+		t.Fatalf("expected generated schemata to be removed")
+	}
+	for _, p := range entities.Properties {
+		if p.Range != nil && p.Range.Generated {
+			t.Fatalf("unexpected property %s with generated range", p.QName)
+		}
+	}
+}
+
+func TestModelVersion(t *testing.T) {
+	dir := t.TempDir()
+	spec := `
+Thing:
+  label: Thing
+  plural: Things
+  properties:
+    name:
+      label: Name
+      type: name
+`
+	if err := os.WriteFile(dir+"/thing.yaml", []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dir+"/VERSION", []byte("3.8.0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewModel(dir)
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	v := m.Version()
+	if v.Upstream != "3.8.0" || len(v.Hash) != 64 || v.String() != "3.8.0+"+v.Hash[:12] {
+		t.Fatalf("unexpected version %+v", v)
+	}
+
+	overlay := strings.Replace(spec, "label: Name", "label: Full name", 1)
+	if err := os.WriteFile(dir+"/thing.yaml", []byte(overlay), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	changed, err := NewModel(dir)
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	if changed.Version().Hash == v.Hash {
+		t.Fatalf("expected hash to change with the schemata")
+	}
+
+	raw, _ := json.Marshal(m.ToDict())
+	loaded, err := NewModelFromJSON(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("NewModelFromJSON: %v", err)
+	}
+	if loaded.Version() != v {
+		t.Fatalf("version not preserved by dump: %+v != %+v", loaded.Version(), v)
+	}
+}
+
+func TestNewModelWithOverlays(t *testing.T) {
+	overlay := fstest.MapFS{
+		"internal.yaml": {Data: []byte(`
+Person:
+  featured: [name, nationality, employeeNumber]
+  properties:
+    employeeNumber:
+      label: Employee number
+      type: identifier
+    name:
+      label: Full name
+Customer:
+  label: Customer
+  plural: Customers
+  extends: [Person]
+  properties:
+    customerSince:
+      label: Customer since
+      type: date
+`)},
+	}
+	m, err := NewModelWithOverlays(os.DirFS("../schema"), overlay)
+	if err != nil {
+		t.Fatalf("NewModelWithOverlays: %v", err)
+	}
+	person := m.Get("Person")
+	if person.Get("employeeNumber") == nil || person.Get("name").Label != "Full name" || person.Get("name").Type != defaultRegistry.Name {
+		t.Fatalf("expected overlaid properties on Person")
+	}
+	if person.Get("nationality") == nil || person.Label != "Person" || !person.IsA("LegalEntity") {
+		t.Fatalf("expected base definition of Person to be kept")
+	}
+	customer := m.Get("Customer")
+	if customer == nil || !customer.IsA("Person") || customer.Get("employeeNumber") == nil {
+		t.Fatalf("expected Customer to extend the overlaid Person")
+	}
+
+	bad := fstest.MapFS{"bad.yaml": {Data: []byte("Person:\n  properties:\n    name:\n      type: string\n")}}
+	if _, err := NewModelWithOverlays(os.DirFS("../schema"), bad); err == nil || !strings.Contains(err.Error(), "cannot change type") {
+		t.Fatalf("expected type change to be rejected, got %v", err)
+	}
+}
+
+func TestSchemaJSONSchema(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	docs := m.JSONSchemas()
+	if _, ok := docs["Thing"]; ok {
+		t.Fatalf("expected abstract schemata to be skipped")
+	}
+	doc := docs["Person"]
+	if doc["$schema"] != JSONSchemaDialect || doc["title"] != "Person" {
+		t.Fatalf("unexpected document header: %v", doc)
+	}
+	top := doc["properties"].(map[string]any)
+	if top["schema"].(map[string]any)["const"] != "Person" {
+		t.Fatalf("expected schema const")
+	}
+	props := top["properties"].(map[string]any)["properties"].(map[string]any)
+	item := func(name string) map[string]any {
+		return props[name].(map[string]any)["items"].(map[string]any)
+	}
+	if item("birthDate")["pattern"] == nil || item("email")["format"] != "email" || item("name")["maxLength"] == nil {
+		t.Fatalf("expected type constraints: %v %v", item("birthDate"), item("email"))
+	}
+	if enum, ok := item("gender")["enum"].([]string); !ok || len(enum) != 3 {
+		t.Fatalf("expected gender enum, got %v", item("gender"))
+	}
+	if _, err := json.Marshal(docs); err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+}
+
+func TestOpenAPIComponents(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	schemas := m.OpenAPIComponents()["schemas"].(map[string]any)
+	entity := schemas["Entity"].(map[string]any)
+	mapping := entity["discriminator"].(map[string]any)["mapping"].(map[string]string)
+	if mapping["Person"] != "#/components/schemas/Person" || schemas["Person"] == nil {
+		t.Fatalf("expected Person to be referenced from Entity")
+	}
+	for name, ref := range mapping {
+		if schemas[name] == nil || ref != "#/components/schemas/"+name {
+			t.Fatalf("dangling reference %s", ref)
+		}
+	}
+	if _, ok := mapping["Thing"]; ok {
+		t.Fatalf("expected abstract schemata to be skipped")
+	}
+	stmt := schemas["Statement"].(map[string]any)["properties"].(map[string]any)
+	if stmt["original_value"] == nil || stmt["external"].(map[string]any)["type"] != "boolean" {
+		t.Fatalf("unexpected statement schema: %v", stmt)
+	}
+}
+
+func TestWriteTypeScript(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	var buf, again bytes.Buffer
+	if err := WriteTypeScript(&buf, m); err != nil {
+		t.Fatalf("WriteTypeScript: %v", err)
+	}
+	_ = WriteTypeScript(&again, m)
+	if buf.String() != again.String() {
+		t.Fatalf("expected stable output")
+	}
+	ts := buf.String()
+	for _, want := range []string{
+		"export enum SchemaName {",
+		`  Person = "Person",`,
+		`  names = "names",`,
+		"export interface PersonProperties extends LegalEntityProperties {",
+		"  nationality?: string[];",
+		"export interface Person extends EntityBase {",
+		"  schema: SchemaName.Person;",
+		"  | Person\n",
+	} {
+		if !strings.Contains(ts, want) {
+			t.Fatalf("expected %q in output", want)
+		}
+	}
+	if strings.Contains(ts, "export interface Thing extends EntityBase") {
+		t.Fatalf("expected no entity interface for abstract schemata")
+	}
+}
+
+type labelledStringType struct{ *StringType }
+
+func (labelledStringType) Label() string { return "Free text" }
+
+func TestModelOwnRegistry(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register(labelledStringType{NewStringType()})
+	custom, err := NewModelFSWith(os.DirFS("../schema"), ".", ModelOptions{Registry: reg})
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	shared, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if custom.Registry() != reg || shared.Registry() != DefaultRegistry() {
+		t.Fatalf("unexpected registries")
+	}
+	if got := custom.Get("Thing").Get("publisher").Type.Label(); got != "Free text" {
+		t.Fatalf("custom model type label: %s", got)
+	}
+	if got := shared.Get("Thing").Get("publisher").Type.Label(); got != "String" {
+		t.Fatalf("shared model type label: %s", got)
+	}
+	view, err := custom.View(ViewOptions{})
+	if err != nil {
+		t.Fatalf("view: %v", err)
+	}
+	if view.Registry() != reg {
+		t.Fatalf("view should keep the model registry")
+	}
+}
+
+func TestModelRegistryTypedFields(t *testing.T) {
+	topics := NewTopicType()
+	topics.values["role.custom"] = "Custom role"
+	reg := NewRegistry()
+	reg.Register(topics)
+	if reg.Topic != topics || reg.Get("topic") != topics {
+		t.Fatalf("register should replace the typed field")
+	}
+	custom, err := NewModelFSWith(os.DirFS("../schema"), ".", ModelOptions{Registry: reg})
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	shared, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	SetCleanCache(NewCleanCache(16))
+	defer SetCleanCache(nil)
+
+	p := NewEntityProxy(custom.Get("Person"), "p1")
+	_ = p.Add("topics", []string{"role.custom"}, false)
+	if !p.HasTopic("role.*") {
+		t.Fatalf("custom topic should be kept: %v", p.Get("topics"))
+	}
+	if got := p.RiskTopics(); got.MostSevere != "" {
+		t.Fatalf("custom role is no risk: %+v", got)
+	}
+	q := NewEntityProxy(shared.Get("Person"), "p2")
+	_ = q.Add("topics", []string{"role.custom"}, false)
+	if len(q.Get("topics")) != 0 {
+		t.Fatalf("shared model should not see the custom topic: %v", q.Get("topics"))
+	}
+}
+
+func TestGeneratedAccessors(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	p := NewPerson(m, "p1")
+	if err := p.AddBirthDate("1970-01-02"); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	_ = p.AddName("Jane Doe")
+	if got := p.BirthDate(); len(got) != 1 || got[0] != "1970-01-02" {
+		t.Fatalf("birthDate: %v", got)
+	}
+	if _, ok := AsCompany(p.EntityProxy); ok {
+		t.Fatalf("a person is not a company")
+	}
+	le, ok := AsLegalEntity(p.EntityProxy)
+	if !ok || le.Name()[0] != "Jane Doe" {
+		t.Fatalf("expected legal entity view of person")
+	}
+
+	var buf bytes.Buffer
+	opts := AccessorOptions{Package: "ftm", Schemata: []string{"LegalEntity", "Person", "Organization", "Company", "Address", "Ownership", "Directorship", "Sanction"}}
+	if err := WriteAccessors(&buf, m, opts); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	current, err := os.ReadFile("accessors_gen.go")
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), current) {
+		t.Fatalf("accessors_gen.go is out of date, run go generate")
+	}
+}
+
+func TestModelSchemaSet(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	set, err := m.SchemaSet("Organization")
+	if err != nil {
+		t.Fatalf("SchemaSet: %v", err)
+	}
+	if !set.Contains("Organization") || !set.Contains("Company") || set.Contains("Person") {
+		t.Fatalf("unexpected set %v", set.Names())
+	}
+	matchable, err := m.SchemaSetWith(SchemaSetOptions{MatchableOnly: true}, "Thing")
+	if err != nil {
+		t.Fatalf("SchemaSetWith: %v", err)
+	}
+	for _, s := range matchable {
+		if !s.Matchable {
+			t.Fatalf("unexpected non-matchable %s", s.Name)
+		}
+	}
+	if matchable.Contains("Thing") || !matchable.Contains("Person") {
+		t.Fatalf("unexpected matchable set %v", matchable.Names())
+	}
+	if _, err := m.SchemaSet("Nope"); err == nil {
+		t.Fatalf("expected unknown schema error")
+	}
+
+	statements := []Statement{{EntityID: "a", Schema: "Company"}, {EntityID: "b", Schema: "Person"}}
+	src := func(yield func(Statement, error) bool) {
+		for _, st := range statements {
+			if !yield(st, nil) {
+				return
+			}
+		}
+	}
+	var ids []string
+	for st := range set.FilterStatements(src) {
+		ids = append(ids, st.EntityID)
+	}
+	if len(ids) != 1 || ids[0] != "a" {
+		t.Fatalf("unexpected filtered statements %v", ids)
+	}
+}
+
+func TestModelFindProperties(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	assets := m.FindProperties(PropertyQuery{Type: "entity", Range: "Asset"})
+	found := false
+	for _, p := range assets {
+		if p.Range == nil || !p.Range.IsA("Asset") || p.Stub {
+			t.Fatalf("unexpected property %s", p.QName)
+		}
+		found = found || p.QName == "Ownership:asset"
+	}
+	if !found {
+		t.Fatalf("expected Ownership:asset in %d results", len(assets))
+	}
+	names := m.FindProperties(PropertyQuery{Group: "names", Schema: "Person"})
+	if len(names) == 0 {
+		t.Fatalf("unexpected name properties %v", names)
+	}
+	for _, p := range names {
+		if m.Get("Person").Get(p.Name) != p {
+			t.Fatalf("%s is not a property of Person", p.QName)
+		}
+	}
+	if got := m.FindProperties(PropertyQuery{Name: "name", Schema: "Nope"}); got != nil {
+		t.Fatalf("expected no results for unknown schema")
+	}
+}
+
+func TestCheckParity(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	dump := m.ToDict()
+	schemata := dump["schemata"].(map[string]any)
+	delete(schemata, "Vessel")
+	props := schemata["Person"].(map[string]any)["properties"].(map[string]any)
+	props["shoeSize"] = map[string]any{"label": "Shoe size", "type": "number"}
+	props["nationality"].(map[string]any)["type"] = "nationality"
+	dump["types"].(map[string]any)["nationality"] = map[string]any{"label": "Nationality"}
+	raw, _ := json.Marshal(dump)
+
+	r, err := CheckParity(bytes.NewReader(raw), m)
+	if err != nil {
+		t.Fatalf("CheckParity: %v", err)
+	}
+	if r.Complete() {
+		t.Fatalf("expected incomplete parity: %+v", r)
+	}
+	if len(r.ExtraSchemata) != 1 || r.ExtraSchemata[0] != "Vessel" {
+		t.Fatalf("unexpected extra schemata %v", r.ExtraSchemata)
+	}
+	if len(r.MissingProperties) != 1 || r.MissingProperties[0] != "Person:shoeSize" {
+		t.Fatalf("unexpected missing properties %v", r.MissingProperties)
+	}
+	if len(r.MissingTypes) != 1 || r.MissingTypes[0] != "nationality" {
+		t.Fatalf("unexpected missing types %v", r.MissingTypes)
+	}
+	typeChanged := false
+	for _, c := range r.Changes {
+		typeChanged = typeChanged || (c.Subject == "Person:nationality" && c.Field == "type" && c.Old == "nationality")
+	}
+	if !typeChanged {
+		t.Fatalf("expected nationality type change, got %v", r.Changes)
+	}
+
+	raw, _ = json.Marshal(m.ToDict())
+	if r, err := CheckParity(bytes.NewReader(raw), m); err != nil || !r.Complete() || len(r.Changes) != 0 {
+		t.Fatalf("expected parity with own dump: %+v, %v", r, err)
+	}
+}
+
+func TestRDFURIs(t *testing.T) {
+	fsys := fstest.MapFS{"thing.yaml": {Data: []byte(`Thing:
+  label: Thing
+  rdf: http://www.w3.org/2002/07/owl#Thing
+  properties:
+    name:
+      label: Name
+      type: name
+      rdf: http://xmlns.com/foaf/0.1/name
+    notes:
+      label: Notes
+      type: text
+`)}}
+	m, err := NewModelFS(fsys, ".")
+	if err != nil {
+		t.Fatalf("NewModelFS: %v", err)
+	}
+	thing := m.Get("Thing")
+	if thing.URI != "http://www.w3.org/2002/07/owl#Thing" || thing.Get("name").URI != "http://xmlns.com/foaf/0.1/name" {
+		t.Fatalf("unexpected URIs %s, %s", thing.URI, thing.Get("name").URI)
+	}
+	if got := thing.Get("notes").URI; got != RDFNamespace+"Thing:notes" {
+		t.Fatalf("unexpected default URI %s", got)
+	}
+	raw, _ := json.Marshal(m.ToDict())
+	loaded, err := NewModelFromJSON(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("NewModelFromJSON: %v", err)
+	}
+	if loaded.Get("Thing").URI != thing.URI || loaded.Get("Thing").Get("name").URI != thing.Get("name").URI {
+		t.Fatalf("URIs not kept in model dump")
+	}
+}
+
+func TestTranslations(t *testing.T) {
+	po := `# German translation
+msgid ""
+msgstr ""
+"Language: de\n"
+
+msgid "Person"
+msgstr "Person"
+
+msgid "People"
+msgstr "Personen"
+
+msgid "Birth date"
+msgstr "Geburts"
+"datum"
+
+#, fuzzy
+msgid "Name"
+msgstr "Nomen"
+
+msgid "Countries"
+msgstr "Länder"
+`
+	fsys := fstest.MapFS{"de/LC_MESSAGES/followthemoney.po": {Data: []byte(po)}}
+	tr, err := LoadTranslations(fsys)
+	if err != nil {
+		t.Fatalf("LoadTranslations: %v", err)
+	}
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	m.SetTranslations(tr)
+	person := m.Get("Person")
+	if got := person.PluralIn("de-AT"); got != "Personen" {
+		t.Fatalf("plural: %s", got)
+	}
+	if got := person.Get("birthDate").LabelIn("de"); got != "Geburtsdatum" {
+		t.Fatalf("property label: %s", got)
+	}
+	if got := person.Get("name").LabelIn("de"); got != "Name" {
+		t.Fatalf("fuzzy entries must be skipped: %s", got)
+	}
+	if got := m.TypePluralIn(m.Registry().Country, "de"); got != "Länder" {
+		t.Fatalf("type plural: %s", got)
+	}
+	if got := person.PluralIn("fr"); got != "People" {
+		t.Fatalf("expected English fallback, got %s", got)
+	}
+	dump := m.ToDictIn("de")["schemata"].(map[string]any)["Person"].(map[string]any)
+	if dump["plural"] != "Personen" {
+		t.Fatalf("dump plural: %v", dump["plural"])
+	}
+}
+
+func TestNewModelCached(t *testing.T) {
+	cache := t.TempDir() + "/model.cache"
+	plain, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		m, err := NewModelCached(os.DirFS("../schema"), ".", cache)
+		if err != nil {
+			t.Fatalf("NewModelCached (%d): %v", i, err)
+		}
+		if d := DiffModels(plain, m); !d.Empty() || m.Version() != plain.Version() {
+			t.Fatalf("cached model differs (%d): %+v", i, d)
+		}
+	}
+
+	fsys := fstest.MapFS{"thing.yaml": {Data: []byte("Thing:\n  label: First\n")}}
+	if _, err := NewModelCached(fsys, ".", cache); err != nil {
+		t.Fatalf("NewModelCached: %v", err)
+	}
+	fsys["thing.yaml"] = &fstest.MapFile{Data: []byte("Thing:\n  label: Second\n")}
+	m, err := NewModelCached(fsys, ".", cache)
+	if err != nil {
+		t.Fatalf("NewModelCached: %v", err)
+	}
+	if m.Get("Thing").Label != "Second" {
+		t.Fatalf("expected stale cache to be replaced, got %s", m.Get("Thing").Label)
+	}
+
+	// The cache is an optimization: failing to write it still yields the model.
+	m, err = NewModelCached(fsys, ".", t.TempDir()+"/missing/model.cache")
+	if err != nil || m.Get("Thing") == nil {
+		t.Fatalf("unwritable cache: %v", err)
+	}
+	// Schema problems are reported like an uncached load.
+	fsys["bad.yaml"] = &fstest.MapFile{Data: []byte("Person:\n  label: [\n")}
+	var sfe *SchemaFileError
+	if _, err := NewModelCached(fsys, ".", cache); !errors.As(err, &sfe) || sfe.Path != "bad.yaml" {
+		t.Fatalf("expected schema file error, got %v", err)
+	}
+}
+
+func TestSchemaFileErrors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.yaml": {Data: []byte("Thing:\n  label: Thing\n")},
+		"b.yaml": {Data: []byte("Person:\n  label: Person\n  extends: Thing\n  abstract: maybe\n")},
+		"c.yaml": {Data: []byte("Company:\n  label: [\n")},
+	}
+	_, err := NewModelFS(fsys, ".")
+	if err == nil {
+		t.Fatalf("expected errors")
+	}
+	var found []*SchemaFileError
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var sfe *SchemaFileError
+		if !errors.As(e, &sfe) {
+			t.Fatalf("unexpected error %v", e)
+		}
+		found = append(found, sfe)
+	}
+	if len(found) != 3 {
+		t.Fatalf("expected three errors, got %v", err)
+	}
+	if found[0].Path != "b.yaml" || found[0].Schema != "Person" || found[0].Line != 3 {
+		t.Fatalf("unexpected first error %+v", found[0])
+	}
+	if found[1].Line != 4 || !strings.Contains(found[1].Error(), "b.yaml:4: schema Person:") {
+		t.Fatalf("unexpected second error %v", found[1])
+	}
+	if found[2].Path != "c.yaml" || found[2].Line == 0 {
+		t.Fatalf("unexpected third error %+v", found[2])
+	}
+}
+
+func TestInboundProperties(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	company := m.Get("Company")
+	qnames := map[string]bool{}
+	for _, p := range m.InboundProperties(company) {
+		if p.Stub || !company.IsA(p.Range.Name) {
+			t.Fatalf("unexpected property %s", p.QName)
+		}
+		qnames[p.QName] = true
+	}
+	if !qnames["Ownership:asset"] || !qnames["Directorship:organization"] || !qnames["Ownership:owner"] {
+		t.Fatalf("missing inbound properties: %v", qnames)
+	}
+	stub := m.Get("LegalEntity").Get("ownershipOwner")
+	if fwd := stub.ReverseOf(); fwd == nil || fwd.QName != "Ownership:owner" {
+		t.Fatalf("unexpected forward property of %s: %v", stub.QName, fwd)
+	}
+	if m.Get("Ownership").Get("owner").ReverseOf() != nil {
+		t.Fatalf("forward properties have no ReverseOf")
+	}
+}
+
+func TestModelOutputDeterministic(t *testing.T) {
+	render := func() []byte {
+		m, err := NewModel("../schema")
+		if err != nil {
+			t.Fatalf("NewModel: %v", err)
+		}
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(m.ToDict()); err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+		if err := WriteTypeScript(&buf, m); err != nil {
+			t.Fatalf("typescript: %v", err)
+		}
+		sorted := m.SchemataSorted()
+		if len(sorted) != len(m.Schemata) || sorted[0].Name != "Address" {
+			t.Fatalf("unexpected sorted schemata")
+		}
+		return buf.Bytes()
+	}
+	first := render()
+	for i := 0; i < 5; i++ {
+		if !bytes.Equal(first, render()) {
+			t.Fatalf("output differs between loads")
+		}
+	}
+}
+
+func TestInheritedPropertiesShared(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	distinct := map[*Property]bool{}
+	for _, s := range m.Schemata {
+		for name, p := range s.Properties {
+			distinct[p] = true
+			if p.Schema != s && p.Schema.Properties[name] != p {
+				t.Fatalf("%s:%s is not shared with %s", s.Name, name, p.QName)
+			}
+		}
+	}
+	if len(distinct) != len(m.Properties) {
+		t.Fatalf("expected one value per qname: %d values, %d qnames", len(distinct), len(m.Properties))
+	}
+	if m.Get("Person").Get("name") != m.Get("Thing").Get("name") {
+		t.Fatalf("expected Person:name to be Thing:name")
+	}
+}
+
+func BenchmarkNewModel(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := NewModel("../schema"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package ftm
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ModelURLOptions configures NewModelURLWith.
+type ModelURLOptions struct {
+	// CacheDir stores downloaded bundles and their ETags. Defaults to
+	// <user cache dir>/ftm-models.
+	CacheDir string
+	// SHA256 is the expected hex digest of the bundle. If set, bundles with a
+	// different digest are rejected. Only valid bundles are cached.
+	SHA256 string
+	// Client is the HTTP client to use, http.DefaultClient if nil.
+	Client *http.Client
+}
+
+// NewModelURL loads a model bundle over HTTP(S). See NewModelURLWith.
+func NewModelURL(url string) (*Model, error) {
+	return NewModelURLWith(url, ModelURLOptions{})
+}
+
+// NewModelURLWith downloads a model bundle: a JSON model dump, or a zip or
+// (gzipped) tar archive of YAML schema files. Bundles are cached by URL and
+// revalidated with ETags, so unchanged models are not downloaded again; if the
+// server cannot be reached, the cached bundle is used.
+func NewModelURLWith(url string, opts ModelURLOptions) (*Model, error) {
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	if opts.CacheDir == "" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		opts.CacheDir = filepath.Join(dir, "ftm-models")
+	}
+	if err := os.MkdirAll(opts.CacheDir, 0o755); err != nil {
+		return nil, err
+	}
+	key := sha256.Sum256([]byte(url))
+	base := filepath.Join(opts.CacheDir, hex.EncodeToString(key[:8]))
+	bodyPath, etagPath := base+".bundle", base+".etag"
+
+	cached, cacheErr := os.ReadFile(bodyPath)
+	body, err := fetchBundle(opts.Client, url, etagPath, cacheErr == nil)
+	fresh := false
+	switch {
+	case err != nil && cacheErr == nil:
+		body = cached // offline: keep serving the last good bundle
+	case err != nil:
+		return nil, err
+	case body == nil:
+		body = cached // not modified
+	default:
+		fresh = true
+	}
+	if err := verifyDigest(body, opts.SHA256); err != nil {
+		return nil, err
+	}
+	m, err := NewModelBundle(body)
+	if err != nil {
+		return nil, err
+	}
+	if fresh {
+		if err := writeFileAtomic(bodyPath, body); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// fetchBundle performs a conditional GET. It returns nil without error when the
+// server reports the cached copy as current.
+func fetchBundle(client *http.Client, url, etagPath string, haveCache bool) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if etag, err := os.ReadFile(etagPath); err == nil && haveCache {
+		req.Header.Set("If-None-Match", string(etag))
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return nil, nil
+	case http.StatusOK:
+	default:
+		return nil, fmt.Errorf("fetching model %s: %s", url, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.ContentLength >= 0 && int64(len(body)) != resp.ContentLength {
+		return nil, fmt.Errorf("fetching model %s: truncated response", url)
+	}
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		_ = writeFileAtomic(etagPath, []byte(etag))
+	} else {
+		_ = os.Remove(etagPath)
+	}
+	return body, nil
+}
+
+func verifyDigest(body []byte, want string) error {
+	if want == "" {
+		return nil
+	}
+	sum := sha256.Sum256(body)
+	if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, want) {
+		return fmt.Errorf("model bundle digest mismatch: got %s, want %s", got, want)
+	}
+	return nil
+}
+
+func writeFileAtomic(name string, data []byte) error {
+	tmp := name + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
+
+// NewModelBundle loads a model from an in-memory bundle: a JSON model dump, or a
+// zip, tar or gzipped tar archive of YAML schema files.
+func NewModelBundle(data []byte) (*Model, error) {
+	var files map[string][]byte
+	var err error
+	switch {
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		files, err = zipYAMLFiles(data)
+	case bytes.HasPrefix(data, []byte{0x1f, 0x8b}):
+		var zr *gzip.Reader
+		if zr, err = gzip.NewReader(bytes.NewReader(data)); err == nil {
+			files, err = tarYAMLFiles(zr)
+		}
+	case len(bytes.TrimSpace(data)) > 0 && bytes.TrimSpace(data)[0] == '{':
+		return NewModelFromJSON(bytes.NewReader(data))
+	default:
+		files, err = tarYAMLFiles(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading model bundle: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, errors.New("model bundle contains no schema files")
+	}
+
+	m := newModel(nil, "")
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		defs, errs := parseSpecFile(name, files[name])
+		if len(errs) > 0 {
+			return nil, errors.Join(errs...)
+		}
+		if err := m.addSpecs(defs); err != nil {
+			return nil, &SchemaFileError{Path: name, Err: err}
+		}
+	}
+	if err := m.resolveExtends(); err != nil {
+		return nil, err
+	}
+	if err := m.Generate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func isYAMLFile(name string) bool {
+	ext := path.Ext(name)
+	return ext == ".yml" || ext == ".yaml"
+}
+
+func zipYAMLFiles(data []byte) (map[string][]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !isYAMLFile(f.Name) {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		files[f.Name] = b
+	}
+	return files, nil
+}
+
+func tarYAMLFiles(r io.Reader) (map[string][]byte, error) {
+	tr := tar.NewReader(r)
+	files := map[string][]byte{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg || !isYAMLFile(hdr.Name) {
+			continue
+		}
+		b, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[hdr.Name] = b
+	}
+}
@@ -0,0 +1,52 @@
+package ftm
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// ModelVersion identifies the schemata a model was built from, so producers and
+// readers of exported data can detect schema drift.
+type ModelVersion struct {
+	// Upstream is the followthemoney release the schemata derive from, read from
+	// a VERSION file next to the YAML files (or the "version" of a JSON dump).
+	Upstream string `json:"upstream,omitempty"`
+	// Hash is the content hash of the schemata. It changes with any local
+	// overlay or edit, even if Upstream stays the same.
+	Hash string `json:"hash"`
+}
+
+// String formats the version as "<upstream>+<short hash>", or just the short hash
+// if the upstream release is unknown.
+func (v ModelVersion) String() string {
+	short := v.Hash
+	if len(short) > 12 {
+		short = short[:12]
+	}
+	if v.Upstream == "" {
+		return short
+	}
+	return v.Upstream + "+" + short
+}
+
+// Version returns the model version. The hash is computed on first use.
+func (m *Model) Version() ModelVersion {
+	m.versionOnce.Do(func() {
+		schemata := map[string]any{}
+		for name, s := range m.Schemata {
+			schemata[name] = s.ToDict()
+		}
+		m.versionHash, _ = ContentHash(schemata)
+	})
+	return ModelVersion{Upstream: m.upstream, Hash: m.versionHash}
+}
+
+// readUpstreamVersion reads the VERSION file in the model root, if any.
+func readUpstreamVersion(fsys fs.FS, root string) string {
+	raw, err := fs.ReadFile(fsys, path.Join(root, "VERSION"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(raw))
+}
@@ -0,0 +1,154 @@
+package ftm
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"sort"
+)
+
+// ViewOptions selects what Model.View keeps. By default hidden, abstract and
+// deprecated schemata and hidden and deprecated properties are removed, while
+// generated schemata (documents and other entities produced by ingestion) are
+// kept unless ExcludeGenerated is set.
+type ViewOptions struct {
+	IncludeHidden     bool
+	IncludeAbstract   bool
+	IncludeDeprecated bool
+	ExcludeGenerated  bool
+}
+
+// View derives a separate model containing only the selected schemata and
+// properties, e.g. for serving to a UI. The source model is not modified.
+//
+// A retained schema extends its nearest retained ancestors, and properties of
+// removed ancestors move to their topmost retained descendants, so their qnames
+// change (Thing:name becomes LegalEntity:name). Properties whose range is
+// removed are dropped along with their reverse.
+func (m *Model) View(opts ViewOptions) (*Model, error) {
+	keepSchema := func(s *Schema) bool {
+		return (opts.IncludeHidden || !s.Hidden) &&
+			(opts.IncludeAbstract || !s.Abstract) &&
+			(opts.IncludeDeprecated || !s.Deprecated) &&
+			!(opts.ExcludeGenerated && s.Generated)
+	}
+	keepProp := func(p *Property) bool {
+		if p.Stub || (p.Hidden && !opts.IncludeHidden) || (p.Deprecated && !opts.IncludeDeprecated) {
+			return false
+		}
+		return p.Range == nil || keepSchema(p.Range)
+	}
+
+	schemata := map[string]any{}
+	for name, s := range m.Schemata {
+		if !keepSchema(s) {
+			continue
+		}
+		data := s.ToDict()
+		parents := viewParents(s, keepSchema)
+		extends := make([]string, 0, len(parents))
+		for _, parent := range parents {
+			extends = append(extends, parent.Name)
+		}
+		sort.Strings(extends)
+		data["extends"] = extends
+
+		own := map[string]*Property{}
+		for pn, p := range s.Properties {
+			if p.Schema == s {
+				own[pn] = p
+				continue
+			}
+			if keepSchema(p.Schema) {
+				continue
+			}
+			inherited := false
+			for _, parent := range parents {
+				if _, ok := parent.Properties[pn]; ok {
+					inherited = true
+					break
+				}
+			}
+			if !inherited {
+				own[pn] = p
+			}
+		}
+		props := map[string]any{}
+		for pn, p := range own {
+			if !keepProp(p) {
+				continue
+			}
+			pd := p.ToDict()
+			pd["rdf"] = p.URI // keep the URI when the qname changes
+			if p.Reverse != nil && p.Reverse.Hidden && !opts.IncludeHidden {
+				delete(pd, "reverse")
+			}
+			props[pn] = pd
+		}
+		// Reverse stubs are rebuilt from their forward property
+		for pn, p := range s.Properties {
+			if p.Stub && p.Schema == s && p.Reverse != nil && keepProp(p.Reverse) && (opts.IncludeHidden || !p.Hidden) {
+				props[pn] = p.ToDict()
+			}
+		}
+		data["properties"] = props
+
+		visible := func(names []string) []string {
+			out := []string{}
+			for _, n := range names {
+				if p := s.Properties[n]; p != nil && keepProp(p) {
+					out = append(out, n)
+				}
+			}
+			return out
+		}
+		for _, key := range []string{"featured", "required", "caption"} {
+			if names, ok := data[key].([]string); ok {
+				data[key] = visible(names)
+			}
+		}
+		delete(data, "temporalExtent")
+		start := visible(ownPropNames(s, s.TemporalStartProps()))
+		end := visible(ownPropNames(s, s.TemporalEndProps()))
+		for _, p := range s.TemporalStartProps() {
+			if own[p.Name] == p && p.Schema != s && !slices.Contains(start, p.Name) && keepProp(p) {
+				start = append(start, p.Name)
+			}
+		}
+		for _, p := range s.TemporalEndProps() {
+			if own[p.Name] == p && p.Schema != s && !slices.Contains(end, p.Name) && keepProp(p) {
+				end = append(end, p.Name)
+			}
+		}
+		if len(start) > 0 || len(end) > 0 {
+			data["temporalExtent"] = map[string]any{"start": start, "end": end}
+		}
+		schemata[name] = data
+	}
+
+	// Rebuild through the model dump, which re-links all schema pointers
+	raw, err := json.Marshal(map[string]any{"schemata": schemata})
+	if err != nil {
+		return nil, err
+	}
+	return NewModelFromJSONWith(bytes.NewReader(raw), ModelOptions{Registry: m.registry})
+}
+
+// viewParents returns the nearest ancestors of s accepted by keep.
+func viewParents(s *Schema, keep func(*Schema) bool) []*Schema {
+	var out []*Schema
+	var walk func(*Schema)
+	walk = func(cur *Schema) {
+		for _, parent := range cur.Extends {
+			if keep(parent) {
+				if !slices.Contains(out, parent) {
+					out = append(out, parent)
+				}
+				continue
+			}
+			walk(parent)
+		}
+	}
+	walk(s)
+	return out
+}
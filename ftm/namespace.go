@@ -78,7 +78,7 @@ func (ns *Namespace) Apply(e *EntityProxy, shallow bool) *EntityProxy {
 	}
 	for name, vals := range cp.props {
 		p := cp.Schema.Get(name)
-		if p == nil || p.Type.Name() != registry.Entity.Name() {
+		if p == nil || p.Type.Name() != cp.Schema.Model.registry.Entity.Name() {
 			continue
 		}
 		newVals := make([]string, 0, len(vals))
@@ -0,0 +1,66 @@
+package ftm
+
+// OpenAPIComponents returns the "components" section of an OpenAPI 3.1 document
+// describing the model: one schema per concrete FtM schema (as in
+// Schema.JSONSchema), an "Entity" schema accepting any of them, discriminated by
+// the "schema" field, and a "Statement" schema. Services can merge it into their
+// specification, referring to "#/components/schemas/Entity" and friends.
+func (m *Model) OpenAPIComponents() map[string]any {
+	schemas := map[string]any{}
+	var names []string
+	for _, s := range m.SchemataSorted() {
+		if s.Abstract {
+			continue
+		}
+		schemas[s.Name] = schemaObject(s)
+		names = append(names, s.Name)
+	}
+	refs := make([]any, 0, len(names))
+	mapping := map[string]string{}
+	for _, name := range names {
+		ref := "#/components/schemas/" + name
+		refs = append(refs, map[string]any{"$ref": ref})
+		mapping[name] = ref
+	}
+	schemas["Entity"] = map[string]any{
+		"oneOf": refs,
+		"discriminator": map[string]any{
+			"propertyName": "schema",
+			"mapping":      mapping,
+		},
+	}
+	schemas["Statement"] = statementSchema(names, sortedKeys(m.registry.types))
+	return map[string]any{"schemas": schemas}
+}
+
+// statementSchema describes the JSON form of a Statement.
+func statementSchema(schemaNames, typeNames []string) map[string]any {
+	str := func() map[string]any { return map[string]any{"type": "string"} }
+	date := func() map[string]any {
+		return map[string]any{"type": "string", "pattern": `^\d{4}-\d{2}-\d{2}`}
+	}
+	schema := str()
+	schema["enum"] = schemaNames
+	propType := str()
+	propType["enum"] = typeNames
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"id":             str(),
+			"entity_id":      str(),
+			"canonical_id":   str(),
+			"prop":           str(),
+			"prop_type":      propType,
+			"schema":         schema,
+			"value":          str(),
+			"dataset":        str(),
+			"lang":           str(),
+			"original_value": str(),
+			"external":       map[string]any{"type": "boolean"},
+			"first_seen":     date(),
+			"last_seen":      date(),
+			"origin":         str(),
+		},
+		"required": []string{"entity_id", "prop", "schema", "value", "dataset"},
+	}
+}
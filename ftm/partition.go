@@ -0,0 +1,198 @@
+package ftm
+
+import (
+	"bufio"
+	"container/list"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// Partition keys supported by PartitionKey.
+const (
+	PartitionBySchema  = "schema"
+	PartitionByCountry = "country"
+	PartitionByDataset = "dataset"
+)
+
+// PartitionUnknown is the key used when an entity has no value for the partition field.
+const PartitionUnknown = "unknown"
+
+var partitionUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// PartitionKey returns the partition an entity belongs to. Countries and datasets
+// use the first value in sorted order; datasets are read from the "datasets" or
+// "dataset" context fields.
+func PartitionKey(e *EntityProxy, by string) (string, error) {
+	var values []string
+	switch by {
+	case PartitionBySchema:
+		return e.Schema.Name, nil
+	case PartitionByCountry:
+		values = e.Countries()
+	case PartitionByDataset:
+		values = contextStrings(e.Context["datasets"])
+		if len(values) == 0 {
+			values = contextStrings(e.Context["dataset"])
+		}
+	default:
+		return "", fmt.Errorf("unknown partition field: %s", by)
+	}
+	if len(values) == 0 {
+		return PartitionUnknown, nil
+	}
+	sort.Strings(values)
+	return values[0], nil
+}
+
+// contextStrings reads a string or list of strings from a context value.
+func contextStrings(v any) []string {
+	switch x := v.(type) {
+	case string:
+		if x != "" {
+			return []string{x}
+		}
+	case []string:
+		return x
+	case []any:
+		out := make([]string, 0, len(x))
+		for _, item := range x {
+			if s, ok := item.(string); ok && s != "" {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// PartitionFile describes one partition in the manifest.
+type PartitionFile struct {
+	File    string `json:"file"`
+	Records int    `json:"records"`
+}
+
+// DefaultPartitionMaxOpen is the number of partition files a PartitionWriter
+// keeps open unless MaxOpen is set.
+const DefaultPartitionMaxOpen = 64
+
+type partitionOut struct {
+	file *os.File // nil while closed
+	buf  *bufio.Writer
+	elem *list.Element // position in PartitionWriter.open
+	info PartitionFile
+}
+
+// PartitionWriter writes JSON lines into one file per partition key and a
+// manifest.json mapping keys to files on Close. To stay within the limits on
+// open files with many partitions, the least recently written files are
+// closed and later reopened for appending.
+type PartitionWriter struct {
+	// MaxOpen is the number of files kept open, DefaultPartitionMaxOpen if 0.
+	MaxOpen int
+
+	dir   string
+	parts map[string]*partitionOut
+	names map[string]struct{}
+	open  *list.List // open partitions, most recently written first
+}
+
+// NewPartitionWriter creates the output directory if needed.
+func NewPartitionWriter(dir string) (*PartitionWriter, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &PartitionWriter{dir: dir, parts: map[string]*partitionOut{}, names: map[string]struct{}{}, open: list.New()}, nil
+}
+
+// Write appends v as a JSON line to the partition file for key.
+func (pw *PartitionWriter) Write(key string, v any) error {
+	out, ok := pw.parts[key]
+	if !ok {
+		base := partitionUnsafe.ReplaceAllString(key, "_")
+		name := base + ".jsonl"
+		for i := 2; ; i++ {
+			if _, taken := pw.names[name]; !taken {
+				break
+			}
+			name = fmt.Sprintf("%s-%d.jsonl", base, i)
+		}
+		pw.names[name] = struct{}{}
+		out = &partitionOut{info: PartitionFile{File: name}}
+		pw.parts[key] = out
+	}
+	if err := pw.reopen(out, !ok); err != nil {
+		return err
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, err := out.buf.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	out.info.Records++
+	return nil
+}
+
+// reopen makes out the most recently used open partition, creating its file
+// if create is set and closing the least recently used files over MaxOpen.
+func (pw *PartitionWriter) reopen(out *partitionOut, create bool) error {
+	if out.file != nil {
+		pw.open.MoveToFront(out.elem)
+		return nil
+	}
+	limit := pw.MaxOpen
+	if limit <= 0 {
+		limit = DefaultPartitionMaxOpen
+	}
+	for pw.open.Len() >= limit {
+		if err := pw.closePart(pw.open.Back().Value.(*partitionOut)); err != nil {
+			return err
+		}
+	}
+	flag := os.O_WRONLY | os.O_APPEND
+	if create {
+		flag = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	f, err := os.OpenFile(filepath.Join(pw.dir, out.info.File), flag, 0o644)
+	if err != nil {
+		return err
+	}
+	out.file, out.buf = f, bufio.NewWriter(f)
+	out.elem = pw.open.PushFront(out)
+	return nil
+}
+
+// closePart flushes and closes the file of an open partition.
+func (pw *PartitionWriter) closePart(out *partitionOut) error {
+	pw.open.Remove(out.elem)
+	err := errors.Join(out.buf.Flush(), out.file.Close())
+	out.file, out.buf, out.elem = nil, nil, nil
+	return err
+}
+
+// Close flushes all partition files and writes manifest.json, as canonical
+// JSON (see CanonicalJSON).
+func (pw *PartitionWriter) Close() error {
+	var errs []error
+	manifest := map[string]PartitionFile{}
+	for key, out := range pw.parts {
+		if out.file != nil {
+			errs = append(errs, pw.closePart(out))
+		}
+		manifest[key] = out.info
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	b, err := CanonicalJSON(manifest)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(pw.dir, "manifest.json"), b, 0o644)
+}
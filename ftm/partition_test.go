@@ -0,0 +1,57 @@
+package ftm
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPartitionWriter(t *testing.T) {
+	dir := t.TempDir()
+	pw, err := NewPartitionWriter(dir)
+	if err != nil {
+		t.Fatalf("NewPartitionWriter: %v", err)
+	}
+	pw.MaxOpen = 2
+	// Cycle through more partitions than files kept open, so that files are
+	// closed and reopened for appending.
+	keys := []string{"a", "b", "c/d", "a", "c/d", "b", "a"}
+	for i, key := range keys {
+		if err := pw.Write(key, map[string]int{"n": i}); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		if pw.open.Len() > 2 {
+			t.Fatalf("%d files open", pw.open.Len())
+		}
+	}
+	if err := pw.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, "manifest.json"))
+	if err != nil {
+		t.Fatalf("manifest: %v", err)
+	}
+	var manifest map[string]PartitionFile
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		t.Fatalf("manifest json: %v", err)
+	}
+	want := map[string]string{"a": `{"n":0} {"n":3} {"n":6}`, "b": `{"n":1} {"n":5}`, "c/d": `{"n":2} {"n":4}`}
+	for key, lines := range want {
+		info := manifest[key]
+		if info.Records != len(strings.Fields(lines)) {
+			t.Fatalf("%s: %+v", key, info)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, info.File))
+		if err != nil {
+			t.Fatalf("read %s: %v", key, err)
+		}
+		if got := strings.Join(strings.Fields(string(data)), " "); got != lines {
+			t.Fatalf("%s: %s", key, got)
+		}
+	}
+	if manifest["c/d"].File != "c_d.jsonl" {
+		t.Fatalf("unsafe key not replaced: %s", manifest["c/d"].File)
+	}
+}
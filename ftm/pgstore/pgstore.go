@@ -0,0 +1,219 @@
+// Package pgstore implements ftm.StatementStore on PostgreSQL, so large
+// multi-dataset deployments can aggregate statements server-side instead of
+// re-sorting flat files.
+//
+// Writes use COPY into a temporary table followed by an upsert, and reads
+// stream with keyset pagination ordered by canonical ID and statement ID.
+package pgstore
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pedrohavay/followthemoney/ftm"
+)
+
+// DB is the subset of *pgx.Conn and *pgxpool.Pool used by the store.
+type DB interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// Options configures a Store.
+type Options struct {
+	// Table is the name of the statement table, "statements" by default.
+	Table string
+	// PageSize is the number of rows fetched per query when streaming, 10000
+	// by default.
+	PageSize int
+	// KeyAlgorithm is the recipe for IDs of statements added without one. If
+	// nil, the recipe selected by ftm.SetStatementKeyAlgorithm is used.
+	KeyAlgorithm *ftm.StatementKeyAlgorithm
+}
+
+// Store is a StatementStore backed by a PostgreSQL table. The canonical_id
+// column holds Statement.GroupKey, so statements read back always carry a
+// canonical ID. Methods of ftm.StatementStore use the context given to New.
+type Store struct {
+	ctx  context.Context
+	db   DB
+	m    *ftm.Model
+	opts Options
+}
+
+// columns of the statement table, in COPY and SELECT order.
+var columns = []string{
+	"id", "entity_id", "canonical_id", "prop", "prop_type", "schema", "value",
+	"dataset", "lang", "original_value", "external", "first_seen", "last_seen", "origin",
+}
+
+// New creates a store on db, building entities with m. Call Migrate to create
+// the table.
+func New(ctx context.Context, db DB, m *ftm.Model, opts Options) *Store {
+	if opts.Table == "" {
+		opts.Table = "statements"
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = 10000
+	}
+	return &Store{ctx: ctx, db: db, m: m, opts: opts}
+}
+
+// makeKey sets the ID of st with the store's recipe.
+func (s *Store) makeKey(st *ftm.Statement) string {
+	if s.opts.KeyAlgorithm != nil {
+		return st.MakeKeyWith(*s.opts.KeyAlgorithm)
+	}
+	return st.MakeKey()
+}
+
+func (s *Store) table() string { return pgx.Identifier{s.opts.Table}.Sanitize() }
+
+// Migrate creates the statement table and its indexes if they do not exist.
+func (s *Store) Migrate() error {
+	t := s.table()
+	_, err := s.db.Exec(s.ctx, fmt.Sprintf(`
+CREATE TABLE IF NOT EXISTS %[1]s (
+	id text PRIMARY KEY,
+	entity_id text NOT NULL,
+	canonical_id text NOT NULL,
+	prop text NOT NULL,
+	prop_type text NOT NULL,
+	schema text NOT NULL,
+	value text NOT NULL,
+	dataset text NOT NULL,
+	lang text NOT NULL DEFAULT '',
+	original_value text NOT NULL DEFAULT '',
+	external boolean NOT NULL DEFAULT false,
+	first_seen text NOT NULL DEFAULT '',
+	last_seen text NOT NULL DEFAULT '',
+	origin text NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS %[2]s ON %[1]s (canonical_id, id);
+CREATE INDEX IF NOT EXISTS %[3]s ON %[1]s (dataset, canonical_id, id);`,
+		t, pgx.Identifier{s.opts.Table + "_canonical_idx"}.Sanitize(), pgx.Identifier{s.opts.Table + "_dataset_idx"}.Sanitize()))
+	return err
+}
+
+// Add implements ftm.StatementStore. Statements are copied into a temporary
+// table and upserted in one transaction; statements without a key are ignored.
+func (s *Store) Add(statements ...ftm.Statement) error {
+	rows := make([][]any, 0, len(statements))
+	for _, st := range statements {
+		if st.ID == "" && s.makeKey(&st) == "" {
+			continue
+		}
+		rows = append(rows, []any{
+			st.ID, st.EntityID, st.GroupKey(), st.Prop, st.PropType, st.Schema, st.Value,
+			st.Dataset, st.Lang, st.Original, st.External, st.FirstSeen, st.LastSeen, st.Origin,
+		})
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	tx, err := s.db.Begin(s.ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(s.ctx)
+	tmp := pgx.Identifier{s.opts.Table + "_load"}
+	if _, err := tx.Exec(s.ctx, fmt.Sprintf("CREATE TEMP TABLE %s (LIKE %s) ON COMMIT DROP", tmp.Sanitize(), s.table())); err != nil {
+		return err
+	}
+	if _, err := tx.CopyFrom(s.ctx, tmp, columns, pgx.CopyFromRows(rows)); err != nil {
+		return fmt.Errorf("copying statements: %w", err)
+	}
+	updates := make([]string, 0, len(columns)-1)
+	for _, col := range columns[1:] {
+		updates = append(updates, fmt.Sprintf("%[1]s = EXCLUDED.%[1]s", col))
+	}
+	// DISTINCT ON keeps one row per key, since an upsert cannot touch a row twice
+	upsert := fmt.Sprintf("INSERT INTO %s SELECT DISTINCT ON (id) * FROM %s ORDER BY id ON CONFLICT (id) DO UPDATE SET %s",
+		s.table(), tmp.Sanitize(), strings.Join(updates, ", "))
+	if _, err := tx.Exec(s.ctx, upsert); err != nil {
+		return fmt.Errorf("upserting statements: %w", err)
+	}
+	return tx.Commit(s.ctx)
+}
+
+// Statements implements ftm.StatementStore.
+func (s *Store) Statements() iter.Seq2[ftm.Statement, error] {
+	return s.scan("TRUE")
+}
+
+// Canonical implements ftm.StatementStore.
+func (s *Store) Canonical(id string) iter.Seq2[ftm.Statement, error] {
+	return s.scan("canonical_id = $1", id)
+}
+
+// Dataset implements ftm.StatementStore.
+func (s *Store) Dataset(name string) iter.Seq2[ftm.Statement, error] {
+	return s.scan("dataset = $1", name)
+}
+
+// scan streams the statements matching where, page by page, resuming after
+// the last (canonical_id, id) seen.
+func (s *Store) scan(where string, args ...any) iter.Seq2[ftm.Statement, error] {
+	return func(yield func(ftm.Statement, error) bool) {
+		n := len(args)
+		query := fmt.Sprintf("SELECT %s FROM %s WHERE %s AND (canonical_id, id) > ($%d, $%d) ORDER BY canonical_id, id LIMIT %d",
+			strings.Join(columns, ", "), s.table(), where, n+1, n+2, s.opts.PageSize)
+		lastCanonical, lastID := "", ""
+		for {
+			rows, err := s.db.Query(s.ctx, query, append(args, lastCanonical, lastID)...)
+			if err != nil {
+				yield(ftm.Statement{}, err)
+				return
+			}
+			page, err := pgx.CollectRows(rows, scanStatement)
+			if err != nil {
+				yield(ftm.Statement{}, err)
+				return
+			}
+			for _, st := range page {
+				if !yield(st, nil) {
+					return
+				}
+			}
+			if len(page) < s.opts.PageSize {
+				return
+			}
+			last := page[len(page)-1]
+			lastCanonical, lastID = last.CanonicalID, last.ID
+		}
+	}
+}
+
+func scanStatement(row pgx.CollectableRow) (ftm.Statement, error) {
+	var st ftm.Statement
+	err := row.Scan(&st.ID, &st.EntityID, &st.CanonicalID, &st.Prop, &st.PropType, &st.Schema, &st.Value,
+		&st.Dataset, &st.Lang, &st.Original, &st.External, &st.FirstSeen, &st.LastSeen, &st.Origin)
+	return st, err
+}
+
+// DeleteDataset implements ftm.StatementStore.
+func (s *Store) DeleteDataset(name string) error {
+	_, err := s.db.Exec(s.ctx, fmt.Sprintf("DELETE FROM %s WHERE dataset = $1", s.table()), name)
+	return err
+}
+
+// Entity implements ftm.StatementStore.
+func (s *Store) Entity(id string) (*ftm.EntityProxy, error) {
+	agg := ftm.NewStatementAggregator(s.m)
+	for st, err := range s.Canonical(id) {
+		if err != nil {
+			return nil, err
+		}
+		agg.Add(st)
+	}
+	e := agg.Flush()
+	if e == nil {
+		return nil, ftm.ErrEntityNotFound
+	}
+	return e, nil
+}
@@ -0,0 +1,78 @@
+package pgstore
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pedrohavay/followthemoney/ftm"
+)
+
+// TestStore runs against the database in FTM_TEST_POSTGRES, e.g.
+// postgres://localhost/ftm_test, and is skipped without it.
+func TestStore(t *testing.T) {
+	dsn := os.Getenv("FTM_TEST_POSTGRES")
+	if dsn == "" {
+		t.Skip("FTM_TEST_POSTGRES not set")
+	}
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close(ctx)
+	m, err := ftm.NewModel("../../schema")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := New(ctx, conn, m, Options{Table: "ftm_test_statements", PageSize: 2})
+	if err := s.Migrate(); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Exec(ctx, "DROP TABLE ftm_test_statements")
+
+	mk := func(entity, canonical, prop, value, dataset string) ftm.Statement {
+		return ftm.Statement{EntityID: entity, CanonicalID: canonical, Prop: prop, Schema: "Person", Value: value, Dataset: dataset}
+	}
+	sts := []ftm.Statement{
+		mk("a", "c1", "name", "Alice", "d1"),
+		mk("a", "c1", "name", "Alice", "d1"),
+		mk("b", "c1", "nationality", "de", "d2"),
+		mk("x", "", "name", "Xavier", "d1"),
+		mk("y", "", "name", "Yvonne", "d1"),
+	}
+	if err := s.Add(sts...); err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for st, err := range s.Statements() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, st.CanonicalID)
+	}
+	if want := []string{"c1", "c1", "x", "y"}; len(ids) != len(want) || ids[0] != want[0] || ids[2] != want[2] || ids[3] != want[3] {
+		t.Fatalf("statements: got %v, want %v", ids, want)
+	}
+	e, err := s.Entity("c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := e.Get("nationality"); len(got) != 1 || got[0] != "de" {
+		t.Fatalf("nationality: %v", got)
+	}
+	if err := s.DeleteDataset("d2"); err != nil {
+		t.Fatal(err)
+	}
+	n := 0
+	for _, err := range s.Canonical("c1") {
+		if err != nil {
+			t.Fatal(err)
+		}
+		n++
+	}
+	if n != 1 {
+		t.Fatalf("after delete: %d statements", n)
+	}
+}
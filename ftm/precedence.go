@@ -0,0 +1,87 @@
+package ftm
+
+// DefaultSingleValuedProps are the properties SourcePrecedence applies to when
+// Props is empty: facts an entity has only one of, where differing values are
+// contradictions rather than additional information.
+var DefaultSingleValuedProps = []string{"gender", "birthDate", "deathDate", "incorporationDate", "dissolutionDate"}
+
+// SourcePrecedence settles contradicting values of single-valued properties by
+// trust in their sources. Statements rank by the trust of their dataset, then
+// by the trust of their origin; names without a rank count as 0 and higher
+// ranks win. For each single-valued property of an entity only the values of
+// the best ranked statements are kept, so equally trusted sources may still
+// contribute several values. Other properties accumulate as usual.
+type SourcePrecedence struct {
+	Datasets map[string]int
+	Origins  map[string]int
+	// Props names the single-valued properties, DefaultSingleValuedProps if empty.
+	Props []string
+}
+
+// rank orders statements by dataset trust, then origin trust.
+func (p *SourcePrecedence) rank(s Statement) [2]int {
+	return [2]int{p.Datasets[s.Dataset], p.Origins[s.Origin]}
+}
+
+func (p *SourcePrecedence) singleValued() map[string]bool {
+	props := p.Props
+	if len(props) == 0 {
+		props = DefaultSingleValuedProps
+	}
+	out := make(map[string]bool, len(props))
+	for _, name := range props {
+		out[name] = true
+	}
+	return out
+}
+
+// Apply returns the statements of one entity without the values of
+// single-valued properties that are overruled by better ranked sources, and
+// the number of statements removed. The order of the kept statements is
+// unchanged.
+func (p *SourcePrecedence) Apply(group []Statement) ([]Statement, int) {
+	single := p.singleValued()
+	best := map[string][2]int{}
+	for _, s := range group {
+		if !single[s.Prop] {
+			continue
+		}
+		r := p.rank(s)
+		if cur, ok := best[s.Prop]; !ok || rankLess(cur, r) {
+			best[s.Prop] = r
+		}
+	}
+	if len(best) == 0 {
+		return group, 0
+	}
+	out := make([]Statement, 0, len(group))
+	for _, s := range group {
+		if single[s.Prop] && rankLess(p.rank(s), best[s.Prop]) {
+			continue
+		}
+		out = append(out, s)
+	}
+	return out, len(group) - len(out)
+}
+
+// applySorted applies Apply to each group of statements sorted by group key.
+func (p *SourcePrecedence) applySorted(st []Statement) []Statement {
+	out := make([]Statement, 0, len(st))
+	for start := 0; start < len(st); {
+		end := start + 1
+		for end < len(st) && st[end].GroupKey() == st[start].GroupKey() {
+			end++
+		}
+		kept, _ := p.Apply(st[start:end])
+		out = append(out, kept...)
+		start = end
+	}
+	return out
+}
+
+func rankLess(a, b [2]int) bool {
+	if a[0] != b[0] {
+		return a[0] < b[0]
+	}
+	return a[1] < b[1]
+}
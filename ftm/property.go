@@ -1,11 +1,14 @@
 package ftm
 
+import "strings"
+
 // Property models a schema field, including type and constraints.
 // Reverse properties are stubs created implicitly for inbound edges.
 type Property struct {
 	Schema *Schema
 	Name   string
 	QName  string
+	URI    string // RDF predicate URI, see RDFNamespace
 
 	Label       string
 	Description string
@@ -17,10 +20,13 @@ type Property struct {
 	Type   PropertyType
 	Range  *Schema
 	Format string
+	Values []string // controlled vocabulary; empty means unrestricted
 
 	// Reverse stub information
 	Stub    bool
 	Reverse *Property
+
+	typeName string // type name as declared in the spec
 }
 
 // reverseSpec is used only during YAML unmarshalling.
@@ -42,6 +48,9 @@ type propertySpec struct {
 	Range       string       `yaml:"range" json:"range"`
 	Format      string       `yaml:"format" json:"format"`
 	Reverse     *reverseSpec `yaml:"reverse" json:"reverse"`
+	Values      []string     `yaml:"values" json:"values"`
+	Enum        []string     `yaml:"enum" json:"enum"` // alias of values
+	RDF         string       `yaml:"rdf" json:"rdf"`
 }
 
 // newProperty creates a new property from its spec, without resolving cross-links.
@@ -50,12 +59,14 @@ func newProperty(schema *Schema, name string, spec propertySpec) (*Property, err
 		Schema:      schema,
 		Name:        name,
 		QName:       schema.Name + ":" + name,
+		URI:         firstNonEmpty(spec.RDF, RDFNamespace+schema.Name+":"+name),
 		Label:       spec.Label,
 		Description: spec.Description,
 		Hidden:      spec.Hidden != nil && *spec.Hidden,
 		Deprecated:  spec.Deprecated != nil && *spec.Deprecated,
 		MaxLength:   0,
 		Format:      spec.Format,
+		Values:      append(append([]string{}, spec.Values...), spec.Enum...),
 	}
 
 	if spec.MaxLength != nil {
@@ -68,10 +79,12 @@ func newProperty(schema *Schema, name string, spec propertySpec) (*Property, err
 	}
 
 	// Lookup type in registry
-	p.Type = registry.Get(tName)
+	p.typeName = tName
+	types := schema.Model.registry
+	p.Type = types.Get(tName)
 	if p.Type == nil {
 		// Fallback to string type for unsupported types in this minimal port.
-		p.Type = registry.String
+		p.Type = types.String
 	}
 
 	// If range is specified, it must be a known schema.
@@ -83,3 +96,65 @@ func newProperty(schema *Schema, name string, spec propertySpec) (*Property, err
 
 	return p, nil
 }
+
+// ToDict serializes the property in the structure of the upstream model dump,
+// including its constraints: format (e.g. "iban"), effective maxLength, range,
+// reverse name and allowed values.
+func (p *Property) ToDict() map[string]any {
+	label := p.Label
+	if label == "" {
+		label = p.Name
+	}
+	data := map[string]any{
+		"name":      p.Name,
+		"qname":     p.QName,
+		"label":     label,
+		"type":      p.Type.Name(),
+		"maxLength": p.maxLength(),
+	}
+	if p.Description != "" {
+		data["description"] = p.Description
+	}
+	if p.Stub {
+		data["stub"] = true
+	}
+	if p.Matchable {
+		data["matchable"] = true
+	}
+	if p.Hidden {
+		data["hidden"] = true
+	}
+	if p.Deprecated {
+		data["deprecated"] = true
+	}
+	if p.Range != nil {
+		data["range"] = p.Range.Name
+	}
+	if p.Reverse != nil {
+		data["reverse"] = p.Reverse.Name
+	}
+	if p.Format != "" {
+		data["format"] = p.Format
+	}
+	if len(p.Values) > 0 {
+		data["values"] = p.Values
+	}
+	if p.URI != RDFNamespace+p.QName {
+		data["rdf"] = p.URI
+	}
+	return data
+}
+
+// allowedValue checks a cleaned value against the property's controlled vocabulary.
+// Matching ignores case and returns the declared spelling.
+func (p *Property) allowedValue(v string) (string, bool) {
+	if len(p.Values) == 0 {
+		return v, true
+	}
+	for _, allowed := range p.Values {
+		if strings.EqualFold(allowed, v) {
+			return allowed, true
+		}
+	}
+	return "", false
+}
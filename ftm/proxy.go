@@ -1,9 +1,11 @@
 package ftm
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sort"
+	"unicode/utf8"
 )
 
 // ErrPropertyNotFound is returned when a property is not found in the schema.
@@ -18,17 +20,49 @@ type EntityProxy struct {
 	KeyPrefix string
 	Context   map[string]any // passthrough contextual fields
 
+	// LengthPolicy controls values longer than the property's maximum length.
+	// The zero value, LengthIgnore, keeps them intact.
+	LengthPolicy LengthPolicy
+	// Strict makes Add return an error for values that were dropped because of
+	// length or size limits, instead of discarding them silently.
+	Strict bool
+
 	props map[string][]string
-	size  int // accumulated size of string values
+	meta  map[string]map[string]valueMeta // per-value metadata (prop -> value -> meta)
+	size  int                             // accumulated size of string values
+
+	dropped int // values rejected because of length or TotalSize limits
+}
+
+// LengthPolicy decides what happens to values exceeding a property's maximum length.
+type LengthPolicy int
+
+const (
+	// LengthIgnore does not enforce maximum lengths.
+	LengthIgnore LengthPolicy = iota
+	// LengthTruncate cuts over-long values at the last rune boundary within the limit.
+	LengthTruncate
+	// LengthReject drops over-long values.
+	LengthReject
+)
+
+// valueMeta holds metadata recorded alongside a single property value.
+type valueMeta struct {
+	Lang     string
+	Original string // value before cleaning, if it differed
 }
 
 // NewEntityProxy creates a new entity proxy with the given schema and ID.
 func NewEntityProxy(schema *Schema, id string) *EntityProxy {
+	if schema != nil && schema.Deprecated {
+		schema.Model.warnDeprecated(schema.Name)
+	}
 	return &EntityProxy{
 		Schema:  schema,
 		ID:      id,
 		Context: map[string]any{},
 		props:   map[string][]string{},
+		meta:    map[string]map[string]valueMeta{},
 	}
 }
 
@@ -41,6 +75,24 @@ func (e *EntityProxy) MakeID(parts ...string) (string, bool) {
 	return e.ID, ok
 }
 
+// MakeIDWithSchema creates a hashed ID from the key prefix, the schema name and the
+// provided parts, so the same natural key yields distinct IDs for different schemata.
+func (e *EntityProxy) MakeIDWithSchema(parts ...string) (string, bool) {
+	return e.MakeIDWith(IDRecipe{IncludeSchema: true}, parts...)
+}
+
+// MakeIDWith creates an ID from the provided parts using a recipe.
+func (e *EntityProxy) MakeIDWith(r IDRecipe, parts ...string) (string, bool) {
+	if r.KeyPrefix == "" {
+		r.KeyPrefix = e.KeyPrefix
+	}
+	id, ok := r.Make(e.Schema, parts...)
+	if ok {
+		e.ID = id
+	}
+	return e.ID, ok
+}
+
 // getProp retrieves a property by name.
 func (e *EntityProxy) getProp(name string) (*Property, error) {
 	if p := e.Schema.Get(name); p != nil {
@@ -79,6 +131,18 @@ func (e *EntityProxy) Has(name string) bool {
 
 // Add adds (and normalizes) values for a property.
 func (e *EntityProxy) Add(name string, values []string, fuzzy bool) error {
+	return e.AddWithLang(name, values, fuzzy, "")
+}
+
+// AddWithLang adds (and normalizes) values for a property, passing a language
+// hint to type cleaners and recording it for each added value.
+func (e *EntityProxy) AddWithLang(name string, values []string, fuzzy bool, lang string) error {
+	return e.addWithMeta(name, values, fuzzy, lang, "")
+}
+
+// addWithMeta is AddWithLang recording original as the value before cleaning,
+// or the raw value if original is empty and cleaning changed it.
+func (e *EntityProxy) addWithMeta(name string, values []string, fuzzy bool, lang, original string) error {
 	// Lookup property in schema
 	p, err := e.getProp(name)
 	if err != nil || p == nil {
@@ -89,6 +153,9 @@ func (e *EntityProxy) Add(name string, values []string, fuzzy bool) error {
 	if p.Stub {
 		return errors.New("stub property cannot be written")
 	}
+	if p.Deprecated {
+		p.Schema.Model.warnDeprecated(p.QName)
+	}
 
 	// Iterate and clean
 	if e.props[name] == nil {
@@ -102,35 +169,127 @@ func (e *EntityProxy) Add(name string, values []string, fuzzy bool) error {
 	}
 
 	// Use property format if not overridden
+	var errs []error
 	for _, raw := range values {
 		// Clean/normalize value
-		clean, ok := p.Type.Clean(raw, fuzzy, p.Format, e)
+		clean, ok := cleanValue(p, raw, fuzzy, lang, e)
 		if !ok || clean == "" {
 			continue
 		}
 
+		// Per-value length limit
+		clean, err := e.fitLength(p, clean)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		// Avoid duplicates; they do not grow the entity, so skip them before
+		// the size cap.
+		if _, seen := set[clean]; seen {
+			continue
+		}
+
 		// Aggregate size cap
 		if maxValue := p.Type.TotalSize(); maxValue > 0 {
 			if e.size+len(clean) > maxValue {
+				e.dropped++
+				errs = append(errs, PropertyError{Property: p.Name, Value: clean, Reason: fmt.Sprintf("exceeds total size %d", maxValue)})
 				continue
 			}
 		}
 
-		// Avoid duplicates
-		if _, seen := set[clean]; !seen {
-			e.props[name] = append(e.props[name], clean)
-			set[clean] = struct{}{}
-			e.size += len(clean)
+		e.props[name] = append(e.props[name], clean)
+		set[clean] = struct{}{}
+		e.size += len(clean)
+		if original == "" && raw != clean {
+			e.setMeta(name, clean, lang, raw)
+		} else {
+			e.setMeta(name, clean, lang, original)
 		}
 	}
 
+	if e.Strict {
+		return errors.Join(errs...)
+	}
 	return nil
 }
 
+// fitLength applies the proxy's LengthPolicy to a value longer than the property's
+// maximum length (counted in runes). Rejected values are counted as dropped.
+func (e *EntityProxy) fitLength(p *Property, value string) (string, error) {
+	if e.LengthPolicy == LengthIgnore {
+		return value, nil
+	}
+	limit := p.maxLength()
+	if limit <= 0 || utf8.RuneCountInString(value) <= limit {
+		return value, nil
+	}
+	if e.LengthPolicy == LengthTruncate {
+		n := 0
+		for i := range value {
+			if n == limit {
+				return value[:i], nil
+			}
+			n++
+		}
+		return value, nil
+	}
+	e.dropped++
+	return "", PropertyError{Property: p.Name, Value: value, Reason: fmt.Sprintf("exceeds max length %d", limit)}
+}
+
+// cleanValue runs the property type cleaner, using the installed CleanCache if any,
+// and restricts the result to the property's allowed values.
+func cleanValue(p *Property, raw string, fuzzy bool, lang string, proxy *EntityProxy) (string, bool) {
+	var clean string
+	var ok bool
+	if c := cleanCache.Load(); c != nil {
+		clean, ok = c.Clean(p, raw, fuzzy, lang, proxy)
+	} else {
+		clean, ok = cleanValueUncached(p, raw, fuzzy, lang, proxy)
+	}
+	if !ok {
+		return "", false
+	}
+	return p.allowedValue(clean)
+}
+
+// cleanValueUncached runs the property type cleaner, preferring the language-aware
+// variant when the type implements LangCleaner and a language is given.
+func cleanValueUncached(p *Property, raw string, fuzzy bool, lang string, proxy *EntityProxy) (string, bool) {
+	if lc, ok := p.Type.(LangCleaner); ok && lang != "" {
+		return lc.CleanLang(raw, fuzzy, p.Format, lang, proxy)
+	}
+	return p.Type.Clean(raw, fuzzy, p.Format, proxy)
+}
+
+// setMeta records the language and original form of a stored value, if any.
+func (e *EntityProxy) setMeta(name, value, lang, original string) {
+	if lang == "" && original == "" {
+		return
+	}
+	if e.meta[name] == nil {
+		e.meta[name] = map[string]valueMeta{}
+	}
+	e.meta[name][value] = valueMeta{Lang: lang, Original: original}
+}
+
+// ValueLang returns the language recorded for a property value, if any.
+func (e *EntityProxy) ValueLang(name, value string) string {
+	return e.meta[name][value].Lang
+}
+
+// ValueOriginal returns the form a property value had before cleaning, if it
+// differed from the stored value.
+func (e *EntityProxy) ValueOriginal(name, value string) string {
+	return e.meta[name][value].Original
+}
+
 // UnsafeAdd is a helper for adding a single already-sanitized value.
 func (e *EntityProxy) UnsafeAdd(p *Property, value string, fuzzy bool) (string, bool) {
-	// Clean/normalize value
-	clean, ok := p.Type.Clean(value, fuzzy, p.Format, e)
+	// Clean/normalize value, applying the property's constraints
+	clean, ok := cleanValue(p, value, fuzzy, "", e)
 	if !ok || clean == "" {
 		return "", false
 	}
@@ -140,6 +299,12 @@ func (e *EntityProxy) UnsafeAdd(p *Property, value string, fuzzy bool) (string,
 		return "", false
 	}
 
+	// Per-value length limit
+	clean, err := e.fitLength(p, clean)
+	if err != nil {
+		return "", false
+	}
+
 	// Initialize if needed
 	if e.props[p.Name] == nil {
 		e.props[p.Name] = []string{}
@@ -154,6 +319,7 @@ func (e *EntityProxy) UnsafeAdd(p *Property, value string, fuzzy bool) (string,
 
 	// Aggregate size cap
 	if maxVal := p.Type.TotalSize(); maxVal > 0 && e.size+len(clean) > maxVal {
+		e.dropped++
 		return "", false
 	}
 
@@ -165,8 +331,13 @@ func (e *EntityProxy) UnsafeAdd(p *Property, value string, fuzzy bool) (string,
 
 // Set replaces all existing values with the provided ones.
 func (e *EntityProxy) Set(name string, values []string, fuzzy bool) error {
-	delete(e.props, name)
-	return e.Add(name, values, fuzzy)
+	return e.SetWithLang(name, values, fuzzy, "")
+}
+
+// SetWithLang replaces all existing values with the provided ones, tagged with a language.
+func (e *EntityProxy) SetWithLang(name string, values []string, fuzzy bool, lang string) error {
+	e.Pop(name)
+	return e.AddWithLang(name, values, fuzzy, lang)
 }
 
 // Pop removes all values for a property and returns them.
@@ -181,6 +352,7 @@ func (e *EntityProxy) Pop(name string) []string {
 		e.size -= len(v)
 	}
 	delete(e.props, name)
+	delete(e.meta, name)
 
 	return xs
 }
@@ -199,16 +371,42 @@ func (e *EntityProxy) Remove(name, value string) {
 		} else {
 			// Adjust size by subtracting removed value
 			e.size -= len(v)
+			delete(e.meta[name], v)
 		}
 	}
 
 	if len(out) == 0 {
 		delete(e.props, name)
+		delete(e.meta, name)
 	} else {
 		e.props[name] = out
 	}
 }
 
+// RemoveIf removes all values of a property for which pred returns true, and returns them.
+func (e *EntityProxy) RemoveIf(name string, pred func(value string) bool) []string {
+	var removed []string
+	for _, v := range e.Get(name) {
+		if pred(v) {
+			e.Remove(name, v)
+			removed = append(removed, v)
+		}
+	}
+	return removed
+}
+
+// PopAllType removes all values of properties with the given type and returns
+// them keyed by property name.
+func (e *EntityProxy) PopAllType(pt PropertyType) map[string][]string {
+	out := map[string][]string{}
+	for _, p := range e.IterProps() {
+		if p.Type.Name() == pt.Name() {
+			out[p.Name] = e.Pop(p.Name)
+		}
+	}
+	return out
+}
+
 // IterProps returns properties for which a value is set.
 func (e *EntityProxy) IterProps() []*Property {
 	props := make([]*Property, 0, len(e.props))
@@ -236,6 +434,42 @@ func (e *EntityProxy) IterValues() [][2]interface{} {
 	return pairs
 }
 
+// PropertyCount returns the number of properties that have at least one value.
+func (e *EntityProxy) PropertyCount() int {
+	return len(e.props)
+}
+
+// ValueCount returns the total number of values across all properties.
+func (e *EntityProxy) ValueCount() int {
+	n := 0
+	for _, vals := range e.props {
+		n += len(vals)
+	}
+	return n
+}
+
+// Size returns the accumulated byte size of all values, as used for TotalSize caps.
+func (e *EntityProxy) Size() int {
+	return e.size
+}
+
+// PropertySizes returns the accumulated byte size of values per property name.
+func (e *EntityProxy) PropertySizes() map[string]int {
+	out := make(map[string]int, len(e.props))
+	for name, vals := range e.props {
+		for _, v := range vals {
+			out[name] += len(v)
+		}
+	}
+	return out
+}
+
+// DroppedValues returns how many values were discarded because they exceeded the
+// property's maximum length (with LengthReject) or the type's TotalSize cap.
+func (e *EntityProxy) DroppedValues() int {
+	return e.dropped
+}
+
 // EdgePairs returns value pairs for edge source/target if schema represents an edge.
 func (e *EntityProxy) EdgePairs() [][2]string {
 	if !e.Schema.Edge {
@@ -255,6 +489,36 @@ func (e *EntityProxy) EdgePairs() [][2]string {
 	return out
 }
 
+// InvertEdge returns a copy of an undirected edge entity (e.g. Family, Associate)
+// with the source and target values swapped. The copy's ID is derived from the
+// original ID so inversion is deterministic.
+func (e *EntityProxy) InvertEdge() (*EntityProxy, error) {
+	if !e.Schema.Edge {
+		return nil, fmt.Errorf("schema %s is not an edge", e.Schema.Name)
+	}
+	if e.Schema.EdgeDirected {
+		return nil, fmt.Errorf("edge schema %s is directed", e.Schema.Name)
+	}
+	inv := e.Clone()
+	id, ok := makeEntityID(e.KeyPrefix, e.ID, "inverted")
+	if !ok {
+		return nil, fmt.Errorf("cannot derive inverted ID for %q", e.ID)
+	}
+	inv.ID = id
+	src, dst := e.Schema.EdgeSource, e.Schema.EdgeTarget
+	inv.props[src], inv.props[dst] = inv.props[dst], inv.props[src]
+	inv.meta[src], inv.meta[dst] = inv.meta[dst], inv.meta[src]
+	for _, name := range []string{src, dst} {
+		if len(inv.props[name]) == 0 {
+			delete(inv.props, name)
+		}
+		if len(inv.meta[name]) == 0 {
+			delete(inv.meta, name)
+		}
+	}
+	return inv, nil
+}
+
 // GetTypeValues returns all values with a given property type name.
 func (e *EntityProxy) GetTypeValues(pt PropertyType, matchable bool) []string {
 	seen := map[string]struct{}{}
@@ -291,7 +555,7 @@ func (e *EntityProxy) Caption() string {
 			continue
 		}
 		values := e.Get(pName)
-		if p.Type.Name() == registry.Name.Name() && len(values) > 1 {
+		if p.Type.Name() == e.Schema.Model.registry.Name.Name() && len(values) > 1 {
 			return shortest(values...)
 		}
 		if len(values) > 0 {
@@ -301,17 +565,42 @@ func (e *EntityProxy) Caption() string {
 	return e.Schema.Label
 }
 
+// ValueCaptions returns display-ready captions for all values of a property, using
+// the property type's Caption with the property's format.
+func (e *EntityProxy) ValueCaptions(name string) []string {
+	p, err := e.getProp(name)
+	if err != nil {
+		return nil
+	}
+	vals := e.props[name]
+	out := make([]string, 0, len(vals))
+	for _, v := range vals {
+		out = append(out, p.Type.Caption(v, p.Format))
+	}
+	return out
+}
+
+// CaptionFor returns the display caption of the first value of a property.
+func (e *EntityProxy) CaptionFor(name string) string {
+	if xs := e.ValueCaptions(name); len(xs) > 0 {
+		return xs[0]
+	}
+	return ""
+}
+
 // Countries returns country-type values set on the entity.
 func (e *EntityProxy) Countries() []string {
-	return e.GetTypeValues(registry.Country, false)
+	return e.GetTypeValues(e.Schema.Model.registry.Country, false)
 }
 
-// ToDict serializes the entity to a plain map.
+// ToDict serializes the entity to a plain map. Property values are sorted so
+// that serializations of the same entity are byte-identical.
 func (e *EntityProxy) ToDict() map[string]any {
 	props := map[string][]string{}
 	for k, v := range e.props {
 		vv := make([]string, len(v))
 		copy(vv, v)
+		sort.Strings(vv)
 		props[k] = vv
 	}
 
@@ -328,10 +617,18 @@ func (e *EntityProxy) ToDict() map[string]any {
 	return data
 }
 
+// MarshalJSON encodes the entity as its ToDict form; encoding/json sorts map keys,
+// so the output is stable.
+func (e *EntityProxy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.ToDict())
+}
+
 // Clone deep-copies the entity proxy.
 func (e *EntityProxy) Clone() *EntityProxy {
 	cp := NewEntityProxy(e.Schema, e.ID)
 	cp.KeyPrefix = e.KeyPrefix
+	cp.LengthPolicy = e.LengthPolicy
+	cp.Strict = e.Strict
 	cp.Context = map[string]any{}
 
 	for k, v := range e.Context {
@@ -344,7 +641,16 @@ func (e *EntityProxy) Clone() *EntityProxy {
 		cp.props[k] = vv
 	}
 
+	for k, vals := range e.meta {
+		mm := make(map[string]valueMeta, len(vals))
+		for v, m := range vals {
+			mm[v] = m
+		}
+		cp.meta[k] = mm
+	}
+
 	cp.size = e.size
+	cp.dropped = e.dropped
 
 	return cp
 }
@@ -367,7 +673,9 @@ func (e *EntityProxy) Merge(other *EntityProxy) (*EntityProxy, error) {
 	}
 
 	for name, values := range other.props {
-		_ = e.Add(name, values, true)
+		for _, v := range values {
+			_ = e.addWithMeta(name, []string{v}, true, other.ValueLang(name, v), other.ValueOriginal(name, v))
+		}
 	}
 
 	return e, nil
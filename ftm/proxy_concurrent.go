@@ -0,0 +1,114 @@
+package ftm
+
+import "sync"
+
+// ConcurrentEntityProxy guards an EntityProxy with a read/write mutex so it can be
+// shared across goroutines. Access the wrapped proxy only through its methods.
+type ConcurrentEntityProxy struct {
+	mu sync.RWMutex
+	e  *EntityProxy
+}
+
+// NewConcurrentEntityProxy wraps an existing proxy. The caller must not use e directly afterwards.
+func NewConcurrentEntityProxy(e *EntityProxy) *ConcurrentEntityProxy {
+	return &ConcurrentEntityProxy{e: e}
+}
+
+// ID returns the entity ID.
+func (c *ConcurrentEntityProxy) ID() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.e.ID
+}
+
+// Schema returns the current entity schema (it may change through Merge).
+func (c *ConcurrentEntityProxy) Schema() *Schema {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.e.Schema
+}
+
+// Get returns all values for a property by name.
+func (c *ConcurrentEntityProxy) Get(name string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.e.Get(name)
+}
+
+// First returns the first value for a property.
+func (c *ConcurrentEntityProxy) First(name string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.e.First(name)
+}
+
+// Has tests if a property has at least one value.
+func (c *ConcurrentEntityProxy) Has(name string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.e.Has(name)
+}
+
+// Add adds (and normalizes) values for a property.
+func (c *ConcurrentEntityProxy) Add(name string, values []string, fuzzy bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.e.Add(name, values, fuzzy)
+}
+
+// AddWithLang adds values for a property with a language hint.
+func (c *ConcurrentEntityProxy) AddWithLang(name string, values []string, fuzzy bool, lang string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.e.AddWithLang(name, values, fuzzy, lang)
+}
+
+// Set replaces all existing values with the provided ones.
+func (c *ConcurrentEntityProxy) Set(name string, values []string, fuzzy bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.e.Set(name, values, fuzzy)
+}
+
+// Pop removes all values for a property and returns them.
+func (c *ConcurrentEntityProxy) Pop(name string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.e.Pop(name)
+}
+
+// Remove removes a single value from the property.
+func (c *ConcurrentEntityProxy) Remove(name, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.e.Remove(name, value)
+}
+
+// Merge merges another entity into the wrapped one. other must not be mutated concurrently.
+func (c *ConcurrentEntityProxy) Merge(other *EntityProxy) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := c.e.Merge(other)
+	return err
+}
+
+// Caption returns the entity caption.
+func (c *ConcurrentEntityProxy) Caption() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.e.Caption()
+}
+
+// ToDict serializes the entity to a plain map.
+func (c *ConcurrentEntityProxy) ToDict() map[string]any {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.e.ToDict()
+}
+
+// Snapshot returns a deep copy of the wrapped proxy that is safe to use without locking.
+func (c *ConcurrentEntityProxy) Snapshot() *EntityProxy {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.e.Clone()
+}
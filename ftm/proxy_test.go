@@ -2,7 +2,12 @@ package ftm
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
+	"unicode/utf8"
 )
 
 func TestProxyAddAndEdgePairs(t *testing.T) {
@@ -91,3 +96,404 @@ func TestEntityProxyFromDict(t *testing.T) {
 		t.Fatalf("last_seen mismatch: %v", eDict["last_seen"])
 	}
 }
+
+func TestAddWithLangDateAndStatements(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	p := NewEntityProxy(m.Get("Person"), "p1")
+	if err := p.AddWithLang("birthDate", []string{"3. März 2021"}, false, "deu"); err != nil {
+		t.Fatalf("add birthDate: %v", err)
+	}
+	if got := p.First("birthDate"); got != "2021-03-03" {
+		t.Fatalf("localized date not parsed: %q", got)
+	}
+	_ = p.AddWithLang("name", []string{"Иван"}, false, "rus")
+	for _, s := range StatementsFromEntity(p, "ds", "2025-01-01", "", false, "") {
+		if s.Prop == "name" && s.Lang != "rus" {
+			t.Fatalf("expected lang on name statement, got %q", s.Lang)
+		}
+	}
+}
+
+func TestConcurrentEntityProxy(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	c := NewConcurrentEntityProxy(NewEntityProxy(m.Get("Person"), "p1"))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_ = c.Add("name", []string{fmt.Sprintf("Name %d", i)}, false)
+			_ = c.Get("name")
+			other := NewEntityProxy(m.Get("Person"), "p1")
+			_ = other.Add("alias", []string{fmt.Sprintf("Alias %d", i)}, false)
+			_ = c.Merge(other)
+		}(i)
+	}
+	wg.Wait()
+	if n := len(c.Get("name")); n != 8 {
+		t.Fatalf("expected 8 names, got %d", n)
+	}
+	if n := len(c.Snapshot().Get("alias")); n != 8 {
+		t.Fatalf("expected 8 aliases, got %d", n)
+	}
+}
+
+func TestMarshalJSONStableOrder(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	a := NewEntityProxy(m.Get("Person"), "p1")
+	_ = a.Add("name", []string{"Zed", "Anna"}, false)
+	_ = a.Add("nationality", []string{"de", "br"}, false)
+	b := NewEntityProxy(m.Get("Person"), "p1")
+	_ = b.Add("nationality", []string{"br", "de"}, false)
+	_ = b.Add("name", []string{"Anna", "Zed"}, false)
+
+	ja, _ := json.Marshal(a)
+	jb, _ := json.Marshal(b)
+	if string(ja) != string(jb) {
+		t.Fatalf("serializations differ:\n%s\n%s", ja, jb)
+	}
+}
+
+func TestEntityProxyValidate(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	own := NewEntityProxy(m.Get("Ownership"), "o1")
+	_ = own.Add("owner", []string{"p1"}, false)
+	errs := own.Validate()
+	if len(errs) != 1 || errs[0].Property != "asset" {
+		t.Fatalf("expected missing asset error, got %v", errs)
+	}
+
+	p := NewEntityProxy(m.Get("Person"), "p1")
+	_ = p.Add("name", []string{"Ana"}, false)
+	if errs := p.Validate(); len(errs) != 0 {
+		t.Fatalf("expected valid person, got %v", errs)
+	}
+}
+
+func TestEntityProxyValidateReferences(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	known := map[string]*Schema{"p1": m.Get("Person"), "v1": m.Get("Vessel")}
+	resolve := func(id string) (*Schema, bool) {
+		s, ok := known[id]
+		return s, ok
+	}
+	dir := NewEntityProxy(m.Get("Directorship"), "d1")
+	_ = dir.Add("director", []string{"p1"}, false)
+	_ = dir.Add("organization", []string{"v1", "missing"}, false)
+	errs := dir.ValidateWith(ValidateOptions{Resolve: resolve})
+	if len(errs) != 2 {
+		t.Fatalf("expected two reference errors, got %v", errs)
+	}
+	if errs[0].Value != "v1" || errs[0].Reason != "referenced Vessel does not match range Organization" {
+		t.Fatalf("unexpected error %v", errs[0])
+	}
+	if errs[1].Value != "missing" || errs[1].Reason != "unresolved entity reference" {
+		t.Fatalf("unexpected error %v", errs[1])
+	}
+	if errs := dir.Validate(); len(errs) != 0 {
+		t.Fatalf("references are only checked with a resolver, got %v", errs)
+	}
+}
+
+func TestInvertEdge(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	rel := NewEntityProxy(m.Get("Associate"), "assoc1")
+	_ = rel.Add("person", []string{"a"}, false)
+	_ = rel.Add("associate", []string{"b"}, false)
+	inv, err := rel.InvertEdge()
+	if err != nil {
+		t.Fatalf("InvertEdge: %v", err)
+	}
+	if inv.First("person") != "b" || inv.First("associate") != "a" {
+		t.Fatalf("expected swapped endpoints, got %v", inv.ToDict())
+	}
+	again, _ := rel.InvertEdge()
+	if inv.ID == rel.ID || inv.ID != again.ID {
+		t.Fatalf("expected deterministic new ID, got %q and %q", inv.ID, again.ID)
+	}
+	if _, err := NewEntityProxy(m.Get("Ownership"), "o").InvertEdge(); err == nil {
+		t.Fatalf("expected error for directed edge")
+	}
+}
+
+func TestMultiStore(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	local := NewEntityProxy(m.Get("Person"), "p1")
+	_ = local.Add("name", []string{"Ana Silva"}, false)
+	remote := NewEntityProxy(m.Get("Person"), "p1")
+	_ = remote.Add("name", []string{"Ana Maria Silva"}, false)
+	_ = remote.Add("nationality", []string{"br"}, false)
+	only := NewEntityProxy(m.Get("Company"), "c1")
+
+	ms := NewMultiStore(NewMemoryEntityStore(local), NewMemoryEntityStore(remote, only))
+	e, err := ms.Get("p1")
+	if err != nil || len(e.Get("name")) != 1 || e.Has("nationality") {
+		t.Fatalf("expected first store to win, got %v (%v)", e, err)
+	}
+	if e, err := ms.Get("c1"); err != nil || e.Schema.Name != "Company" {
+		t.Fatalf("expected fallback to second store, got %v", err)
+	}
+	ms.Merge = true
+	e, err = ms.Get("p1")
+	if err != nil || len(e.Get("name")) != 2 || e.First("nationality") != "br" {
+		t.Fatalf("expected merged entity, got %v (%v)", e, err)
+	}
+	if _, err := ms.Get("missing"); !errors.Is(err, ErrEntityNotFound) {
+		t.Fatalf("expected ErrEntityNotFound, got %v", err)
+	}
+}
+
+func TestMaxLengthPolicy(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	p := m.Get("Person").Get("name")
+	long := strings.Repeat("é", p.maxLength()+5)
+
+	e := NewEntityProxy(m.Get("Person"), "p0")
+	_ = e.Add("name", []string{long}, false)
+	if got := e.First("name"); got != long {
+		t.Fatalf("untouched proxy should keep long values, got %d runes", utf8.RuneCountInString(got))
+	}
+	c := NewEntityProxy(m.Get("Company"), "c0")
+	regNr := strings.Repeat("1", 100)
+	if _, ok := c.UnsafeAdd(m.Get("Company").Get("registrationNumber"), regNr, false); !ok || c.First("registrationNumber") != regNr {
+		t.Fatalf("untouched proxy should keep long identifiers, got %q", c.First("registrationNumber"))
+	}
+	if c.DroppedValues() != 0 {
+		t.Fatalf("expected no dropped values, got %d", c.DroppedValues())
+	}
+
+	e = NewEntityProxy(m.Get("Person"), "p1")
+	e.LengthPolicy = LengthTruncate
+	_ = e.Add("name", []string{long}, false)
+	if got := e.First("name"); utf8.RuneCountInString(got) != p.maxLength() || !utf8.ValidString(got) {
+		t.Fatalf("expected truncation at rune boundary, got %d runes", utf8.RuneCountInString(got))
+	}
+
+	e = NewEntityProxy(m.Get("Person"), "p2")
+	e.LengthPolicy = LengthReject
+	e.Strict = true
+	err = e.Add("name", []string{long, "Ana"}, false)
+	var pe PropertyError
+	if !errors.As(err, &pe) || pe.Property != "name" {
+		t.Fatalf("expected PropertyError for over-long name, got %v", err)
+	}
+	if e.DroppedValues() != 1 || e.First("name") != "Ana" {
+		t.Fatalf("expected one dropped value, got %d / %v", e.DroppedValues(), e.Get("name"))
+	}
+}
+
+func TestEntityProxySizes(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	e := NewEntityProxy(m.Get("Person"), "p1")
+	if e.PropertyCount() != 0 || e.ValueCount() != 0 || e.Size() != 0 || len(e.PropertySizes()) != 0 {
+		t.Fatalf("empty proxy: %d %d %d", e.PropertyCount(), e.ValueCount(), e.Size())
+	}
+	_ = e.Add("name", []string{"Ana", "Ana Lima", "Ana"}, false)
+	_ = e.Add("nationality", []string{"br"}, false)
+	if e.PropertyCount() != 2 || e.ValueCount() != 3 {
+		t.Fatalf("counts: %d properties, %d values", e.PropertyCount(), e.ValueCount())
+	}
+	sizes := e.PropertySizes()
+	if sizes["name"] != 11 || sizes["nationality"] != 2 || e.Size() != 13 {
+		t.Fatalf("sizes: %v, total %d", sizes, e.Size())
+	}
+	e.Remove("name", "Ana")
+	if e.ValueCount() != 2 || e.Size() != 10 || e.PropertySizes()["name"] != 8 {
+		t.Fatalf("after remove: %d values, size %d", e.ValueCount(), e.Size())
+	}
+	if cp := e.Clone(); cp.Size() != e.Size() || cp.ValueCount() != e.ValueCount() {
+		t.Fatalf("clone: size %d, %d values", cp.Size(), cp.ValueCount())
+	}
+
+	// Values that would take the entity beyond the TotalSize budget of their
+	// type are dropped and counted.
+	m.Get("Person").Get("notes").Type = &TextType{BaseType{name: "text", totalSize: 20}}
+	_ = e.Add("notes", []string{"0123456789", "overflow"}, false)
+	if e.DroppedValues() != 1 || len(e.Get("notes")) != 1 || e.Size() != 20 {
+		t.Fatalf("total size: %d dropped, size %d", e.DroppedValues(), e.Size())
+	}
+	// Re-adding a value at the cap adds nothing, so nothing is dropped.
+	e.Strict = true
+	if err := e.Add("notes", []string{"0123456789"}, false); err != nil || e.DroppedValues() != 1 {
+		t.Fatalf("re-add at cap: %v, %d dropped", err, e.DroppedValues())
+	}
+}
+
+func TestIDRecipe(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	person, company := m.Get("Person"), m.Get("Company")
+
+	plain, ok := IDRecipe{}.Make(person, "123")
+	if want, _ := makeEntityID("", "123"); !ok || plain != want {
+		t.Fatalf("plain recipe should hash the parts only: %s", plain)
+	}
+	if id, _ := (IDRecipe{}).Make(company, "123"); id != plain {
+		t.Fatalf("without schema, IDs should not depend on it")
+	}
+	p1, _ := IDRecipe{IncludeSchema: true}.Make(person, "123")
+	c1, _ := IDRecipe{IncludeSchema: true}.Make(company, "123")
+	if p1 == c1 || p1 == plain {
+		t.Fatalf("schema should be mixed into the hash: %s %s", p1, c1)
+	}
+	keyed, _ := IDRecipe{KeyPrefix: "secret"}.Make(person, "123")
+	if keyed == plain {
+		t.Fatalf("key prefix should change the hash")
+	}
+	prefixed, _ := IDRecipe{Prefix: "ofac"}.Make(person, "123")
+	if prefixed != "ofac-"+plain {
+		t.Fatalf("readable prefix: %s", prefixed)
+	}
+	for _, r := range []IDRecipe{{}, {IncludeSchema: true}, {Prefix: "ofac"}} {
+		if id, ok := r.Make(person, "", ""); ok || id != "" {
+			t.Fatalf("%+v: empty parts should fail, got %q", r, id)
+		}
+	}
+
+	e := NewEntityProxy(person, "")
+	if id, ok := e.MakeIDWithSchema("123"); !ok || id != p1 || e.ID != p1 {
+		t.Fatalf("MakeIDWithSchema: %s", id)
+	}
+	if id, ok := e.MakeIDWithSchema(""); ok || id != p1 {
+		t.Fatalf("failed MakeIDWithSchema should keep the ID, got %s", id)
+	}
+	e.KeyPrefix = "secret"
+	withKey, _ := IDRecipe{KeyPrefix: "secret", IncludeSchema: true}.Make(person, "123")
+	if id, _ := e.MakeIDWithSchema("123"); id != withKey {
+		t.Fatalf("MakeIDWithSchema should use the proxy key prefix")
+	}
+	if id, _ := e.MakeIDWith(IDRecipe{KeyPrefix: "other"}, "123"); id == withKey {
+		t.Fatalf("recipe key prefix should win over the proxy's")
+	}
+}
+
+func TestRemoveIfAndPopAllType(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	e := NewEntityProxy(m.Get("Person"), "p1")
+	_ = e.AddWithLang("name", []string{"Ana Lima", "Анна Лима", "A. Lima"}, false, "por")
+	_ = e.Add("nationality", []string{"br"}, false)
+	_ = e.Add("country", []string{"pt"}, false)
+	size := e.Size()
+
+	removed := e.RemoveIf("name", func(v string) bool { return strings.HasPrefix(v, "A") })
+	if len(removed) != 2 || removed[0] != "Ana Lima" || removed[1] != "A. Lima" {
+		t.Fatalf("removed: %v", removed)
+	}
+	if got := e.Get("name"); len(got) != 1 || got[0] != "Анна Лима" || e.ValueLang("name", "Анна Лима") != "por" {
+		t.Fatalf("kept: %v", got)
+	}
+	if e.ValueLang("name", "Ana Lima") != "" {
+		t.Fatalf("metadata of removed values should be dropped")
+	}
+	if e.Size() != size-len("Ana Lima")-len("A. Lima") {
+		t.Fatalf("size after RemoveIf: %d", e.Size())
+	}
+	if got := e.RemoveIf("name", func(string) bool { return false }); got != nil || e.RemoveIf("nope", func(string) bool { return true }) != nil {
+		t.Fatalf("nothing should be removed: %v", got)
+	}
+
+	countries := e.PopAllType(m.Registry().Country)
+	if len(countries) != 2 || countries["nationality"][0] != "br" || countries["country"][0] != "pt" {
+		t.Fatalf("popped: %v", countries)
+	}
+	if e.PropertyCount() != 1 || len(e.Countries()) != 0 || e.Size() != len("Анна Лима") {
+		t.Fatalf("after PopAllType: %d properties, size %d", e.PropertyCount(), e.Size())
+	}
+	if got := e.PopAllType(m.Registry().Country); len(got) != 0 {
+		t.Fatalf("second pop: %v", got)
+	}
+	if got := e.PopAllType(m.Registry().Name); len(got["name"]) != 1 || e.Size() != 0 || e.ValueLang("name", "Анна Лима") != "" {
+		t.Fatalf("pop names: %v, size %d", got, e.Size())
+	}
+}
+
+func TestConsolidateText(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	e := NewEntityProxy(m.Get("Company"), "c1")
+	notes := []string{
+		"ACME Corp is a shell company registered in Panama in 2010.",
+		"ACME Corp is a shell company registered in Panama in 2010",
+		"acme corp is a shell company, registered in Panama in 2010!",
+		"Sanctioned by OFAC.",
+		"ACME Corp is a shell company registered in Panama in 2010 by a nominee director.",
+	}
+	for _, n := range notes {
+		_ = e.Add("notes", []string{n}, false)
+	}
+	_ = e.Add("name", []string{"ACME", "Acme"}, false)
+	if removed := ConsolidateText(e); removed != 2 {
+		t.Fatalf("expected 2 removed notes, got %d: %v", removed, e.Get("notes"))
+	}
+	got := e.Get("notes")
+	if len(got) != 3 || got[1] != "Sanctioned by OFAC." || len(e.Get("name")) != 2 {
+		t.Fatalf("unexpected values: %v", e.ToDict())
+	}
+	if ConsolidateTextWith(e, ConsolidateOptions{MaxValues: 1}) != 2 || e.First("notes") != notes[4] {
+		t.Fatalf("expected only the longest note: %v", e.Get("notes"))
+	}
+}
+
+func TestSchemaResolverMerge(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	person := NewEntityProxy(m.Get("Person"), "x")
+	_ = person.Add("name", []string{"ACME"}, false)
+	company := NewEntityProxy(m.Get("Company"), "x")
+	_ = company.Add("jurisdiction", []string{"de"}, false)
+	if _, err := person.Clone().Merge(company); err == nil {
+		t.Fatalf("expected unrelated schemata to fail by default")
+	}
+
+	m.SetSchemaResolver(ResolveCommonAncestor)
+	merged, err := person.Clone().Merge(company)
+	if err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	if merged.Schema.Name != "LegalEntity" || merged.First("name") != "ACME" || merged.First("jurisdiction") != "de" {
+		t.Fatalf("unexpected merge result: %v", merged.ToDict())
+	}
+	if _, err := m.CommonSchema(m.Get("Person"), m.Get("Vessel")); err == nil {
+		t.Fatalf("expected no concrete ancestor for Person and Vessel")
+	}
+	m.SetSchemaResolver(nil)
+	if _, err := m.CommonSchema(m.Get("Person"), m.Get("Company")); err == nil {
+		t.Fatalf("expected default policy to be restored")
+	}
+}
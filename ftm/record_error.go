@@ -0,0 +1,87 @@
+package ftm
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// snippetLength is the maximum number of bytes of a record included in a RecordError.
+const snippetLength = 120
+
+// RecordError locates a decoding failure in a statement or entity stream.
+// Record is the 1-based record number (line number for JSON lines).
+type RecordError struct {
+	Format  string
+	Record  int
+	Snippet string
+	Err     error
+}
+
+func (re *RecordError) Error() string {
+	if re.Snippet == "" {
+		return fmt.Sprintf("%s record %d: %v", re.Format, re.Record, re.Err)
+	}
+	return fmt.Sprintf("%s record %d: %v (near %q)", re.Format, re.Record, re.Err, re.Snippet)
+}
+
+func (re *RecordError) Unwrap() error { return re.Err }
+
+// ReadOptions configures the statement and entity readers.
+type ReadOptions struct {
+	// OnReject is called for records that cannot be decoded. If it returns nil the
+	// record is skipped and reading continues; otherwise reading stops with the
+	// returned error. Without OnReject, the first bad record stops reading.
+	OnReject func(*RecordError) error
+	// KeyAlgorithm is the recipe for IDs of statements read without one. If
+	// nil, the recipe selected by SetStatementKeyAlgorithm is used.
+	KeyAlgorithm *StatementKeyAlgorithm
+}
+
+// reject applies the reject policy to a record error.
+func (o ReadOptions) reject(re *RecordError) error {
+	if o.OnReject == nil {
+		return re
+	}
+	return o.OnReject(re)
+}
+
+// snippet truncates a record for inclusion in error messages.
+func snippet(b []byte) string {
+	b = bytes.TrimSpace(b)
+	if len(b) > snippetLength {
+		b = b[:snippetLength]
+		for len(b) > 0 && !utf8.Valid(b) {
+			b = b[:len(b)-1]
+		}
+		return string(b) + "…"
+	}
+	return string(b)
+}
+
+// readLines calls fn for each non-blank line of r with its 1-based line number.
+// Errors returned by decode are wrapped as RecordError and passed through opts.
+func readLines(r io.Reader, format string, opts ReadOptions, decode func(line []byte) error, fn func() error) error {
+	br := bufio.NewReader(r)
+	for n := 1; ; n++ {
+		line, err := br.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if len(bytes.TrimSpace(line)) > 0 {
+			if derr := decode(line); derr != nil {
+				if rerr := opts.reject(&RecordError{Format: format, Record: n, Snippet: snippet(line), Err: derr}); rerr != nil {
+					return rerr
+				}
+			} else if ferr := fn(); ferr != nil {
+				return ferr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package ftm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReifyRule describes how a plain entity-typed link is represented as a relationship entity.
+// The entity holding the link is written to EntityProp of the relationship, the linked
+// entity ID to ValueProp.
+type ReifyRule struct {
+	Property   string // qualified link property, e.g. "Vehicle:owner"
+	Schema     string // relationship schema, e.g. "Ownership"
+	EntityProp string // relationship property receiving the holder entity ID
+	ValueProp  string // relationship property receiving the linked entity ID
+}
+
+// DefaultReifyRules covers the deprecated direct links that upstream models as relationships.
+var DefaultReifyRules = []ReifyRule{
+	{Property: "LegalEntity:parent", Schema: "Ownership", EntityProp: "asset", ValueProp: "owner"},
+	{Property: "Vehicle:owner", Schema: "Ownership", EntityProp: "asset", ValueProp: "owner"},
+}
+
+// Reify converts plain entity links on e matching the given rules into relationship
+// entities with deterministic IDs. The converted link values are removed from e.
+func Reify(e *EntityProxy, rules []ReifyRule) ([]*EntityProxy, error) {
+	var out []*EntityProxy
+	for _, rule := range rules {
+		_, name := splitQName(rule.Property)
+		p := e.Schema.Get(name)
+		if p == nil || p.QName != rule.Property {
+			continue
+		}
+		schema := e.Schema.Model.Get(rule.Schema)
+		if schema == nil {
+			return nil, fmt.Errorf("schema not found: %s", rule.Schema)
+		}
+		for _, value := range e.Pop(p.Name) {
+			rel := NewEntityProxy(schema, "")
+			rel.KeyPrefix = e.KeyPrefix
+			if _, ok := rel.MakeID(schema.Name, e.ID, p.Name, value); !ok {
+				continue
+			}
+			if err := rel.Add(rule.EntityProp, []string{e.ID}, false); err != nil {
+				return nil, err
+			}
+			if err := rel.Add(rule.ValueProp, []string{value}, false); err != nil {
+				return nil, err
+			}
+			out = append(out, rel)
+		}
+	}
+	return out, nil
+}
+
+// Simplify is SimplifyWith without a resolver: the holder's schema is unknown,
+// so it only applies when a single rule is registered for the relationship
+// schema.
+func Simplify(rel *EntityProxy, rules []ReifyRule) (*EntityProxy, bool) {
+	return SimplifyWith(rel, rules, nil)
+}
+
+// SimplifyWith collapses a simple relationship entity (one holder and one linked
+// value) into a partial entity carrying the direct link. Several rules may share
+// a relationship schema (an Ownership of a Vehicle becomes Vehicle:owner, that of
+// a company LegalEntity:parent), so the rule is chosen by the holder's schema,
+// looked up with resolve: only rules whose link property the holder has apply,
+// and the partial entity keeps the holder's schema. If resolve is nil or does not
+// know the holder, a rule is only used if it is the single candidate. The partial
+// entity is meant to be merged with the full holder entity; other properties of
+// the relationship (percentage, dates) are dropped. It returns false if no rule
+// applies.
+func SimplifyWith(rel *EntityProxy, rules []ReifyRule, resolve EntityResolver) (*EntityProxy, bool) {
+	var candidates []ReifyRule
+	for _, rule := range rules {
+		if rel.Schema.Name == rule.Schema {
+			candidates = append(candidates, rule)
+		}
+	}
+	for _, rule := range candidates {
+		holders := rel.Get(rule.EntityProp)
+		values := rel.Get(rule.ValueProp)
+		if len(holders) != 1 || len(values) != 1 {
+			continue
+		}
+		schemaName, name := splitQName(rule.Property)
+		schema := rel.Schema.Model.Get(schemaName)
+		if schema == nil || schema.Get(name) == nil {
+			continue
+		}
+		var holder *Schema
+		if resolve != nil {
+			holder, _ = resolve(holders[0])
+		}
+		switch {
+		case holder != nil && !holder.IsA(schema.Name):
+			continue
+		case holder != nil:
+			schema = holder
+		case len(candidates) > 1:
+			return nil, false
+		}
+		out := NewEntityProxy(schema, holders[0])
+		out.KeyPrefix = rel.KeyPrefix
+		if err := out.Add(name, values, false); err != nil || !out.Has(name) {
+			continue
+		}
+		return out, true
+	}
+	return nil, false
+}
+
+// splitQName splits a qualified property name into schema and property names.
+func splitQName(qname string) (string, string) {
+	schema, prop, ok := strings.Cut(qname, ":")
+	if !ok {
+		return "", qname
+	}
+	return schema, prop
+}
@@ -0,0 +1,91 @@
+package ftm
+
+import "testing"
+
+func TestReifyAndSimplifyRoundTrip(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	c := NewEntityProxy(m.Get("Company"), "sub")
+	_ = c.Add("name", []string{"Subsidiary Ltd"}, false)
+	_ = c.Add("parent", []string{"holding"}, false)
+
+	rels, err := Reify(c, DefaultReifyRules)
+	if err != nil {
+		t.Fatalf("Reify: %v", err)
+	}
+	if len(rels) != 1 || rels[0].Schema.Name != "Ownership" {
+		t.Fatalf("expected one Ownership, got %v", rels)
+	}
+	if c.Has("parent") {
+		t.Fatalf("parent link should be removed after reification")
+	}
+	again, _ := Reify(NewEntityProxy(m.Get("Company"), "sub"), nil)
+	if len(again) != 0 {
+		t.Fatalf("no rules should produce no relationships")
+	}
+	if rels[0].First("asset") != "sub" || rels[0].First("owner") != "holding" {
+		t.Fatalf("unexpected ownership values: %v", rels[0].ToDict())
+	}
+
+	resolve := func(id string) (*Schema, bool) { return m.Get("Company"), id == "sub" }
+	simple, ok := SimplifyWith(rels[0], DefaultReifyRules, resolve)
+	if !ok {
+		t.Fatalf("Simplify failed")
+	}
+	if simple.ID != "sub" || simple.First("parent") != "holding" {
+		t.Fatalf("unexpected simplified entity: %v", simple.ToDict())
+	}
+}
+
+func TestSimplifyChoosesRuleByHolderSchema(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	schemata := map[string]string{"car1": "Vehicle", "sub": "Company", "p1": "Person"}
+	resolve := func(id string) (*Schema, bool) {
+		sc := m.Get(schemata[id])
+		return sc, sc != nil
+	}
+	own := func(asset, owner string) *EntityProxy {
+		rel := NewEntityProxy(m.Get("Ownership"), asset+"-"+owner)
+		_ = rel.Add("asset", []string{asset}, false)
+		_ = rel.Add("owner", []string{owner}, false)
+		_ = rel.Add("percentage", []string{"50"}, false)
+		_ = rel.Add("startDate", []string{"2020-01-01"}, false)
+		return rel
+	}
+
+	vehicle, ok := SimplifyWith(own("car1", "p1"), DefaultReifyRules, resolve)
+	if !ok {
+		t.Fatal("expected the vehicle ownership to simplify")
+	}
+	if vehicle.Schema.Name != "Vehicle" || vehicle.ID != "car1" || vehicle.First("owner") != "p1" {
+		t.Fatalf("unexpected simplified vehicle: %v", vehicle.ToDict())
+	}
+	if vehicle.PropertyCount() != 1 {
+		t.Fatalf("percentage and dates should be dropped: %v", vehicle.ToDict())
+	}
+
+	company, ok := SimplifyWith(own("sub", "p1"), DefaultReifyRules, resolve)
+	if !ok || company.Schema.Name != "Company" || company.First("parent") != "p1" {
+		t.Fatalf("unexpected simplified company: %v", company)
+	}
+	if company.Has("percentage") || company.Has("startDate") || company.PropertyCount() != 1 {
+		t.Fatalf("percentage and dates should be dropped: %v", company.ToDict())
+	}
+
+	// Without knowing the asset, the Ownership rules are ambiguous.
+	if _, ok := Simplify(own("car1", "p1"), DefaultReifyRules); ok {
+		t.Fatal("expected no simplification without the asset schema")
+	}
+	if _, ok := SimplifyWith(own("unknown", "p1"), DefaultReifyRules, resolve); ok {
+		t.Fatal("expected no simplification for an unknown asset")
+	}
+	// A single rule needs no resolver.
+	if v, ok := Simplify(own("car1", "p1"), DefaultReifyRules[1:]); !ok || v.Schema.Name != "Vehicle" {
+		t.Fatalf("expected the single rule to apply, got %v", v)
+	}
+}
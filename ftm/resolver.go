@@ -0,0 +1,253 @@
+package ftm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Judgement is a merge decision between two entity IDs, as recorded by
+// nomenklatura.
+type Judgement string
+
+const (
+	JudgementPositive Judgement = "positive"
+	JudgementNegative Judgement = "negative"
+	JudgementUnsure   Judgement = "unsure"
+	JudgementNone     Judgement = "no_judgement"
+)
+
+// Resolver applies merge decisions made elsewhere: IDs connected by positive
+// judgements form a cluster, and every member of a cluster resolves to the
+// same canonical ID. As in nomenklatura, the canonical ID is the greatest ID of
+// the cluster, preferring IDs with the "NK-" prefix. It is safe for concurrent use.
+type Resolver struct {
+	mu sync.Mutex
+	// edges holds the latest judgement per pair of IDs (greater ID first).
+	edges map[[2]string]Judgement
+	// canonical and clusters are built from edges when first needed.
+	canonical map[string]string
+	clusters  map[string][]string
+}
+
+// NewResolver creates a resolver without decisions.
+func NewResolver() *Resolver {
+	return &Resolver{edges: map[[2]string]Judgement{}}
+}
+
+// LoadResolver reads merge decisions in one of the formats written by
+// nomenklatura: JSON lines of edges, [target, source, judgement, ...] (the
+// resolver file), or a JSON object mapping entity IDs to canonical IDs (the
+// linker). A top-level array of edges is accepted as well. Later decisions
+// about the same pair replace earlier ones.
+func LoadResolver(r io.Reader) (*Resolver, error) {
+	res := NewResolver()
+	dec := json.NewDecoder(r)
+	for n := 1; ; n++ {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return res, nil
+			}
+			return nil, &RecordError{Format: "resolver", Record: n, Err: err}
+		}
+		if err := res.load(bytes.TrimSpace(raw)); err != nil {
+			return nil, &RecordError{Format: "resolver", Record: n, Snippet: snippet(raw), Err: err}
+		}
+	}
+}
+
+func (r *Resolver) load(raw []byte) error {
+	switch {
+	case bytes.HasPrefix(raw, []byte("{")):
+		var links map[string]string
+		if err := json.Unmarshal(raw, &links); err != nil {
+			return err
+		}
+		for id, canonical := range links {
+			r.Decide(id, canonical, JudgementPositive)
+		}
+		return nil
+	case bytes.HasPrefix(raw, []byte("[")):
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return err
+		}
+		if len(items) > 0 && bytes.HasPrefix(bytes.TrimSpace(items[0]), []byte("[")) {
+			for _, item := range items {
+				if err := r.loadEdge(item); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return r.loadEdge(raw)
+	}
+	return fmt.Errorf("expected an edge or an object of links")
+}
+
+func (r *Resolver) loadEdge(raw []byte) error {
+	var edge []any
+	if err := json.Unmarshal(raw, &edge); err != nil {
+		return err
+	}
+	if len(edge) < 3 {
+		return fmt.Errorf("edge needs target, source and judgement")
+	}
+	target, ok1 := edge[0].(string)
+	source, ok2 := edge[1].(string)
+	judgement, ok3 := edge[2].(string)
+	if !ok1 || !ok2 || !ok3 {
+		return fmt.Errorf("edge target, source and judgement must be strings")
+	}
+	switch j := Judgement(judgement); j {
+	case JudgementPositive, JudgementNegative, JudgementUnsure, JudgementNone:
+		r.Decide(target, source, j)
+		return nil
+	}
+	return fmt.Errorf("unknown judgement: %s", judgement)
+}
+
+// Decide records a judgement between two IDs, replacing an earlier one.
+func (r *Resolver) Decide(a, b string, j Judgement) {
+	if a == "" || b == "" || a == b {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.edges[edgeKey(a, b)] = j
+	r.canonical, r.clusters = nil, nil
+}
+
+// Merge connects IDs with positive judgements.
+func (r *Resolver) Merge(ids ...string) {
+	for i := 1; i < len(ids); i++ {
+		r.Decide(ids[0], ids[i], JudgementPositive)
+	}
+}
+
+// Judgement returns the decision recorded for a pair, or JudgementNone.
+func (r *Resolver) Judgement(a, b string) Judgement {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if j, ok := r.edges[edgeKey(a, b)]; ok {
+		return j
+	}
+	return JudgementNone
+}
+
+func edgeKey(a, b string) [2]string {
+	if idGreater(b, a) {
+		a, b = b, a
+	}
+	return [2]string{a, b}
+}
+
+// idGreater orders IDs for choosing canonical IDs: "NK-" IDs come last, then
+// by string order.
+func idGreater(a, b string) bool {
+	na, nb := strings.HasPrefix(a, "NK-"), strings.HasPrefix(b, "NK-")
+	if na != nb {
+		return na
+	}
+	return a > b
+}
+
+// build computes the clusters of positive edges with a union-find.
+func (r *Resolver) build() {
+	if r.canonical != nil {
+		return
+	}
+	parent := map[string]string{}
+	var find func(string) string
+	find = func(id string) string {
+		p, ok := parent[id]
+		if !ok {
+			parent[id] = id
+			return id
+		}
+		if p == id {
+			return id
+		}
+		root := find(p)
+		parent[id] = root
+		return root
+	}
+	for pair, j := range r.edges {
+		if j != JudgementPositive {
+			continue
+		}
+		ra, rb := find(pair[0]), find(pair[1])
+		if ra == rb {
+			continue
+		}
+		if idGreater(rb, ra) {
+			ra, rb = rb, ra
+		}
+		parent[rb] = ra
+	}
+	r.canonical = map[string]string{}
+	r.clusters = map[string][]string{}
+	for id := range parent {
+		root := find(id)
+		r.canonical[id] = root
+		r.clusters[root] = append(r.clusters[root], id)
+	}
+	for _, members := range r.clusters {
+		sort.Strings(members)
+	}
+}
+
+// Canonical returns the canonical ID of id, which is id itself unless it was
+// merged with others.
+func (r *Resolver) Canonical(id string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.build()
+	if c, ok := r.canonical[id]; ok {
+		return c
+	}
+	return id
+}
+
+// Connected returns the sorted IDs of the cluster of id, including id.
+func (r *Resolver) Connected(id string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.build()
+	c, ok := r.canonical[id]
+	if !ok {
+		return []string{id}
+	}
+	return append([]string(nil), r.clusters[c]...)
+}
+
+// Apply sets the canonical ID of a statement from its entity ID. As in
+// nomenklatura, the resolver is authoritative: entities it does not know keep
+// their own ID as canonical ID.
+func (r *Resolver) Apply(s Statement) Statement {
+	s.CanonicalID = r.Canonical(s.EntityID)
+	return s
+}
+
+// ApplyStatements rewrites the canonical IDs of a statement stream. The result
+// is no longer sorted by canonical ID; see SortStatements.
+func (r *Resolver) ApplyStatements(statements iter.Seq2[Statement, error]) iter.Seq2[Statement, error] {
+	return func(yield func(Statement, error) bool) {
+		for s, err := range statements {
+			if err != nil {
+				yield(Statement{}, err)
+				return
+			}
+			if !yield(r.Apply(s), nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package ftm
+
+// Risk categories, ordered from most to least severe in RiskCategories.
+const (
+	RiskSanction = "sanction"
+	RiskCrime    = "crime"
+	RiskPEP      = "pep"
+)
+
+// RiskCategories lists the risk categories by descending severity, each with the
+// topic patterns (see TopicType.Match) it covers.
+var RiskCategories = []struct {
+	Name     string
+	Patterns []string
+}{
+	{RiskSanction, []string{"sanction*", "asset.frozen", "debarment", "export.control"}},
+	{RiskCrime, []string{"crime*", "wanted", "forced.labor"}},
+	{RiskPEP, []string{"role.pep", "role.rca", "gov.head"}},
+}
+
+// RiskSummary groups the risk-relevant topics of an entity by category.
+type RiskSummary struct {
+	Topics     map[string][]string `json:"topics"`      // category -> matching topics
+	MostSevere string              `json:"most_severe"` // empty if no risk topics
+}
+
+// RiskTopics classifies the entity's topics into risk categories and flags the most severe one.
+func (e *EntityProxy) RiskTopics() RiskSummary {
+	summary := RiskSummary{Topics: map[string][]string{}}
+	topic := e.Schema.Model.registry.Topic
+	topics := e.GetTypeValues(topic, false)
+	for _, cat := range RiskCategories {
+		matched := topic.Filter(topics, cat.Patterns...)
+		if len(matched) == 0 {
+			continue
+		}
+		summary.Topics[cat.Name] = matched
+		if summary.MostSevere == "" {
+			summary.MostSevere = cat.Name
+		}
+	}
+	return summary
+}
+
+// RiskCaption returns the entity caption annotated with its most severe risk category,
+// e.g. "John Smith [sanction]".
+func (e *EntityProxy) RiskCaption() string {
+	caption := e.Caption()
+	if risk := e.RiskTopics().MostSevere; risk != "" {
+		return caption + " [" + risk + "]"
+	}
+	return caption
+}
+
+// EntityStats is a summary of an entity's size and risk profile.
+type EntityStats struct {
+	Properties int            `json:"properties"`
+	Values     int            `json:"values"`
+	Size       int            `json:"size"`
+	Dropped    int            `json:"dropped"`
+	PropSizes  map[string]int `json:"prop_sizes"`
+	Risk       RiskSummary    `json:"risk"`
+}
+
+// Stats returns size statistics and the risk summary of the entity.
+func (e *EntityProxy) Stats() EntityStats {
+	return EntityStats{
+		Properties: e.PropertyCount(),
+		Values:     e.ValueCount(),
+		Size:       e.Size(),
+		Dropped:    e.DroppedValues(),
+		PropSizes:  e.PropertySizes(),
+		Risk:       e.RiskTopics(),
+	}
+}
@@ -0,0 +1,53 @@
+package ftm
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestRiskTopics(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	e := NewEntityProxy(m.Get("Person"), "p1")
+	_ = e.Add("name", []string{"John Smith"}, false)
+	if got := e.RiskTopics(); got.MostSevere != "" || len(got.Topics) != 0 {
+		t.Fatalf("no topics: %+v", got)
+	}
+	if got := e.RiskCaption(); got != "John Smith" {
+		t.Fatalf("caption without risk: %s", got)
+	}
+
+	_ = e.Add("topics", []string{"role.pep", "crime.fraud", "export.risk"}, false)
+	got := e.RiskTopics()
+	if got.MostSevere != RiskCrime || len(got.Topics) != 2 {
+		t.Fatalf("crime and pep: %+v", got)
+	}
+	if !slices.Equal(got.Topics[RiskCrime], []string{"crime.fraud"}) || !slices.Equal(got.Topics[RiskPEP], []string{"role.pep"}) {
+		t.Fatalf("topics by category: %v", got.Topics)
+	}
+	if c := e.RiskCaption(); c != "John Smith [crime]" {
+		t.Fatalf("caption: %s", c)
+	}
+
+	_ = e.Add("topics", []string{"sanction.linked"}, false)
+	if got := e.RiskTopics(); got.MostSevere != RiskSanction || !slices.Equal(got.Topics[RiskSanction], []string{"sanction.linked"}) {
+		t.Fatalf("sanction should be most severe: %+v", got)
+	}
+
+	st := e.Stats()
+	if st.Properties != 2 || st.Values != 5 || st.Size != e.Size() || st.PropSizes["name"] != len("John Smith") || st.Risk.MostSevere != RiskSanction {
+		t.Fatalf("stats: %+v", st)
+	}
+	raw, err := json.Marshal(st)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var back map[string]any
+	_ = json.Unmarshal(raw, &back)
+	if back["risk"].(map[string]any)["most_severe"] != RiskSanction || back["values"] != float64(5) {
+		t.Fatalf("stats json: %s", raw)
+	}
+}
@@ -0,0 +1,170 @@
+package ftm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// RotateOptions configures a RotatingWriter. A new file is started before a record
+// that would push the current file over MaxBytes (uncompressed) or MaxRecords.
+// Zero limits are ignored.
+type RotateOptions struct {
+	Dir        string // output directory
+	Prefix     string // file name prefix, default "out"
+	Gzip       bool   // compress files and add a ".gz" suffix
+	MaxBytes   int64
+	MaxRecords int
+}
+
+// RotatedFile describes one output file in the manifest.
+type RotatedFile struct {
+	Name    string `json:"name"`
+	Records int    `json:"records"`
+	Bytes   int64  `json:"bytes"` // uncompressed size
+}
+
+// RotateManifest lists the files written by a RotatingWriter, and the version of
+// the default model they were written with.
+type RotateManifest struct {
+	Files   []RotatedFile `json:"files"`
+	Records int           `json:"records"`
+	Model   ModelVersion  `json:"model"`
+}
+
+// RotatingWriter writes JSON lines into numbered files (out-0001.jsonl.gz, ...)
+// and records them in a manifest written on Close.
+type RotatingWriter struct {
+	opts     RotateOptions
+	file     *os.File
+	gz       *gzip.Writer
+	w        io.Writer
+	cur      *RotatedFile
+	manifest RotateManifest
+}
+
+// NewRotatingWriter creates the output directory if needed and returns a writer.
+func NewRotatingWriter(opts RotateOptions) (*RotatingWriter, error) {
+	if opts.Prefix == "" {
+		opts.Prefix = "out"
+	}
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &RotatingWriter{opts: opts, manifest: RotateManifest{Model: Default().Version()}}, nil
+}
+
+// WriteLine writes a single pre-encoded JSON line, adding the trailing newline if missing.
+func (rw *RotatingWriter) WriteLine(line []byte) error {
+	if len(line) == 0 || line[len(line)-1] != '\n' {
+		line = append(line, '\n')
+	}
+	if rw.cur == nil || rw.full(int64(len(line))) {
+		if err := rw.rotate(); err != nil {
+			return err
+		}
+	}
+	if _, err := rw.w.Write(line); err != nil {
+		return err
+	}
+	rw.cur.Records++
+	rw.cur.Bytes += int64(len(line))
+	rw.manifest.Records++
+	return nil
+}
+
+// WriteRecord JSON-encodes v as one line.
+func (rw *RotatingWriter) WriteRecord(v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return rw.WriteLine(b)
+}
+
+// WriteStatement writes a statement with the same normalization as WriteStatementsJSONL.
+func (rw *RotatingWriter) WriteStatement(s Statement) error {
+	var buf bytes.Buffer
+	if err := WriteStatementsJSONL(&buf, []Statement{s}); err != nil {
+		return err
+	}
+	return rw.WriteLine(buf.Bytes())
+}
+
+// WriteEntity writes an entity in its ToDict form.
+func (rw *RotatingWriter) WriteEntity(e *EntityProxy) error {
+	return rw.WriteRecord(e)
+}
+
+// full reports whether adding n bytes would exceed the current file's limits.
+func (rw *RotatingWriter) full(n int64) bool {
+	if rw.cur.Records == 0 {
+		return false
+	}
+	if rw.opts.MaxRecords > 0 && rw.cur.Records >= rw.opts.MaxRecords {
+		return true
+	}
+	return rw.opts.MaxBytes > 0 && rw.cur.Bytes+n > rw.opts.MaxBytes
+}
+
+// rotate closes the current file and opens the next one.
+func (rw *RotatingWriter) rotate() error {
+	if err := rw.closeFile(); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%04d.jsonl", rw.opts.Prefix, len(rw.manifest.Files)+1)
+	if rw.opts.Gzip {
+		name += ".gz"
+	}
+	f, err := os.Create(filepath.Join(rw.opts.Dir, name))
+	if err != nil {
+		return err
+	}
+	rw.file = f
+	rw.w = f
+	if rw.opts.Gzip {
+		rw.gz = gzip.NewWriter(f)
+		rw.w = rw.gz
+	}
+	rw.manifest.Files = append(rw.manifest.Files, RotatedFile{Name: name})
+	rw.cur = &rw.manifest.Files[len(rw.manifest.Files)-1]
+	return nil
+}
+
+func (rw *RotatingWriter) closeFile() error {
+	var errs []error
+	if rw.gz != nil {
+		errs = append(errs, rw.gz.Close())
+		rw.gz = nil
+	}
+	if rw.file != nil {
+		errs = append(errs, rw.file.Close())
+		rw.file = nil
+	}
+	return errors.Join(errs...)
+}
+
+// Manifest returns the files written so far.
+func (rw *RotatingWriter) Manifest() RotateManifest {
+	m := rw.manifest
+	m.Files = append([]RotatedFile{}, rw.manifest.Files...)
+	return m
+}
+
+// Close flushes the current file and writes <prefix>-manifest.json, as
+// canonical JSON so that its SHA-256 is the ContentHash of the manifest.
+func (rw *RotatingWriter) Close() error {
+	if err := rw.closeFile(); err != nil {
+		return err
+	}
+	b, err := CanonicalJSON(rw.manifest)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(rw.opts.Dir, rw.opts.Prefix+"-manifest.json"), b, 0o644)
+}
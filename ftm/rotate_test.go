@@ -0,0 +1,38 @@
+package ftm
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRotatingWriter(t *testing.T) {
+	dir := t.TempDir()
+	rw, err := NewRotatingWriter(RotateOptions{Dir: dir, Gzip: true, MaxRecords: 2})
+	if err != nil {
+		t.Fatalf("NewRotatingWriter: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := rw.WriteRecord(map[string]int{"n": i}); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	if err := rw.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, "out-manifest.json"))
+	if err != nil {
+		t.Fatalf("manifest: %v", err)
+	}
+	var man RotateManifest
+	if err := json.Unmarshal(raw, &man); err != nil {
+		t.Fatalf("manifest json: %v", err)
+	}
+	if len(man.Files) != 3 || man.Records != 5 || man.Files[2].Name != "out-0003.jsonl.gz" {
+		t.Fatalf("unexpected manifest: %+v", man)
+	}
+	if canonical, _ := CanonicalJSON(man); string(canonical) != string(raw) {
+		t.Fatalf("manifest is not canonical JSON: %s", raw)
+	}
+}
@@ -0,0 +1,168 @@
+package ftm
+
+import (
+	"container/heap"
+	"crypto/sha1"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// SampleTuple is one sampled statement value.
+type SampleTuple struct {
+	Schema  string `json:"schema"`
+	Prop    string `json:"prop"`
+	Value   string `json:"value"`
+	Dataset string `json:"dataset"`
+}
+
+// StatementSnapshot summarizes a statement stream for QA: value counts per
+// schema:prop and a seed-based sample of tuples.
+type StatementSnapshot struct {
+	Seed   string         `json:"seed"`
+	Total  int            `json:"total"`
+	Counts map[string]int `json:"counts"` // "Schema:prop" -> number of statements
+	Sample []SampleTuple  `json:"sample"`
+}
+
+// StatementSampler builds a StatementSnapshot. The sample keeps the tuples with the
+// lowest seeded hash, so it only depends on the set of statements and the seed, not
+// on their order, and unchanged data yields the same sample across releases.
+type StatementSampler struct {
+	seed   string
+	size   int
+	total  int
+	counts map[string]int
+	heap   sampleHeap
+	seen   map[SampleTuple]struct{}
+}
+
+// NewStatementSampler creates a sampler keeping at most size tuples.
+func NewStatementSampler(seed string, size int) *StatementSampler {
+	return &StatementSampler{seed: seed, size: size, counts: map[string]int{}, seen: map[SampleTuple]struct{}{}}
+}
+
+// Add records a statement. Entity ID statements are ignored.
+func (ss *StatementSampler) Add(s Statement) {
+	if s.Prop == BaseID {
+		return
+	}
+	ss.total++
+	ss.counts[s.Schema+":"+s.Prop]++
+	if ss.size <= 0 {
+		return
+	}
+	t := SampleTuple{Schema: s.Schema, Prop: s.Prop, Value: s.Value, Dataset: s.Dataset}
+	if _, ok := ss.seen[t]; ok {
+		return
+	}
+	h := ss.hash(t)
+	if len(ss.heap) < ss.size {
+		heap.Push(&ss.heap, sampleItem{h, t})
+		ss.seen[t] = struct{}{}
+		return
+	}
+	if h < ss.heap[0].hash {
+		delete(ss.seen, ss.heap[0].tuple)
+		ss.heap[0] = sampleItem{h, t}
+		heap.Fix(&ss.heap, 0)
+		ss.seen[t] = struct{}{}
+	}
+}
+
+func (ss *StatementSampler) hash(t SampleTuple) uint64 {
+	sum := sha1.Sum([]byte(ss.seed + "\x00" + t.Schema + "\x00" + t.Prop + "\x00" + t.Value + "\x00" + t.Dataset))
+	return binary.BigEndian.Uint64(sum[:8])
+}
+
+// Snapshot returns the counts and the sample ordered by hash.
+func (ss *StatementSampler) Snapshot() StatementSnapshot {
+	items := append(sampleHeap{}, ss.heap...)
+	sort.Slice(items, func(i, j int) bool { return items[i].hash < items[j].hash })
+	sample := make([]SampleTuple, len(items))
+	for i, it := range items {
+		sample[i] = it.tuple
+	}
+	counts := make(map[string]int, len(ss.counts))
+	for k, v := range ss.counts {
+		counts[k] = v
+	}
+	return StatementSnapshot{Seed: ss.seed, Total: ss.total, Counts: counts, Sample: sample}
+}
+
+type sampleItem struct {
+	hash  uint64
+	tuple SampleTuple
+}
+
+// sampleHeap is a max-heap on hash, so the root is the first tuple to evict.
+type sampleHeap []sampleItem
+
+func (h sampleHeap) Len() int           { return len(h) }
+func (h sampleHeap) Less(i, j int) bool { return h[i].hash > h[j].hash }
+func (h sampleHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *sampleHeap) Push(x any)        { *h = append(*h, x.(sampleItem)) }
+func (h *sampleHeap) Pop() any {
+	old := *h
+	it := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return it
+}
+
+// SnapshotDrift describes a schema:prop whose share of statements changed.
+type SnapshotDrift struct {
+	Key      string  `json:"key"`
+	Previous float64 `json:"previous"` // share of all statements in the previous snapshot
+	Current  float64 `json:"current"`
+}
+
+func (d SnapshotDrift) String() string {
+	return fmt.Sprintf("%s: %.4f -> %.4f", d.Key, d.Previous, d.Current)
+}
+
+// CompareSnapshots flags every schema:prop whose share of statements moved by more
+// than threshold (e.g. 0.01 for one percentage point), including keys that appear
+// or disappear. It also returns the fraction of the previous sample found in the
+// current one; with the same seed this stays close to 1 for stable data.
+func CompareSnapshots(prev, cur StatementSnapshot, threshold float64) ([]SnapshotDrift, float64) {
+	share := func(s StatementSnapshot, key string) float64 {
+		if s.Total == 0 {
+			return 0
+		}
+		return float64(s.Counts[key]) / float64(s.Total)
+	}
+	keys := map[string]struct{}{}
+	for k := range prev.Counts {
+		keys[k] = struct{}{}
+	}
+	for k := range cur.Counts {
+		keys[k] = struct{}{}
+	}
+	var drifts []SnapshotDrift
+	for k := range keys {
+		p, c := share(prev, k), share(cur, k)
+		_, inPrev := prev.Counts[k]
+		_, inCur := cur.Counts[k]
+		if math.Abs(p-c) > threshold || inPrev != inCur {
+			drifts = append(drifts, SnapshotDrift{Key: k, Previous: p, Current: c})
+		}
+	}
+	sort.Slice(drifts, func(i, j int) bool { return drifts[i].Key < drifts[j].Key })
+
+	overlap := 1.0
+	if len(prev.Sample) > 0 {
+		current := make(map[SampleTuple]struct{}, len(cur.Sample))
+		for _, t := range cur.Sample {
+			current[t] = struct{}{}
+		}
+		found := 0
+		for _, t := range prev.Sample {
+			if _, ok := current[t]; ok {
+				found++
+			}
+		}
+		overlap = float64(found) / float64(len(prev.Sample))
+	}
+	return drifts, overlap
+}
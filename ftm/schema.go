@@ -1,8 +1,9 @@
 package ftm
 
 import (
-	"fmt"
 	"slices"
+	"sort"
+	"strings"
 )
 
 // EdgeSpec defines how a schema is represented as a graph edge.
@@ -27,6 +28,7 @@ type Schema struct {
 	Label       string
 	Plural      string
 	Description string
+	URI         string // RDF class URI, see RDFNamespace
 
 	Abstract   bool
 	Hidden     bool
@@ -38,6 +40,10 @@ type Schema struct {
 	Required []string
 	Caption  []string
 
+	// RequiredOneOf lists groups of properties of which at least one must have a value.
+	// Groups declared on ancestors apply as well.
+	RequiredOneOf [][]string
+
 	EdgeSpec       EdgeSpec
 	TemporalExtent TemporalExtentSpec
 
@@ -54,34 +60,45 @@ type Schema struct {
 	Names       map[string]struct{} // names of Schemata
 	Descendants map[string]*Schema
 
+	// Properties holds own and inherited properties by name. Inherited entries
+	// point to the ancestor's Property; only properties (re)defined by this
+	// schema are separate values.
 	Properties map[string]*Property
 
 	temporalStart []string
 	temporalEnd   []string
 
+	hasLabel  bool // label declared in the spec
+	hasPlural bool // plural declared in the spec
 	generated bool
 }
 
 // schemaSpec is the YAML/JSON structure for loading a schema.
 type schemaSpec struct {
-	Label       string                  `yaml:"label" json:"label"`
-	Plural      string                  `yaml:"plural" json:"plural"`
-	Schemata    []string                `yaml:"schemata" json:"schemata"`
-	Extends     []string                `yaml:"extends" json:"extends"`
-	Properties  map[string]propertySpec `yaml:"properties" json:"properties"`
-	Featured    []string                `yaml:"featured" json:"featured"`
-	Required    []string                `yaml:"required" json:"required"`
-	Caption     []string                `yaml:"caption" json:"caption"`
-	Edge        EdgeSpec                `yaml:"edge" json:"edge"`
-	Temporal    TemporalExtentSpec      `yaml:"temporalExtent" json:"temporalExtent"`
-	Description string                  `yaml:"description" json:"description"`
-	Abstract    *bool                   `yaml:"abstract" json:"abstract"`
-	Hidden      *bool                   `yaml:"hidden" json:"hidden"`
-	Generated   *bool                   `yaml:"generated" json:"generated"`
-	Matchable   *bool                   `yaml:"matchable" json:"matchable"`
-	Deprecated  *bool                   `yaml:"deprecated" json:"deprecated"`
+	Label         string                  `yaml:"label" json:"label"`
+	Plural        string                  `yaml:"plural" json:"plural"`
+	Schemata      []string                `yaml:"schemata" json:"schemata"`
+	Extends       []string                `yaml:"extends" json:"extends"`
+	Properties    map[string]propertySpec `yaml:"properties" json:"properties"`
+	Featured      []string                `yaml:"featured" json:"featured"`
+	Required      []string                `yaml:"required" json:"required"`
+	RequiredOneOf [][]string              `yaml:"requiredOneOf" json:"requiredOneOf"`
+	Caption       []string                `yaml:"caption" json:"caption"`
+	Edge          EdgeSpec                `yaml:"edge" json:"edge"`
+	Temporal      TemporalExtentSpec      `yaml:"temporalExtent" json:"temporalExtent"`
+	Description   string                  `yaml:"description" json:"description"`
+	Abstract      *bool                   `yaml:"abstract" json:"abstract"`
+	Hidden        *bool                   `yaml:"hidden" json:"hidden"`
+	Generated     *bool                   `yaml:"generated" json:"generated"`
+	Matchable     *bool                   `yaml:"matchable" json:"matchable"`
+	Deprecated    *bool                   `yaml:"deprecated" json:"deprecated"`
+	RDF           string                  `yaml:"rdf" json:"rdf"`
 }
 
+// RDFNamespace is the namespace of schema and property URIs that are not set
+// with an rdf field in the spec: <ns>Person, <ns>Person:birthDate.
+const RDFNamespace = "https://schema.followthemoney.tech/#"
+
 // newSchema creates a new schema from its spec, without resolving inheritance or cross-links.
 func newSchema(m *Model, name string, spec schemaSpec) (*Schema, error) {
 	s := &Schema{
@@ -90,9 +107,11 @@ func newSchema(m *Model, name string, spec schemaSpec) (*Schema, error) {
 		Label:          spec.Label,
 		Plural:         spec.Plural,
 		Description:    spec.Description,
+		URI:            firstNonEmpty(spec.RDF, RDFNamespace+name),
 		Featured:       append([]string{}, spec.Featured...),
 		Required:       append([]string{}, spec.Required...),
 		Caption:        append([]string{}, spec.Caption...),
+		RequiredOneOf:  append([][]string{}, spec.RequiredOneOf...),
 		EdgeSpec:       spec.Edge,
 		TemporalExtent: spec.Temporal,
 		Extends:        []*Schema{},
@@ -102,6 +121,7 @@ func newSchema(m *Model, name string, spec schemaSpec) (*Schema, error) {
 		Properties:     map[string]*Property{},
 	}
 
+	s.hasLabel, s.hasPlural = s.Label != "", s.Plural != ""
 	if s.Label == "" {
 		s.Label = name
 	}
@@ -166,8 +186,14 @@ func newSchema(m *Model, name string, spec schemaSpec) (*Schema, error) {
 }
 
 // Generate finalizes the schema by resolving inheritance chains, property ranges, and establishing bidirectional reverse links.
-// This method is idempotent and safe to call multiple times.
+// This method is idempotent and safe to call multiple times, also concurrently.
 func (s *Schema) Generate() error {
+	s.Model.genMu.Lock()
+	defer s.Model.genMu.Unlock()
+	return s.generate()
+}
+
+func (s *Schema) generate() error {
 	if s.generated {
 		return nil
 	}
@@ -177,7 +203,12 @@ func (s *Schema) Generate() error {
 	// Inherit properties and ancestry.
 	for _, parent := range s.Model.extendsIndex[s.Name] {
 		// Ensure parent is generated first
-		_ = parent.Generate()
+		_ = parent.generate()
+
+		// Without own captions, use those of the first parent that has any
+		if len(s.Caption) == 0 && len(parent.Caption) > 0 {
+			s.Caption = append([]string{}, parent.Caption...)
+		}
 
 		// Parent already exists by model load stage
 		if _, ok := s.Schemata[parent.Name]; !ok {
@@ -199,9 +230,11 @@ func (s *Schema) Generate() error {
 		}
 	}
 
-	// Resolve ranges and reverse stubs for entity properties
-	for _, prop := range s.Properties {
-		if prop.Type.Name() == registry.Entity.Name() {
+	// Resolve ranges and reverse stubs for entity properties, in name order so
+	// that the first of two properties declaring the same reverse wins
+	for _, pn := range sortedKeys(s.Properties) {
+		prop := s.Properties[pn]
+		if prop.Type.Name() == s.Model.registry.Entity.Name() {
 			if prop.Range == nil {
 				if rngName := s.Model.rangeIndex[prop.QName]; rngName != "" {
 					if rng := s.Model.Schemata[rngName]; rng != nil {
@@ -222,14 +255,16 @@ func (s *Schema) Generate() error {
 							hidden = *rs.Hidden
 						}
 						rev = &Property{
-							Schema: targetSchema,
-							Name:   rs.Name,
-							QName:  targetSchema.Name + ":" + rs.Name,
-							Label:  rs.Label,
-							Hidden: hidden,
-							Type:   registry.Entity,
-							Range:  s,
-							Stub:   true,
+							Schema:  targetSchema,
+							Name:    rs.Name,
+							QName:   targetSchema.Name + ":" + rs.Name,
+							URI:     RDFNamespace + targetSchema.Name + ":" + rs.Name,
+							Label:   rs.Label,
+							Hidden:  hidden,
+							Type:    s.Model.registry.Entity,
+							Range:   s,
+							Stub:    true,
+							Reverse: prop,
 						}
 						targetSchema.Properties[rs.Name] = rev
 					}
@@ -243,44 +278,239 @@ func (s *Schema) Generate() error {
 	return nil
 }
 
+// ToDict serializes the schema in the structure of the upstream model dump
+// (as served by Aleph's metadata API). Only properties defined on this schema
+// are included; inherited ones are listed on the ancestors.
+func (s *Schema) ToDict() map[string]any {
+	extends := make([]string, 0, len(s.Extends))
+	for _, parent := range s.Extends {
+		extends = append(extends, parent.Name)
+	}
+	sort.Strings(extends)
+	names := make([]string, 0, len(s.Names))
+	for name := range s.Names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	data := map[string]any{
+		"label":    s.Label,
+		"plural":   s.Plural,
+		"schemata": names,
+		"extends":  extends,
+	}
+	if s.Edge {
+		data["edge"] = map[string]any{
+			"source":   s.EdgeSource,
+			"target":   s.EdgeTarget,
+			"caption":  s.EdgeCaption,
+			"label":    s.EdgeLabel(),
+			"directed": s.EdgeDirected,
+		}
+	}
+	start, end := ownPropNames(s, s.TemporalStartProps()), ownPropNames(s, s.TemporalEndProps())
+	if len(start) > 0 || len(end) > 0 {
+		data["temporalExtent"] = map[string]any{"start": start, "end": end}
+	}
+	if len(s.Featured) > 0 {
+		data["featured"] = s.Featured
+	}
+	if len(s.Required) > 0 {
+		data["required"] = s.Required
+	}
+	if len(s.Caption) > 0 {
+		data["caption"] = s.Caption
+	}
+	if s.Description != "" {
+		data["description"] = s.Description
+	}
+	if s.Abstract {
+		data["abstract"] = true
+	}
+	if s.Hidden {
+		data["hidden"] = true
+	}
+	if s.Generated {
+		data["generated"] = true
+	}
+	if s.Matchable {
+		data["matchable"] = true
+	}
+	if s.Deprecated {
+		data["deprecated"] = true
+	}
+	if s.URI != RDFNamespace+s.Name {
+		data["rdf"] = s.URI
+	}
+	props := map[string]any{}
+	for name, p := range s.Properties {
+		if p.Schema == s {
+			props[name] = p.ToDict()
+		}
+	}
+	data["properties"] = props
+	return data
+}
+
+// EdgeLabel returns the label used when rendering the schema as an edge from
+// source to target (e.g. "owns"), defaulting to the schema label. It is empty
+// for schemata that are not edges.
+func (s *Schema) EdgeLabel() string {
+	if !s.Edge {
+		return ""
+	}
+	if s.edgeLabel != "" {
+		return s.edgeLabel
+	}
+	return s.Label
+}
+
+// ReverseEdgeLabel returns the label used when rendering the edge from target
+// back to source. Undirected edges read the same both ways; directed edges use
+// the label of the target property's reverse (e.g. "Owners"), falling back to
+// EdgeLabel.
+func (s *Schema) ReverseEdgeLabel() string {
+	if !s.Edge || !s.EdgeDirected {
+		return s.EdgeLabel()
+	}
+	if target := s.Get(s.EdgeTarget); target != nil && target.Reverse != nil && target.Reverse.Label != "" {
+		return target.Reverse.Label
+	}
+	return s.EdgeLabel()
+}
+
+// ownPropNames returns the sorted names of properties defined on s.
+func ownPropNames(s *Schema, props []*Property) []string {
+	out := make([]string, 0, len(props))
+	for _, p := range props {
+		if p.Schema == s {
+			out = append(out, p.Name)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
 // Get returns the property by name, or nil if not found.
 func (s *Schema) Get(name string) *Property { return s.Properties[name] }
 
+// PropertiesByType returns the properties of the given type, sorted by name.
+func (s *Schema) PropertiesByType(pt PropertyType) []*Property {
+	return s.filterProperties(func(p *Property) bool { return p.Type.Name() == pt.Name() })
+}
+
+// PropertiesByGroup returns the properties whose type belongs to the given group
+// (e.g. "names", "identifiers"), sorted by name.
+func (s *Schema) PropertiesByGroup(group string) []*Property {
+	return s.filterProperties(func(p *Property) bool { return group != "" && p.Type.Group() == group })
+}
+
+// filterProperties returns the properties matching pred, sorted by name.
+func (s *Schema) filterProperties(pred func(*Property) bool) []*Property {
+	out := make([]*Property, 0)
+	for _, p := range s.Properties {
+		if pred(p) {
+			out = append(out, p)
+		}
+	}
+	slices.SortFunc(out, func(a, b *Property) int { return strings.Compare(a.Name, b.Name) })
+	return out
+}
+
 // IsA checks if the schema or any parent matches the candidate name.
 func (s *Schema) IsA(candidate string) bool {
 	_, ok := s.Names[candidate]
 	return ok
 }
 
-// SortedProperties returns properties sorted with caption/featured priority then by label.
+// DescendantsSorted returns all schemata extending this one (excluding itself), sorted by name.
+func (s *Schema) DescendantsSorted() []*Schema {
+	out := make([]*Schema, 0, len(s.Descendants))
+	for _, sc := range s.Descendants {
+		out = append(out, sc)
+	}
+	slices.SortFunc(out, func(a, b *Schema) int { return strings.Compare(a.Name, b.Name) })
+	return out
+}
+
+// MatchableDescendants returns the matchable schemata extending this one, sorted by name.
+func (s *Schema) MatchableDescendants() []*Schema {
+	out := make([]*Schema, 0)
+	for _, sc := range s.DescendantsSorted() {
+		if sc.Matchable {
+			out = append(out, sc)
+		}
+	}
+	return out
+}
+
+// MatchableSchemata returns the matchable schemata this schema can be compared
+// with: its matchable ancestors and descendants (including itself), sorted by name.
+// It is empty when the schema itself is not matchable.
+func (s *Schema) MatchableSchemata() []*Schema {
+	if !s.Matchable {
+		return nil
+	}
+	seen := map[string]*Schema{}
+	for name, sc := range s.Schemata {
+		if sc.Matchable {
+			seen[name] = sc
+		}
+	}
+	for name, sc := range s.Descendants {
+		if sc.Matchable {
+			seen[name] = sc
+		}
+	}
+	out := make([]*Schema, 0, len(seen))
+	for _, sc := range seen {
+		out = append(out, sc)
+	}
+	slices.SortFunc(out, func(a, b *Schema) int { return strings.Compare(a.Name, b.Name) })
+	return out
+}
+
+// CanMatch checks whether entities of this schema can be compared with entities of other.
+func (s *Schema) CanMatch(other *Schema) bool {
+	if other == nil || !s.Matchable || !other.Matchable {
+		return false
+	}
+	return s.IsA(other.Name) || other.IsA(s.Name)
+}
+
+// PropertySortOptions controls which properties SortedPropertiesWith returns.
+type PropertySortOptions struct {
+	IncludeHidden bool // include hidden properties
+	IncludeStubs  bool // include reverse stub properties
+}
+
+// SortedProperties returns the visible properties in display order: caption
+// properties in declared order, then featured properties in declared order, then
+// the rest alphabetically by label. Hidden and stub properties are excluded.
 func (s *Schema) SortedProperties() []*Property {
-	// Collect properties into a slice
+	return s.SortedPropertiesWith(PropertySortOptions{})
+}
+
+// SortedPropertiesWith is SortedProperties with control over hidden and stub properties.
+func (s *Schema) SortedPropertiesWith(opts PropertySortOptions) []*Property {
 	props := make([]*Property, 0, len(s.Properties))
 	for _, p := range s.Properties {
+		if (p.Hidden && !opts.IncludeHidden) || (p.Stub && !opts.IncludeStubs) {
+			continue
+		}
 		props = append(props, p)
 	}
 
-	// Keep deterministic order: captions first, then featured, then name
 	slices.SortFunc(props, func(a, b *Property) int {
-		// caption priority
-		ac := indexOf(s.Caption, a.Name)
-		bc := indexOf(s.Caption, b.Name)
-		if ac != bc {
-			return compareIndex(ac, bc)
-		}
-		// featured priority
-		af := indexOf(s.Featured, a.Name)
-		bf := indexOf(s.Featured, b.Name)
-		if af != bf {
-			return compareIndex(af, bf)
+		if c := compareIndex(indexOf(s.Caption, a.Name), indexOf(s.Caption, b.Name)); c != 0 {
+			return c
 		}
-		if a.Label < b.Label {
-			return -1
+		if c := compareIndex(indexOf(s.Featured, a.Name), indexOf(s.Featured, b.Name)); c != 0 {
+			return c
 		}
-		if a.Label > b.Label {
-			return 1
+		if c := strings.Compare(a.Label, b.Label); c != 0 {
+			return c
 		}
-		return 0
+		return strings.Compare(a.Name, b.Name)
 	})
 
 	return props
@@ -334,26 +564,11 @@ func (s *Schema) TemporalEndProps() []*Property {
 	return props
 }
 
-// Validate checks property presence and basic type validation.
+// Validate checks required properties, type validity and maximum value lengths.
+// It returns a *ValidationError listing every problem, or nil if data is valid.
 func (s *Schema) Validate(data map[string][]string) error {
-	// Required fields present?
-	for _, req := range s.Required {
-		if len(data[req]) == 0 {
-			return fmt.Errorf("required property missing: %s", req)
-		}
-	}
-
-	// Type-level validation
-	for name, values := range data {
-		p := s.Properties[name]
-		if p == nil {
-			continue
-		}
-		for _, v := range values {
-			if !p.Type.Validate(v) {
-				return fmt.Errorf("invalid value for %s", name)
-			}
-		}
+	if errs := s.validateValues(data); len(errs) > 0 {
+		return &ValidationError{Schema: s.Name, Errors: errs}
 	}
 	return nil
 }
@@ -0,0 +1,73 @@
+package ftm
+
+import (
+	"fmt"
+	"iter"
+)
+
+// SchemaSet is a set of schemata by name, e.g. "Person and subtypes", used to
+// filter statement and entity streams.
+type SchemaSet map[string]*Schema
+
+// SchemaSetOptions configures Model.SchemaSetWith.
+type SchemaSetOptions struct {
+	// MatchableOnly keeps only matchable schemata, so the set can be used to
+	// select entities for matching.
+	MatchableOnly bool
+}
+
+// SchemaSet expands the named schemata to include all their descendants.
+func (m *Model) SchemaSet(names ...string) (SchemaSet, error) {
+	return m.SchemaSetWith(SchemaSetOptions{}, names...)
+}
+
+// SchemaSetWith is SchemaSet with options.
+func (m *Model) SchemaSetWith(opts SchemaSetOptions, names ...string) (SchemaSet, error) {
+	set := SchemaSet{}
+	add := func(s *Schema) {
+		if !opts.MatchableOnly || s.Matchable {
+			set[s.Name] = s
+		}
+	}
+	for _, name := range names {
+		s := m.Get(name)
+		if s == nil {
+			return nil, fmt.Errorf("unknown schema: %s", name)
+		}
+		add(s)
+		for _, d := range s.Descendants {
+			add(d)
+		}
+	}
+	return set, nil
+}
+
+// Contains reports whether the named schema is in the set.
+func (set SchemaSet) Contains(name string) bool {
+	_, ok := set[name]
+	return ok
+}
+
+// Names returns the schema names in the set, sorted.
+func (set SchemaSet) Names() []string { return sortedKeys(set) }
+
+// FilterStatements yields the statements whose schema is in the set. Errors
+// are passed through.
+func (set SchemaSet) FilterStatements(src iter.Seq2[Statement, error]) iter.Seq2[Statement, error] {
+	return FilterStatements(src, SchemaFilter(set))
+}
+
+// FilterEntities yields the entities whose schema is in the set. Errors are
+// passed through.
+func (set SchemaSet) FilterEntities(src iter.Seq2[*EntityProxy, error]) iter.Seq2[*EntityProxy, error] {
+	return func(yield func(*EntityProxy, error) bool) {
+		for e, err := range src {
+			if err == nil && !set.Contains(e.Schema.Name) {
+				continue
+			}
+			if !yield(e, err) {
+				return
+			}
+		}
+	}
+}
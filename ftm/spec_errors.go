@@ -0,0 +1,86 @@
+package ftm
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaFileError locates a problem in a schema YAML file.
+type SchemaFileError struct {
+	Path   string
+	Schema string // schema name, empty for problems with the whole file
+	Line   int    // 1-based, 0 if unknown
+	Column int    // 1-based, 0 if unknown
+	Err    error
+}
+
+func (e *SchemaFileError) Error() string {
+	pos := e.Path
+	if e.Line > 0 {
+		pos += ":" + strconv.Itoa(e.Line)
+		if e.Column > 0 {
+			pos += ":" + strconv.Itoa(e.Column)
+		}
+	}
+	if e.Schema != "" {
+		return fmt.Sprintf("%s: schema %s: %v", pos, e.Schema, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", pos, e.Err)
+}
+
+func (e *SchemaFileError) Unwrap() error { return e.Err }
+
+var yamlLineRe = regexp.MustCompile(`line (\d+): `)
+
+// parseSpecFile decodes a schema file schema by schema, so that one broken
+// schema does not hide problems in the others. Errors carry the position of
+// the problem, or of the schema if yaml does not report one.
+func parseSpecFile(path string, raw []byte) (map[string]schemaSpec, []error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return nil, []error{yamlFileError(path, "", 0, 0, err.Error())}
+	}
+	defs := map[string]schemaSpec{}
+	if len(doc.Content) == 0 {
+		return defs, nil // empty file
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, []error{&SchemaFileError{Path: path, Line: root.Line, Column: root.Column, Err: fmt.Errorf("expected a mapping of schema names")}}
+	}
+	var errs []error
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		var spec schemaSpec
+		if err := value.Decode(&spec); err != nil {
+			if te, ok := err.(*yaml.TypeError); ok {
+				for _, msg := range te.Errors {
+					errs = append(errs, yamlFileError(path, key.Value, key.Line, key.Column, msg))
+				}
+				continue
+			}
+			errs = append(errs, yamlFileError(path, key.Value, key.Line, key.Column, err.Error()))
+			continue
+		}
+		if _, ok := defs[key.Value]; ok {
+			errs = append(errs, &SchemaFileError{Path: path, Schema: key.Value, Line: key.Line, Column: key.Column, Err: fmt.Errorf("duplicate schema name")})
+			continue
+		}
+		defs[key.Value] = spec
+	}
+	return defs, errs
+}
+
+// yamlFileError moves the "line N: " prefix of a yaml message into the error
+// position, falling back to the given line and column.
+func yamlFileError(path, schema string, line, column int, msg string) *SchemaFileError {
+	if m := yamlLineRe.FindStringSubmatchIndex(msg); m != nil {
+		line, _ = strconv.Atoi(msg[m[2]:m[3]])
+		column = 0
+		msg = msg[:m[0]] + msg[m[1]:]
+	}
+	return &SchemaFileError{Path: path, Schema: schema, Line: line, Column: column, Err: fmt.Errorf("%s", msg)}
+}
@@ -1,8 +1,6 @@
 package ftm
 
 import (
-	"crypto/sha1"
-	"encoding/hex"
 	"fmt"
 	"strings"
 )
@@ -29,23 +27,17 @@ type Statement struct {
 	Origin      string `json:"origin,omitempty"`
 }
 
-// MakeKey computes a deterministic ID for a statement.
+// MakeKey computes a deterministic ID for a statement with the recipe
+// selected by SetStatementKeyAlgorithm.
 func (s *Statement) MakeKey() string {
-	s.ID = MakeStatementKey(s.Dataset, s.EntityID, s.Prop, s.Value, s.External)
-	return s.ID
+	return s.MakeKeyWith(CurrentStatementKeyAlgorithm())
 }
 
-// MakeStatementKey hashes the key properties to produce an ID.
+// MakeStatementKey hashes the key properties to produce an ID with the
+// recipe selected by SetStatementKeyAlgorithm. Recipes including the schema or
+// property type need the full statement; use Statement.MakeKey for those.
 func MakeStatementKey(dataset, entityID, prop, value string, external bool) string {
-	if prop == "" || value == "" {
-		return ""
-	}
-	key := fmt.Sprintf("%s.%s.%s.%s", dataset, entityID, prop, value)
-	if external {
-		key += ".ext"
-	}
-	h := sha1.Sum([]byte(key))
-	return hex.EncodeToString(h[:])
+	return CurrentStatementKeyAlgorithm().Key(Statement{Dataset: dataset, EntityID: entityID, Prop: prop, Value: value, External: external})
 }
 
 // PropTypeName resolves the property type name for a (schema, prop) pair.
@@ -65,7 +57,8 @@ func PropTypeName(m *Model, schema, prop string) (string, error) {
 	return pr.Type.Name(), nil
 }
 
-// StatementsFromEntity emits statements for an entity.
+// StatementsFromEntity emits statements for an entity. The language and
+// original value recorded for each value on the proxy are carried over.
 func StatementsFromEntity(e *EntityProxy, dataset string, firstSeen, lastSeen string, external bool, origin string) []Statement {
     if e == nil || e.ID == "" {
         return nil
@@ -97,6 +90,8 @@ func StatementsFromEntity(e *EntityProxy, dataset string, firstSeen, lastSeen st
                 Schema:      e.Schema.Name,
                 Value:       v,
                 Dataset:     dataset,
+                Lang:        e.ValueLang(name, v),
+                Original:    e.ValueOriginal(name, v),
                 External:    external,
                 FirstSeen:   firstSeen,
                 LastSeen:    ifEmpty(lastSeen, firstSeen),
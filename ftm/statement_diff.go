@@ -0,0 +1,177 @@
+package ftm
+
+import (
+	"fmt"
+	"iter"
+)
+
+// DiffOp is the kind of a change between two statement streams.
+type DiffOp string
+
+const (
+	DiffAdd DiffOp = "ADD"
+	DiffDel DiffOp = "DEL"
+	// DiffMod marks an entity that kept some statements; only used in EntityChange.
+	DiffMod DiffOp = "MOD"
+)
+
+// StatementChange is a statement added to or removed from a stream.
+type StatementChange struct {
+	Op        DiffOp    `json:"op"`
+	Statement Statement `json:"statement"`
+}
+
+// EntityChange summarizes the statement changes of one canonical ID. Op is ADD
+// for an entity that is new, DEL for one that is gone and MOD otherwise.
+type EntityChange struct {
+	ID      string      `json:"id"`
+	Op      DiffOp      `json:"op"`
+	Added   []Statement `json:"added,omitempty"`
+	Removed []Statement `json:"removed,omitempty"`
+}
+
+// DiffStatements compares two statement streams sorted as by SortStatements
+// (by GroupKey, then ID), old a and new b, and yields the statements removed
+// from a and added in b, in stream order. Statements with the same key that
+// differ only in first_seen or last_seen are unchanged; other differences are
+// reported as a removal and an addition. Input out of order is an error.
+func DiffStatements(a, b iter.Seq2[Statement, error]) iter.Seq2[StatementChange, error] {
+	return func(yield func(StatementChange, error) bool) {
+		err := diffStatements(a, b, func(_ string, op DiffOp, s Statement) bool {
+			return op == "" || yield(StatementChange{Op: op, Statement: s}, nil)
+		})
+		if err != nil {
+			yield(StatementChange{}, err)
+		}
+	}
+}
+
+// DiffEntities compares two sorted statement streams like DiffStatements and
+// yields one EntityChange per canonical ID with changes, so indexes can be
+// updated incrementally.
+func DiffEntities(a, b iter.Seq2[Statement, error]) iter.Seq2[EntityChange, error] {
+	return func(yield func(EntityChange, error) bool) {
+		var cur EntityChange
+		kept, started := false, false
+		flush := func() bool {
+			if !started || (len(cur.Added) == 0 && len(cur.Removed) == 0) {
+				return true
+			}
+			switch {
+			case kept || (len(cur.Added) > 0 && len(cur.Removed) > 0):
+				cur.Op = DiffMod
+			case len(cur.Added) > 0:
+				cur.Op = DiffAdd
+			default:
+				cur.Op = DiffDel
+			}
+			return yield(cur, nil)
+		}
+		stopped := false
+		err := diffStatements(a, b, func(gk string, op DiffOp, s Statement) bool {
+			if !started || gk != cur.ID {
+				if !flush() {
+					stopped = true
+					return false
+				}
+				cur, kept, started = EntityChange{ID: gk}, false, true
+			}
+			switch op {
+			case DiffAdd:
+				cur.Added = append(cur.Added, s)
+			case DiffDel:
+				cur.Removed = append(cur.Removed, s)
+			default:
+				kept = true
+			}
+			return true
+		})
+		if err != nil {
+			yield(EntityChange{}, err)
+			return
+		}
+		if !stopped {
+			flush()
+		}
+	}
+}
+
+// diffStatements merges the sorted streams a and b, calling fn with DiffDel
+// for statements only in a, DiffAdd for those only in b, and an empty op for
+// unchanged statements (from b). It stops when fn returns false.
+func diffStatements(a, b iter.Seq2[Statement, error], fn func(gk string, op DiffOp, s Statement) bool) error {
+	nextA, stopA := iter.Pull2(a)
+	defer stopA()
+	nextB, stopB := iter.Pull2(b)
+	defer stopB()
+
+	pull := func(next func() (Statement, error, bool), prev *Statement, name string) (Statement, bool, error) {
+		s, err, ok := next()
+		if !ok {
+			return Statement{}, false, nil
+		}
+		if err != nil {
+			return Statement{}, false, err
+		}
+		if s.ID == "" {
+			s.MakeKey()
+		}
+		if prev != nil && compareStatements(*prev, s) > 0 {
+			return Statement{}, false, fmt.Errorf("%s statements out of order: %s/%s after %s/%s", name, s.GroupKey(), s.ID, prev.GroupKey(), prev.ID)
+		}
+		return s, true, nil
+	}
+	sa, okA, err := pull(nextA, nil, "old")
+	if err != nil {
+		return err
+	}
+	sb, okB, err := pull(nextB, nil, "new")
+	if err != nil {
+		return err
+	}
+	for okA || okB {
+		c := 0
+		switch {
+		case !okA:
+			c = 1
+		case !okB:
+			c = -1
+		default:
+			c = compareStatements(sa, sb)
+		}
+		var cont bool
+		switch {
+		case c < 0:
+			cont = fn(sa.GroupKey(), DiffDel, sa)
+		case c > 0:
+			cont = fn(sb.GroupKey(), DiffAdd, sb)
+		case sameStatement(sa, sb):
+			cont = fn(sb.GroupKey(), "", sb)
+		default:
+			cont = fn(sa.GroupKey(), DiffDel, sa) && fn(sb.GroupKey(), DiffAdd, sb)
+		}
+		if !cont {
+			return nil
+		}
+		if c <= 0 {
+			prev := sa
+			if sa, okA, err = pull(nextA, &prev, "old"); err != nil {
+				return err
+			}
+		}
+		if c >= 0 {
+			prev := sb
+			if sb, okB, err = pull(nextB, &prev, "new"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sameStatement compares statements ignoring when they were seen.
+func sameStatement(a, b Statement) bool {
+	a.FirstSeen, a.LastSeen = "", ""
+	b.FirstSeen, b.LastSeen = "", ""
+	return a == b
+}
@@ -53,14 +53,14 @@ func (se *StatementEntity) AddStatement(m *Model, s Statement) error {
     if se.stmts[s.Prop] == nil {
         se.stmts[s.Prop] = map[string]Statement{}
     }
-    if s.ID == "" {
-        s.MakeKey()
-    }
     if s.PropType == "" {
         if t, err := PropTypeName(m, s.Schema, s.Prop); err == nil {
             s.PropType = t
         }
     }
+    if s.ID == "" {
+        s.MakeKey()
+    }
     // keep canonical id aligned if provided
     if s.CanonicalID == "" && se.ID != "" {
         s.CanonicalID = se.ID
@@ -0,0 +1,162 @@
+package ftm
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ToDict serializes the entity with its provenance, nesting the statements of
+// each property under "properties":
+//
+//	{"id": "NK-1", "schema": "Person", "datasets": ["a"], "referents": ["q1"],
+//	 "first_seen": "...", "last_seen": "...",
+//	 "properties": {"name": [{"value": "Ana", "dataset": "a", "lang": "por", ...}]}}
+//
+// Statement fields are named as in Statement's JSON form and left out when
+// empty; entity_id only appears for values contributed by a referent.
+func (se *StatementEntity) ToDict() map[string]any {
+	props := map[string]any{}
+	var prov provenance
+	for _, s := range se.Statements() {
+		if s.Prop == BaseID {
+			continue
+		}
+		prov.add(s)
+		v := map[string]any{"value": s.Value}
+		for key, val := range map[string]string{
+			"id": s.ID, "dataset": s.Dataset, "lang": s.Lang, "original_value": s.Original,
+			"first_seen": s.FirstSeen, "last_seen": s.LastSeen, "origin": s.Origin,
+		} {
+			if val != "" {
+				v[key] = val
+			}
+		}
+		if s.EntityID != se.ID {
+			v["entity_id"] = s.EntityID
+		}
+		if s.External {
+			v["external"] = true
+		}
+		values, _ := props[s.Prop].([]any)
+		props[s.Prop] = append(values, v)
+	}
+	data := map[string]any{
+		"id":         se.ID,
+		"schema":     se.Schema.Name,
+		"properties": props,
+	}
+	for _, r := range se.Referents() {
+		prov.add(Statement{EntityID: r})
+	}
+	proxy := NewEntityProxy(se.Schema, se.ID)
+	prov.apply(proxy)
+	for k, v := range proxy.Context {
+		data[k] = v
+	}
+	if se.LastChange != "" {
+		data["last_change"] = se.LastChange
+	}
+	return data
+}
+
+// StatementEntityFromDict loads an entity written by StatementEntity.ToDict.
+// Plain entity dicts (property values as strings) are accepted too; their
+// values become statements of the given dataset.
+func StatementEntityFromDict(m *Model, data map[string]any, dataset string) (*StatementEntity, error) {
+	id, _ := data["id"].(string)
+	schema, _ := data["schema"].(string)
+	if id == "" || schema == "" {
+		return nil, errors.New("the 'id' and 'schema' fields are required strings")
+	}
+	se, err := NewStatementEntity(m, dataset, schema, id)
+	if err != nil {
+		return nil, err
+	}
+	se.LastChange, _ = data["last_change"].(string)
+	props, ok := data["properties"].(map[string]any)
+	if !ok && data["properties"] != nil {
+		return nil, errors.New("the 'properties' field must be a map")
+	}
+	for _, name := range sortedKeys(props) {
+		values, ok := props[name].([]any)
+		if !ok {
+			return nil, fmt.Errorf("property %q must be a list", name)
+		}
+		for i, raw := range values {
+			s := Statement{EntityID: id, CanonicalID: id, Prop: name, Schema: schema, Dataset: dataset}
+			switch v := raw.(type) {
+			case string:
+				s.Value = v
+			case map[string]any:
+				for key, dst := range map[string]*string{
+					"id": &s.ID, "value": &s.Value, "dataset": &s.Dataset, "lang": &s.Lang,
+					"original_value": &s.Original, "first_seen": &s.FirstSeen, "last_seen": &s.LastSeen,
+					"origin": &s.Origin, "entity_id": &s.EntityID,
+				} {
+					if str, ok := v[key].(string); ok {
+						*dst = str
+					}
+				}
+				s.External, _ = v["external"].(bool)
+			default:
+				return nil, fmt.Errorf("property %q value at index %d is neither a string nor a statement", name, i)
+			}
+			if s.Value == "" {
+				continue
+			}
+			if err := se.AddStatement(m, s); err != nil {
+				return nil, fmt.Errorf("property %q: %w", name, err)
+			}
+		}
+	}
+	for _, r := range contextStrings(data["referents"]) {
+		if r != id {
+			se.ExtraReferents[r] = struct{}{}
+		}
+	}
+	return se, nil
+}
+
+// Proxy converts the entity to an EntityProxy, keeping the language and
+// original value of each value and the provenance context of aggregation
+// (datasets, referents, first_seen and last_seen).
+func (se *StatementEntity) Proxy() *EntityProxy {
+	e := NewEntityProxy(se.Schema, se.ID)
+	var prov provenance
+	for _, s := range se.Statements() {
+		if s.Prop == BaseID {
+			continue
+		}
+		prov.add(s)
+		_ = e.addWithMeta(s.Prop, []string{s.Value}, true, s.Lang, s.Original)
+	}
+	for _, r := range se.Referents() {
+		prov.add(Statement{EntityID: r})
+	}
+	prov.apply(e)
+	return e
+}
+
+// StatementEntityFromProxy converts an EntityProxy into a StatementEntity of
+// the given dataset. The first_seen and last_seen context fields, if present,
+// date the statements.
+func StatementEntityFromProxy(e *EntityProxy, dataset string) (*StatementEntity, error) {
+	m := e.Schema.Model
+	se, err := NewStatementEntity(m, dataset, e.Schema.Name, e.ID)
+	if err != nil {
+		return nil, err
+	}
+	firstSeen, _ := e.Context["first_seen"].(string)
+	lastSeen, _ := e.Context["last_seen"].(string)
+	for _, s := range StatementsFromEntity(e, dataset, firstSeen, lastSeen, false, "") {
+		if err := se.AddStatement(m, s); err != nil {
+			return nil, err
+		}
+	}
+	for _, r := range contextStrings(e.Context["referents"]) {
+		if r != e.ID {
+			se.ExtraReferents[r] = struct{}{}
+		}
+	}
+	return se, nil
+}
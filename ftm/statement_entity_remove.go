@@ -0,0 +1,64 @@
+package ftm
+
+// Len returns the number of statements held by the entity, not counting the
+// synthetic BaseID statement.
+func (se *StatementEntity) Len() int {
+	n := 0
+	for _, stmts := range se.stmts {
+		n += len(stmts)
+	}
+	return n
+}
+
+// RemoveStatement retracts the statement with the given ID and reports
+// whether it was present.
+func (se *StatementEntity) RemoveStatement(id string) bool {
+	return se.RemoveWhere(func(s Statement) bool { return s.ID == id }) > 0
+}
+
+// RemoveOrigin retracts all statements of the given origin and returns how
+// many were removed.
+func (se *StatementEntity) RemoveOrigin(origin string) int {
+	return se.RemoveWhere(func(s Statement) bool { return s.Origin == origin })
+}
+
+// RemoveDataset retracts all statements of the given dataset and returns how
+// many were removed.
+func (se *StatementEntity) RemoveDataset(dataset string) int {
+	return se.RemoveWhere(func(s Statement) bool { return s.Dataset == dataset })
+}
+
+// RemoveWhere retracts the statements matching fn and returns how many were
+// removed. Properties left without statements are dropped, and so are
+// referents that no remaining statement comes from. The schema is kept even
+// if it was widened by a removed statement.
+func (se *StatementEntity) RemoveWhere(fn func(Statement) bool) int {
+	removed := 0
+	orphans := map[string]struct{}{}
+	for prop, stmts := range se.stmts {
+		for id, s := range stmts {
+			if !fn(s) {
+				continue
+			}
+			delete(stmts, id)
+			removed++
+			if s.EntityID != "" && s.EntityID != se.ID {
+				orphans[s.EntityID] = struct{}{}
+			}
+		}
+		if len(stmts) == 0 {
+			delete(se.stmts, prop)
+		}
+	}
+	if len(orphans) > 0 {
+		for _, stmts := range se.stmts {
+			for _, s := range stmts {
+				delete(orphans, s.EntityID)
+			}
+		}
+		for id := range orphans {
+			delete(se.ExtraReferents, id)
+		}
+	}
+	return removed
+}
@@ -1,6 +1,10 @@
 package ftm
 
-import "testing"
+import (
+    "encoding/json"
+    "reflect"
+    "testing"
+)
 
 func TestStatementEntityBaseIDValue(t *testing.T) {
     m, err := NewModel("../schema")
@@ -34,3 +38,116 @@ func TestStatementEntityBaseIDValue(t *testing.T) {
     }
 }
 
+
+func TestStatementEntityDict(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	se, err := NewStatementEntity(m, "a", "Person", "NK-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = se.AddStatement(m, Statement{EntityID: "q1", Schema: "Person", Prop: "name", Value: "Ana", Lang: "por", Original: "ANA", Dataset: "a", FirstSeen: "2024-01-01", LastSeen: "2024-06-01"})
+	_ = se.AddStatement(m, Statement{EntityID: "NK-1", Schema: "Person", Prop: "country", Value: "br", Dataset: "b", FirstSeen: "2023-05-01", Origin: "crawl"})
+
+	// Round trip through JSON, as a store would.
+	raw, err := json.Marshal(se.ToDict())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data map[string]any
+	if err := json.Unmarshal(raw, &data); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(data["datasets"], []any{"a", "b"}) || !reflect.DeepEqual(data["referents"], []any{"q1"}) {
+		t.Fatalf("unexpected provenance: %s", raw)
+	}
+	if data["first_seen"] != "2023-05-01" || data["last_seen"] != "2024-06-01" {
+		t.Fatalf("unexpected seen range: %s", raw)
+	}
+	back, err := StatementEntityFromDict(m, data, "a")
+	if err != nil {
+		t.Fatalf("StatementEntityFromDict: %v", err)
+	}
+	if !reflect.DeepEqual(back.Statements(), se.Statements()) {
+		t.Fatalf("statements changed:\n%v\n%v", back.Statements(), se.Statements())
+	}
+	if !reflect.DeepEqual(back.Referents(), []string{"q1"}) {
+		t.Fatalf("unexpected referents: %v", back.Referents())
+	}
+
+	// Plain entity dicts become statements of the given dataset.
+	plain, err := StatementEntityFromDict(m, map[string]any{
+		"id": "p1", "schema": "Person", "properties": map[string]any{"name": []any{"Bo"}},
+	}, "c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st := plain.Statements(); len(st) != 2 || st[0].Value != "Bo" || st[0].Dataset != "c" {
+		t.Fatalf("unexpected statements: %v", st)
+	}
+	if _, err := StatementEntityFromDict(m, map[string]any{"schema": "Person"}, "c"); err == nil {
+		t.Fatal("expected error without id")
+	}
+
+	// Proxy conversion keeps value metadata and provenance.
+	e := se.Proxy()
+	if e.ValueLang("name", "Ana") != "por" || e.ValueOriginal("name", "Ana") != "ANA" {
+		t.Fatalf("value metadata lost: %v", e.ToDict())
+	}
+	if !reflect.DeepEqual(e.Context["referents"], []string{"q1"}) || e.Context["first_seen"] != "2023-05-01" {
+		t.Fatalf("unexpected context: %v", e.Context)
+	}
+	fromProxy, err := StatementEntityFromProxy(e, "d")
+	if err != nil {
+		t.Fatalf("StatementEntityFromProxy: %v", err)
+	}
+	st := fromProxy.Statements()
+	if len(st) != 3 || st[0].Dataset != "d" || st[0].FirstSeen != "2023-05-01" {
+		t.Fatalf("unexpected statements: %v", st)
+	}
+	if !reflect.DeepEqual(fromProxy.Referents(), []string{"q1"}) {
+		t.Fatalf("unexpected referents: %v", fromProxy.Referents())
+	}
+}
+
+func TestStatementEntityRemove(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	se, err := NewStatementEntity(m, "a", "Person", "NK-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []Statement{
+		{EntityID: "q1", Schema: "Person", Prop: "name", Value: "Ana", Dataset: "a", Origin: "crawl"},
+		{EntityID: "q1", Schema: "Person", Prop: "birthDate", Value: "1980", Dataset: "a"},
+		{EntityID: "q2", Schema: "Person", Prop: "name", Value: "Ann", Dataset: "b", Origin: "crawl"},
+		{EntityID: "NK-1", Schema: "Person", Prop: "country", Value: "br", Dataset: "b"},
+	} {
+		if err := se.AddStatement(m, s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if se.Len() != 4 {
+		t.Fatalf("expected 4 statements, got %d", se.Len())
+	}
+	if n := se.RemoveOrigin("crawl"); n != 2 || se.Len() != 2 {
+		t.Fatalf("RemoveOrigin removed %d, %d left", n, se.Len())
+	}
+	if refs := se.Referents(); len(refs) != 1 || refs[0] != "q1" {
+		t.Fatalf("unexpected referents: %v", refs)
+	}
+	if n := se.RemoveDataset("a"); n != 1 || len(se.Referents()) != 0 {
+		t.Fatalf("RemoveDataset removed %d, referents %v", n, se.Referents())
+	}
+	id := se.Statements()[0].ID
+	if !se.RemoveStatement(id) || se.RemoveStatement(id) || se.Len() != 0 {
+		t.Fatalf("RemoveStatement did not remove %s once", id)
+	}
+	if st := se.Statements(); len(st) != 1 || st[0].Prop != BaseID {
+		t.Fatalf("expected only the BaseID statement, got %v", st)
+	}
+}
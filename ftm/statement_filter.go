@@ -0,0 +1,131 @@
+package ftm
+
+import (
+	"iter"
+	"slices"
+)
+
+// StatementFilter decides whether a statement is kept. Filters compose with
+// AndFilter, OrFilter and NotFilter and apply to any statement reader through
+// FilterStatements:
+//
+//	people, _ := m.SchemaSet("Person")
+//	src = ftm.FilterStatements(src, ftm.DatasetFilter("us_ofac_sdn"), ftm.SchemaFilter(people))
+type StatementFilter func(Statement) bool
+
+// FilterStatements yields the statements of src accepted by all filters.
+// Errors are passed through.
+func FilterStatements(src iter.Seq2[Statement, error], filters ...StatementFilter) iter.Seq2[Statement, error] {
+	keep := AndFilter(filters...)
+	return func(yield func(Statement, error) bool) {
+		for st, err := range src {
+			if err == nil && !keep(st) {
+				continue
+			}
+			if !yield(st, err) {
+				return
+			}
+		}
+	}
+}
+
+// AndFilter accepts statements accepted by all filters, or all statements if
+// there are none.
+func AndFilter(filters ...StatementFilter) StatementFilter {
+	return func(st Statement) bool {
+		for _, f := range filters {
+			if !f(st) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// OrFilter accepts statements accepted by any of the filters.
+func OrFilter(filters ...StatementFilter) StatementFilter {
+	return func(st Statement) bool {
+		for _, f := range filters {
+			if f(st) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// NotFilter inverts a filter.
+func NotFilter(f StatementFilter) StatementFilter {
+	return func(st Statement) bool { return !f(st) }
+}
+
+// DatasetFilter accepts statements of the given datasets.
+func DatasetFilter(names ...string) StatementFilter {
+	return func(st Statement) bool { return slices.Contains(names, st.Dataset) }
+}
+
+// SchemaFilter accepts statements whose schema is in the set; see
+// Model.SchemaSet for including descendants.
+func SchemaFilter(set SchemaSet) StatementFilter {
+	return func(st Statement) bool { return set.Contains(st.Schema) }
+}
+
+// PropTypeFilter accepts statements of properties of the given types (e.g.
+// "name", "country"). Statements without prop_type are resolved in m.
+func PropTypeFilter(m *Model, types ...string) StatementFilter {
+	return func(st Statement) bool { return slices.Contains(types, statementPropType(m, st)) }
+}
+
+// PropGroupFilter accepts statements whose property type belongs to one of the
+// given groups (e.g. "names", "countries").
+func PropGroupFilter(m *Model, groups ...string) StatementFilter {
+	return func(st Statement) bool {
+		t := m.Registry().Get(statementPropType(m, st))
+		return t != nil && t.Group() != "" && slices.Contains(groups, t.Group())
+	}
+}
+
+func statementPropType(m *Model, st Statement) string {
+	if st.PropType != "" {
+		return st.PropType
+	}
+	t, _ := PropTypeName(m, st.Schema, st.Prop)
+	return t
+}
+
+// FirstSeenFilter accepts statements first seen between from and to,
+// inclusive. Timestamps compare as ISO 8601 strings and an empty bound is
+// open; a shorter bound covers its whole period, so to "2024" includes
+// "2024-12-31T10:00:00". Statements without first_seen are rejected.
+func FirstSeenFilter(from, to string) StatementFilter {
+	return func(st Statement) bool { return inTimeRange(st.FirstSeen, from, to) }
+}
+
+// LastSeenFilter accepts statements last seen between from and to, with the
+// bounds of FirstSeenFilter.
+func LastSeenFilter(from, to string) StatementFilter {
+	return func(st Statement) bool { return inTimeRange(st.LastSeen, from, to) }
+}
+
+func inTimeRange(v, from, to string) bool {
+	if v == "" {
+		return false
+	}
+	if from != "" && v < from {
+		return false
+	}
+	if to != "" && v[:min(len(v), len(to))] > to {
+		return false
+	}
+	return true
+}
+
+// OriginFilter accepts statements of the given origins.
+func OriginFilter(origins ...string) StatementFilter {
+	return func(st Statement) bool { return slices.Contains(origins, st.Origin) }
+}
+
+// ExternalFilter accepts statements whose external flag equals external.
+func ExternalFilter(external bool) StatementFilter {
+	return func(st Statement) bool { return st.External == external }
+}
@@ -1,160 +1,215 @@
 package ftm
 
 import (
-	"bufio"
 	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"io"
 	"strconv"
+	"strings"
 )
 
+// WriteOptions configures the statement writers.
+type WriteOptions struct {
+	// KeyAlgorithm is the recipe for IDs of statements written without one. If
+	// nil, the recipe selected by SetStatementKeyAlgorithm is used.
+	KeyAlgorithm *StatementKeyAlgorithm
+}
+
 // WriteStatementsJSONL writes statements as JSON lines.
 func WriteStatementsJSONL(w io.Writer, st []Statement) error {
-    enc := json.NewEncoder(w)
-    for i := range st {
-        st[i].Clean()
-        if st[i].ID == "" {
-            st[i].MakeKey()
-        }
-        if st[i].PropType == "" {
-            if t, err := PropTypeName(Default(), st[i].Schema, st[i].Prop); err == nil {
-                st[i].PropType = t
-            }
-        }
-        if err := enc.Encode(&st[i]); err != nil {
-            return err
-        }
-    }
-    return nil
+	return WriteStatementsJSONLWith(w, WriteOptions{}, st)
+}
+
+// WriteStatementsJSONLWith writes statements as JSON lines, filling in missing
+// IDs with the recipe in opts.
+func WriteStatementsJSONLWith(w io.Writer, opts WriteOptions, st []Statement) error {
+	enc := json.NewEncoder(w)
+	for i := range st {
+		st[i].Clean()
+		if st[i].ID == "" {
+			st[i].MakeKeyWith(opts.KeyAlgorithm.orCurrent())
+		}
+		if st[i].PropType == "" {
+			if t, err := PropTypeName(Default(), st[i].Schema, st[i].Prop); err == nil {
+				st[i].PropType = t
+			}
+		}
+		if err := enc.Encode(&st[i]); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // ReadStatementsJSONL reads statements from a JSON lines stream.
 func ReadStatementsJSONL(r io.Reader, fn func(Statement) error) error {
-    dec := json.NewDecoder(bufio.NewReader(r))
-    for {
-        var s Statement
-        if err := dec.Decode(&s); err != nil {
-            if err == io.EOF {
-                return nil
-            }
-            return err
-        }
-        s.Clean()
-        if s.ID == "" {
-            s.MakeKey()
-        }
-        if s.PropType == "" {
-            if t, err := PropTypeName(Default(), s.Schema, s.Prop); err == nil {
-                s.PropType = t
-            }
-        }
-        if err := fn(s); err != nil {
-            return err
-        }
-    }
+	return ReadStatementsJSONLWith(r, ReadOptions{}, fn)
 }
 
-// WriteStatementsCSV a minimal CSV writer (header with common fields).
+// ReadStatementsJSONLWith reads statements from a JSON lines stream, which may
+// be gzip or zstd compressed. Decoding failures are reported as *RecordError
+// and handled according to opts.
+func ReadStatementsJSONLWith(r io.Reader, opts ReadOptions, fn func(Statement) error) error {
+	dr, err := Decompress(r)
+	if err != nil {
+		return err
+	}
+	defer dr.Close()
+	var s Statement
+	decode := func(line []byte) error {
+		s = Statement{}
+		return json.Unmarshal(line, &s)
+	}
+	return readLines(dr, "jsonl", opts, decode, func() error {
+		s.Clean()
+		if s.ID == "" {
+			s.MakeKeyWith(opts.KeyAlgorithm.orCurrent())
+		}
+		if s.PropType == "" {
+			if t, err := PropTypeName(Default(), s.Schema, s.Prop); err == nil {
+				s.PropType = t
+			}
+		}
+		return fn(s)
+	})
+}
+
+// statementCSVHeader lists the columns written by WriteStatementsCSV.
+var statementCSVHeader = []string{"id", "entity_id", "canonical_id", "prop", "prop_type", "schema", "value", "dataset", "lang", "original_value", "external", "first_seen", "last_seen", "origin"}
+
+// WriteStatementsCSV writes statements as CSV with a header row. The columns,
+// including prop_type (resolved from the default model when missing), match
+// nomenklatura's statements.csv, and ReadStatementsCSV maps them by name, so
+// files interoperate in either column order.
 func WriteStatementsCSV(w io.Writer, st []Statement) error {
-    cw := csv.NewWriter(w)
-    header := []string{"id", "entity_id", "canonical_id", "prop", "prop_type", "schema", "value", "dataset", "lang", "original_value", "external", "first_seen", "last_seen", "origin"}
-    if err := cw.Write(header); err != nil {
-        return err
-    }
-    rec := make([]string, len(header))
-    for i := range st {
-        s := st[i]
-        s.Clean()
-        if s.ID == "" {
-            s.MakeKey()
-        }
-        if s.PropType == "" {
-            if t, err := PropTypeName(Default(), s.Schema, s.Prop); err == nil {
-                s.PropType = t
-            }
-        }
-        rec[0] = s.ID
-        rec[1] = s.EntityID
-        rec[2] = s.CanonicalID
-        rec[3] = s.Prop
-        rec[4] = s.PropType
-        rec[5] = s.Schema
-        rec[6] = s.Value
-        rec[7] = s.Dataset
-        rec[8] = s.Lang
-        rec[9] = s.Original
-        if s.External {
-            rec[10] = "true"
-        } else {
-            rec[10] = "false"
-        }
-        rec[11] = s.FirstSeen
-        rec[12] = s.LastSeen
-        rec[13] = s.Origin
-        if err := cw.Write(rec); err != nil {
-            return err
-        }
-    }
-    cw.Flush()
-    return cw.Error()
+	return WriteStatementsCSVWith(w, WriteOptions{}, st)
+}
+
+// WriteStatementsCSVWith writes statements as CSV, filling in missing IDs with
+// the recipe in opts.
+func WriteStatementsCSVWith(w io.Writer, opts WriteOptions, st []Statement) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(statementCSVHeader); err != nil {
+		return err
+	}
+	rec := make([]string, len(statementCSVHeader))
+	for i := range st {
+		if err := cw.Write(statementCSVRecord(st[i], opts, rec)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// statementCSVRecord fills rec with the columns of a normalized copy of s.
+func statementCSVRecord(s Statement, opts WriteOptions, rec []string) []string {
+	s.Clean()
+	if s.ID == "" {
+		s.MakeKeyWith(opts.KeyAlgorithm.orCurrent())
+	}
+	if s.PropType == "" {
+		if t, err := PropTypeName(Default(), s.Schema, s.Prop); err == nil {
+			s.PropType = t
+		}
+	}
+	rec[0] = s.ID
+	rec[1] = s.EntityID
+	rec[2] = s.CanonicalID
+	rec[3] = s.Prop
+	rec[4] = s.PropType
+	rec[5] = s.Schema
+	rec[6] = s.Value
+	rec[7] = s.Dataset
+	rec[8] = s.Lang
+	rec[9] = s.Original
+	if s.External {
+		rec[10] = "true"
+	} else {
+		rec[10] = "false"
+	}
+	rec[11] = s.FirstSeen
+	rec[12] = s.LastSeen
+	rec[13] = s.Origin
+	return rec
 }
 
 // ReadStatementsCSV reads statements from a CSV reader with the same header as WriteStatementsCSV
 // and calls fn for each parsed statement.
 func ReadStatementsCSV(r io.Reader, fn func(Statement) error) error {
-    cr := csv.NewReader(bufio.NewReader(r))
-    header, err := cr.Read()
-    if err != nil {
-        return err
-    }
-    idx := map[string]int{}
-    for i, h := range header {
-        idx[h] = i
-    }
-    get := func(rec []string, key string) string {
-        if p, ok := idx[key]; ok && p < len(rec) {
-            return rec[p]
-        }
-        return ""
-    }
-    for {
-        rec, err := cr.Read()
-        if err != nil {
-            if err == io.EOF {
-                return nil
-            }
-            return err
-        }
-        s := Statement{
-            ID:          get(rec, "id"),
-            EntityID:    get(rec, "entity_id"),
-            CanonicalID: get(rec, "canonical_id"),
-            Prop:        get(rec, "prop"),
-            PropType:    get(rec, "prop_type"),
-            Schema:      get(rec, "schema"),
-            Value:       get(rec, "value"),
-            Dataset:     get(rec, "dataset"),
-            Lang:        get(rec, "lang"),
-            Original:    get(rec, "original_value"),
-            FirstSeen:   get(rec, "first_seen"),
-            LastSeen:    get(rec, "last_seen"),
-            Origin:      get(rec, "origin"),
-        }
-        if p, ok := idx["external"]; ok && p < len(rec) {
-            b, _ := strconv.ParseBool(rec[p])
-            s.External = b
-        }
-        s.Clean()
-        if s.ID == "" {
-            s.MakeKey()
-        }
-        if s.PropType == "" {
-            if t, err := PropTypeName(Default(), s.Schema, s.Prop); err == nil {
-                s.PropType = t
-            }
-        }
-        if err := fn(s); err != nil {
-            return err
-        }
-    }
+	return ReadStatementsCSVWith(r, ReadOptions{}, fn)
+}
+
+// ReadStatementsCSVWith reads statements from CSV, which may be gzip or zstd
+// compressed. Malformed rows are reported as *RecordError (Record counts data
+// rows) and handled according to opts.
+func ReadStatementsCSVWith(r io.Reader, opts ReadOptions, fn func(Statement) error) error {
+	dr, err := Decompress(r)
+	if err != nil {
+		return err
+	}
+	defer dr.Close()
+	cr := csv.NewReader(dr)
+	header, err := cr.Read()
+	if err != nil {
+		return err
+	}
+	idx := map[string]int{}
+	for i, h := range header {
+		idx[h] = i
+	}
+	get := func(rec []string, key string) string {
+		if p, ok := idx[key]; ok && p < len(rec) {
+			return rec[p]
+		}
+		return ""
+	}
+	for n := 1; ; n++ {
+		rec, err := cr.Read()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			var pe *csv.ParseError
+			if !errors.As(err, &pe) {
+				return err
+			}
+			if rerr := opts.reject(&RecordError{Format: "csv", Record: n, Snippet: snippet([]byte(strings.Join(rec, ","))), Err: err}); rerr != nil {
+				return rerr
+			}
+			continue
+		}
+		s := Statement{
+			ID:          get(rec, "id"),
+			EntityID:    get(rec, "entity_id"),
+			CanonicalID: get(rec, "canonical_id"),
+			Prop:        get(rec, "prop"),
+			PropType:    get(rec, "prop_type"),
+			Schema:      get(rec, "schema"),
+			Value:       get(rec, "value"),
+			Dataset:     get(rec, "dataset"),
+			Lang:        get(rec, "lang"),
+			Original:    get(rec, "original_value"),
+			FirstSeen:   get(rec, "first_seen"),
+			LastSeen:    get(rec, "last_seen"),
+			Origin:      get(rec, "origin"),
+		}
+		if p, ok := idx["external"]; ok && p < len(rec) {
+			b, _ := strconv.ParseBool(rec[p])
+			s.External = b
+		}
+		s.Clean()
+		if s.ID == "" {
+			s.MakeKeyWith(opts.KeyAlgorithm.orCurrent())
+		}
+		if s.PropType == "" {
+			if t, err := PropTypeName(Default(), s.Schema, s.Prop); err == nil {
+				s.PropType = t
+			}
+		}
+		if err := fn(s); err != nil {
+			return err
+		}
+	}
 }
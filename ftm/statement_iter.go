@@ -0,0 +1,97 @@
+package ftm
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+)
+
+// errStopIteration is used internally to abort callback-style readers when a
+// range loop breaks early.
+var errStopIteration = errors.New("stop iteration")
+
+// iterStatements adapts a callback-style statement reader into an iterator.
+// A read error is yielded once as the final element.
+func iterStatements(read func(io.Reader, func(Statement) error) error, r io.Reader) iter.Seq2[Statement, error] {
+	return func(yield func(Statement, error) bool) {
+		err := read(r, func(s Statement) error {
+			if !yield(s, nil) {
+				return errStopIteration
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errStopIteration) {
+			yield(Statement{}, err)
+		}
+	}
+}
+
+// IterStatementsJSONL iterates over statements in a JSON lines stream.
+//
+//	for s, err := range ftm.IterStatementsJSONL(r) {
+//		if err != nil { ... }
+//	}
+func IterStatementsJSONL(r io.Reader) iter.Seq2[Statement, error] {
+	return iterStatements(ReadStatementsJSONL, r)
+}
+
+// IterStatementsCSV iterates over statements in a CSV stream.
+func IterStatementsCSV(r io.Reader) iter.Seq2[Statement, error] {
+	return iterStatements(ReadStatementsCSV, r)
+}
+
+// IterStatementsMsgpack iterates over statements in a MessagePack array stream.
+func IterStatementsMsgpack(r io.Reader) iter.Seq2[Statement, error] {
+	return iterStatements(ReadStatementsMsgpack, r)
+}
+
+// IterAggregate aggregates a statement sequence ordered by GroupKey into entities.
+// Errors from the input are passed through and end the iteration.
+func IterAggregate(m *Model, statements iter.Seq2[Statement, error]) iter.Seq2[*EntityProxy, error] {
+	return func(yield func(*EntityProxy, error) bool) {
+		agg := NewStatementAggregator(m)
+		for s, err := range statements {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if ent := agg.Add(s); ent != nil {
+				if !yield(ent, nil) {
+					return
+				}
+			}
+		}
+		for ent := agg.Flush(); ent != nil; ent = agg.Flush() {
+			if !yield(ent, nil) {
+				return
+			}
+		}
+	}
+}
+
+// IterEntitiesJSONL iterates over entities in a JSON lines stream, as produced by
+// EntityProxy.ToDict. Undecodable records are yielded as *RecordError and end the iteration.
+func IterEntitiesJSONL(m *Model, r io.Reader) iter.Seq2[*EntityProxy, error] {
+	return func(yield func(*EntityProxy, error) bool) {
+		var e *EntityProxy
+		decode := func(line []byte) error {
+			var data map[string]any
+			if err := json.Unmarshal(line, &data); err != nil {
+				return err
+			}
+			var err error
+			e, err = EntityProxyFromDict(m, data, "")
+			return err
+		}
+		err := readLines(r, "jsonl", ReadOptions{}, decode, func() error {
+			if !yield(e, nil) {
+				return errStopIteration
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errStopIteration) {
+			yield(nil, err)
+		}
+	}
+}
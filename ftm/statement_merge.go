@@ -0,0 +1,95 @@
+package ftm
+
+import (
+	"container/heap"
+	"fmt"
+	"iter"
+)
+
+// MergeSortedStatements merges statement streams that are each sorted by
+// GroupKey and ID (see SortStatements) into one stream in the same order, so
+// sorted exports of several datasets can be aggregated together without
+// sorting them again. Statements comparing equal keep the order of the
+// sources. A source out of order stops the merge with an error, as do read
+// errors.
+func MergeSortedStatements(sources ...iter.Seq2[Statement, error]) iter.Seq2[Statement, error] {
+	return func(yield func(Statement, error) bool) {
+		var h mergeHeap
+		for i, src := range sources {
+			next, stop := iter.Pull2(src)
+			defer stop()
+			ms := &mergeSource{next: next, index: i}
+			ok, err := ms.advance()
+			if err != nil {
+				yield(Statement{}, err)
+				return
+			}
+			if ok {
+				h = append(h, ms)
+			}
+		}
+		heap.Init(&h)
+		for h.Len() > 0 {
+			ms := h[0]
+			if !yield(ms.head, nil) {
+				return
+			}
+			ok, err := ms.advance()
+			if err != nil {
+				yield(Statement{}, err)
+				return
+			}
+			if ok {
+				heap.Fix(&h, 0)
+			} else {
+				heap.Pop(&h)
+			}
+		}
+	}
+}
+
+// mergeSource is one input of MergeSortedStatements with its current head.
+type mergeSource struct {
+	next  func() (Statement, error, bool)
+	index int
+	head  Statement
+	read  bool
+}
+
+// advance reads the next statement into head and reports whether there was one.
+func (ms *mergeSource) advance() (bool, error) {
+	s, err, ok := ms.next()
+	if !ok {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	s.Clean()
+	if s.ID == "" {
+		s.MakeKey()
+	}
+	if ms.read && compareStatements(ms.head, s) > 0 {
+		return false, fmt.Errorf("source %d statements out of order: %s/%s after %s/%s", ms.index+1, s.GroupKey(), s.ID, ms.head.GroupKey(), ms.head.ID)
+	}
+	ms.head, ms.read = s, true
+	return true, nil
+}
+
+type mergeHeap []*mergeSource
+
+func (h mergeHeap) Len() int { return len(h) }
+func (h mergeHeap) Less(i, j int) bool {
+	if c := compareStatements(h[i].head, h[j].head); c != 0 {
+		return c < 0
+	}
+	return h[i].index < h[j].index
+}
+func (h mergeHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *mergeHeap) Push(x any)   { *h = append(*h, x.(*mergeSource)) }
+func (h *mergeHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,331 @@
+package ftm
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The protobuf codec implements the messages of
+// proto/followthemoney/v1/followthemoney.proto with protowire, so no generated
+// code is needed. Streams are length-delimited: every message is preceded by
+// its size as a varint, as with protodelim.
+
+// Field numbers of the Statement message, in the order of the CSV columns.
+const (
+	pbStatementID protowire.Number = iota + 1
+	pbStatementEntityID
+	pbStatementCanonicalID
+	pbStatementProp
+	pbStatementPropType
+	pbStatementSchema
+	pbStatementValue
+	pbStatementDataset
+	pbStatementLang
+	pbStatementOriginal
+	pbStatementExternal
+	pbStatementFirstSeen
+	pbStatementLastSeen
+	pbStatementOrigin
+)
+
+// Field numbers of the Entity message.
+const (
+	pbEntityID protowire.Number = iota + 1
+	pbEntitySchema
+	pbEntityProperties
+	pbEntityContext
+)
+
+// maxProtoMessage bounds the size of a single message read from a stream.
+const maxProtoMessage = 64 << 20
+
+// WriteStatementsProto writes statements as length-delimited protobuf messages.
+func WriteStatementsProto(w io.Writer, st []Statement) error {
+	var buf []byte
+	for i := range st {
+		st[i].Clean()
+		if st[i].ID == "" {
+			st[i].MakeKey()
+		}
+		if st[i].PropType == "" {
+			if t, err := PropTypeName(Default(), st[i].Schema, st[i].Prop); err == nil {
+				st[i].PropType = t
+			}
+		}
+		buf = appendDelimited(buf[:0], appendStatementProto(nil, &st[i]))
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func appendStatementProto(b []byte, s *Statement) []byte {
+	b = appendProtoString(b, pbStatementID, s.ID)
+	b = appendProtoString(b, pbStatementEntityID, s.EntityID)
+	b = appendProtoString(b, pbStatementCanonicalID, s.CanonicalID)
+	b = appendProtoString(b, pbStatementProp, s.Prop)
+	b = appendProtoString(b, pbStatementPropType, s.PropType)
+	b = appendProtoString(b, pbStatementSchema, s.Schema)
+	b = appendProtoString(b, pbStatementValue, s.Value)
+	b = appendProtoString(b, pbStatementDataset, s.Dataset)
+	b = appendProtoString(b, pbStatementLang, s.Lang)
+	b = appendProtoString(b, pbStatementOriginal, s.Original)
+	if s.External {
+		b = protowire.AppendTag(b, pbStatementExternal, protowire.VarintType)
+		b = protowire.AppendVarint(b, 1)
+	}
+	b = appendProtoString(b, pbStatementFirstSeen, s.FirstSeen)
+	b = appendProtoString(b, pbStatementLastSeen, s.LastSeen)
+	b = appendProtoString(b, pbStatementOrigin, s.Origin)
+	return b
+}
+
+// appendProtoString appends a string field, leaving out empty values as proto3 does.
+func appendProtoString(b []byte, num protowire.Number, v string) []byte {
+	if v == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, v)
+}
+
+func appendDelimited(b, msg []byte) []byte {
+	b = protowire.AppendVarint(b, uint64(len(msg)))
+	return append(b, msg...)
+}
+
+// ReadStatementsProto reads length-delimited protobuf statements, which may be
+// gzip or zstd compressed. Undecodable messages are reported as *RecordError.
+func ReadStatementsProto(r io.Reader, fn func(Statement) error) error {
+	dr, err := Decompress(r)
+	if err != nil {
+		return err
+	}
+	defer dr.Close()
+	return readDelimited(dr, func(n int, msg []byte) error {
+		s, err := parseStatementProto(msg)
+		if err != nil {
+			return &RecordError{Format: "proto", Record: n, Err: err}
+		}
+		s.Clean()
+		if s.ID == "" {
+			s.MakeKey()
+		}
+		if s.PropType == "" {
+			if t, err := PropTypeName(Default(), s.Schema, s.Prop); err == nil {
+				s.PropType = t
+			}
+		}
+		return fn(s)
+	})
+}
+
+func parseStatementProto(b []byte) (Statement, error) {
+	var s Statement
+	fields := map[protowire.Number]*string{
+		pbStatementID: &s.ID, pbStatementEntityID: &s.EntityID, pbStatementCanonicalID: &s.CanonicalID,
+		pbStatementProp: &s.Prop, pbStatementPropType: &s.PropType, pbStatementSchema: &s.Schema,
+		pbStatementValue: &s.Value, pbStatementDataset: &s.Dataset, pbStatementLang: &s.Lang,
+		pbStatementOriginal: &s.Original, pbStatementFirstSeen: &s.FirstSeen,
+		pbStatementLastSeen: &s.LastSeen, pbStatementOrigin: &s.Origin,
+	}
+	err := parseProtoFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if dst, ok := fields[num]; ok && typ == protowire.BytesType {
+			v, n := protowire.ConsumeString(b)
+			*dst = v
+			return n, nil
+		}
+		if num == pbStatementExternal && typ == protowire.VarintType {
+			v, n := protowire.ConsumeVarint(b)
+			s.External = v != 0
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(num, typ, b), nil
+	})
+	return s, err
+}
+
+// parseProtoFields calls field for each field of a message; field consumes the
+// value and returns its length, or a negative protowire error code.
+func parseProtoFields(b []byte, field func(protowire.Number, protowire.Type, []byte) (int, error)) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		n, err := field(num, typ, b)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+// readDelimited calls fn with each length-delimited message of r and its
+// 1-based record number.
+func readDelimited(r io.Reader, fn func(n int, msg []byte) error) error {
+	br := bufio.NewReader(r)
+	var msg []byte
+	for n := 1; ; n++ {
+		size, err := binary.ReadUvarint(br)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return &RecordError{Format: "proto", Record: n, Err: err}
+		}
+		if size > maxProtoMessage {
+			return &RecordError{Format: "proto", Record: n, Err: fmt.Errorf("message of %d bytes exceeds limit", size)}
+		}
+		if uint64(cap(msg)) < size {
+			msg = make([]byte, size)
+		}
+		msg = msg[:size]
+		if _, err := io.ReadFull(br, msg); err != nil {
+			return &RecordError{Format: "proto", Record: n, Err: io.ErrUnexpectedEOF}
+		}
+		if err := fn(n, msg); err != nil {
+			return err
+		}
+	}
+}
+
+// WriteEntitiesProto writes entities as length-delimited protobuf messages.
+// Property values are sorted, as in ToDict; context fields are stored as JSON.
+func WriteEntitiesProto(w io.Writer, entities []*EntityProxy) error {
+	var buf []byte
+	for _, e := range entities {
+		msg, err := appendEntityProto(nil, e)
+		if err != nil {
+			return err
+		}
+		buf = appendDelimited(buf[:0], msg)
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func appendEntityProto(b []byte, e *EntityProxy) ([]byte, error) {
+	b = appendProtoString(b, pbEntityID, e.ID)
+	b = appendProtoString(b, pbEntitySchema, e.Schema.Name)
+	props := e.ToDict()["properties"].(map[string][]string)
+	for _, name := range sortedKeys(props) {
+		var values []byte
+		for _, v := range props[name] {
+			values = protowire.AppendTag(values, 1, protowire.BytesType)
+			values = protowire.AppendString(values, v)
+		}
+		// map entries are messages of key = 1 and value = 2
+		var entry []byte
+		entry = appendProtoString(entry, 1, name)
+		entry = protowire.AppendTag(entry, 2, protowire.BytesType)
+		entry = protowire.AppendBytes(entry, values)
+		b = protowire.AppendTag(b, pbEntityProperties, protowire.BytesType)
+		b = protowire.AppendBytes(b, entry)
+	}
+	if len(e.Context) > 0 {
+		ctx, err := json.Marshal(e.Context)
+		if err != nil {
+			return nil, fmt.Errorf("entity %s: encoding context: %w", e.ID, err)
+		}
+		b = protowire.AppendTag(b, pbEntityContext, protowire.BytesType)
+		b = protowire.AppendBytes(b, ctx)
+	}
+	return b, nil
+}
+
+// ReadEntitiesProto reads length-delimited protobuf entities of model m.
+// Messages that cannot be decoded or do not fit the model are reported as
+// *RecordError.
+func ReadEntitiesProto(m *Model, r io.Reader, fn func(*EntityProxy) error) error {
+	return readDelimited(r, func(n int, msg []byte) error {
+		data, err := parseEntityProto(msg)
+		if err == nil {
+			var e *EntityProxy
+			if e, err = EntityProxyFromDict(m, data, ""); err == nil {
+				return fn(e)
+			}
+		}
+		return &RecordError{Format: "proto", Record: n, Err: err}
+	})
+}
+
+// parseEntityProto decodes an Entity message into the ToDict shape.
+func parseEntityProto(b []byte) (map[string]any, error) {
+	data := map[string]any{}
+	props := map[string]any{}
+	err := parseProtoFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if typ != protowire.BytesType {
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return n, nil
+		}
+		switch num {
+		case pbEntityID:
+			data["id"] = string(v)
+		case pbEntitySchema:
+			data["schema"] = string(v)
+		case pbEntityProperties:
+			name, values, err := parseEntityPropertyProto(v)
+			if err != nil {
+				return 0, err
+			}
+			if prev, ok := props[name].([]any); ok {
+				values = append(prev, values...)
+			}
+			props[name] = values
+		case pbEntityContext:
+			ctx := map[string]any{}
+			if err := json.Unmarshal(v, &ctx); err != nil {
+				return 0, fmt.Errorf("decoding context: %w", err)
+			}
+			for k, cv := range ctx {
+				data[k] = cv
+			}
+		}
+		return n, nil
+	})
+	data["properties"] = props
+	return data, err
+}
+
+// parseEntityPropertyProto decodes a properties map entry.
+func parseEntityPropertyProto(b []byte) (string, []any, error) {
+	var name string
+	var values []any
+	err := parseProtoFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if typ != protowire.BytesType || (num != 1 && num != 2) {
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 || num == 1 {
+			name = string(v)
+			return n, nil
+		}
+		return n, parseProtoFields(v, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+			if num != 1 || typ != protowire.BytesType {
+				return protowire.ConsumeFieldValue(num, typ, b), nil
+			}
+			s, n := protowire.ConsumeString(b)
+			values = append(values, s)
+			return n, nil
+		})
+	})
+	return name, values, err
+}
@@ -0,0 +1,229 @@
+package ftm
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// SortOptions configures SortStatements.
+type SortOptions struct {
+	// Format of input and output: "jsonl" (default) or "csv".
+	Format string
+	// ChunkSize is the number of statements sorted in memory before they are
+	// spilled to a temporary file, 500000 by default.
+	ChunkSize int
+	// TempDir holds the spilled chunks, os.TempDir() by default.
+	TempDir string
+}
+
+// SortStatements orders the statements read from r by GroupKey, and by ID
+// within a group, and writes them to w, ready for AggregateSortedStatements or
+// IterAggregate. Inputs larger than opts.ChunkSize are sorted in chunks spilled
+// to temporary files, which are then merged, so memory use stays bounded.
+func SortStatements(r io.Reader, w io.Writer, opts SortOptions) error {
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = 500000
+	}
+	var read func(io.Reader, func(Statement) error) error
+	switch opts.Format {
+	case "", "jsonl":
+		read = ReadStatementsJSONL
+	case "csv":
+		read = ReadStatementsCSV
+	default:
+		return fmt.Errorf("unknown statement format: %s", opts.Format)
+	}
+
+	var chunks []*os.File
+	defer func() {
+		for _, f := range chunks {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+	chunk := make([]Statement, 0, min(opts.ChunkSize, 1024))
+	spill := func() error {
+		slices.SortFunc(chunk, compareStatements)
+		f, err := os.CreateTemp(opts.TempDir, "ftm-sort-*.msgpack")
+		if err != nil {
+			return err
+		}
+		chunks = append(chunks, f)
+		bw := bufio.NewWriter(f)
+		if err := WriteStatementsMsgpack(bw, chunk); err != nil {
+			return err
+		}
+		if err := bw.Flush(); err != nil {
+			return err
+		}
+		chunk = chunk[:0]
+		_, err = f.Seek(0, io.SeekStart)
+		return err
+	}
+	err := read(r, func(s Statement) error {
+		chunk = append(chunk, s)
+		if len(chunk) >= opts.ChunkSize {
+			return spill()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	out := newSortWriter(w, opts.Format)
+	if len(chunks) == 0 {
+		slices.SortFunc(chunk, compareStatements)
+		for _, s := range chunk {
+			if err := out.write(s); err != nil {
+				return err
+			}
+		}
+		return out.flush()
+	}
+	if len(chunk) > 0 {
+		if err := spill(); err != nil {
+			return err
+		}
+	}
+
+	h := make(chunkHeap, 0, len(chunks))
+	for _, f := range chunks {
+		c, err := openChunk(f)
+		if err != nil {
+			return err
+		}
+		if ok, err := c.next(); err != nil {
+			return err
+		} else if ok {
+			h = append(h, c)
+		}
+	}
+	heap.Init(&h)
+	for h.Len() > 0 {
+		c := h[0]
+		if err := out.write(c.head); err != nil {
+			return err
+		}
+		ok, err := c.next()
+		if err != nil {
+			return err
+		}
+		if ok {
+			heap.Fix(&h, 0)
+		} else {
+			heap.Pop(&h)
+		}
+	}
+	return out.flush()
+}
+
+func compareStatements(a, b Statement) int {
+	if c := strings.Compare(a.GroupKey(), b.GroupKey()); c != 0 {
+		return c
+	}
+	return strings.Compare(a.ID, b.ID)
+}
+
+// sortChunk reads one spilled chunk, as written by WriteStatementsMsgpack.
+type sortChunk struct {
+	dec  *msgpack.Decoder
+	left int
+	head Statement
+}
+
+func openChunk(f *os.File) (*sortChunk, error) {
+	dec := msgpack.NewDecoder(bufio.NewReader(f))
+	n, err := dec.DecodeArrayLen()
+	if err != nil {
+		return nil, fmt.Errorf("reading sort chunk: %w", err)
+	}
+	return &sortChunk{dec: dec, left: n}, nil
+}
+
+// next decodes the following statement into head, reporting false at the end.
+func (c *sortChunk) next() (bool, error) {
+	if c.left == 0 {
+		return false, nil
+	}
+	c.left--
+	c.head = Statement{}
+	if err := c.dec.Decode(&c.head); err != nil {
+		return false, fmt.Errorf("reading sort chunk: %w", err)
+	}
+	return true, nil
+}
+
+type chunkHeap []*sortChunk
+
+func (h chunkHeap) Len() int           { return len(h) }
+func (h chunkHeap) Less(i, j int) bool { return compareStatements(h[i].head, h[j].head) < 0 }
+func (h chunkHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *chunkHeap) Push(x any)        { *h = append(*h, x.(*sortChunk)) }
+func (h *chunkHeap) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
+
+// sortWriter writes statements one at a time in the format they were read in.
+type sortWriter struct {
+	bw  *bufio.Writer
+	enc *json.Encoder
+	cw  *csv.Writer
+	rec []string
+	// header is set once the CSV header is written
+	header bool
+}
+
+func newSortWriter(w io.Writer, format string) *sortWriter {
+	sw := &sortWriter{bw: bufio.NewWriter(w)}
+	if format == "csv" {
+		sw.cw = csv.NewWriter(sw.bw)
+		sw.rec = make([]string, len(statementCSVHeader))
+	} else {
+		sw.enc = json.NewEncoder(sw.bw)
+	}
+	return sw
+}
+
+func (sw *sortWriter) write(s Statement) error {
+	if sw.enc != nil {
+		return sw.enc.Encode(&s)
+	}
+	if err := sw.writeHeader(); err != nil {
+		return err
+	}
+	return sw.cw.Write(statementCSVRecord(s, WriteOptions{}, sw.rec))
+}
+
+func (sw *sortWriter) writeHeader() error {
+	if sw.header {
+		return nil
+	}
+	sw.header = true
+	return sw.cw.Write(statementCSVHeader)
+}
+
+func (sw *sortWriter) flush() error {
+	if sw.cw != nil {
+		if err := sw.writeHeader(); err != nil {
+			return err
+		}
+		sw.cw.Flush()
+		if err := sw.cw.Error(); err != nil {
+			return err
+		}
+	}
+	return sw.bw.Flush()
+}
@@ -0,0 +1,92 @@
+package ftm
+
+import "iter"
+
+// SeenCoverage is the date range covered by statements: the earliest
+// first_seen and the latest last_seen, and the number of statements without
+// first_seen.
+type SeenCoverage struct {
+	FirstSeen string `json:"first_seen,omitempty"`
+	LastSeen  string `json:"last_seen,omitempty"`
+	Undated   int    `json:"undated"`
+}
+
+func (c *SeenCoverage) add(s Statement) {
+	if s.FirstSeen == "" {
+		c.Undated++
+	} else if c.FirstSeen == "" || s.FirstSeen < c.FirstSeen {
+		c.FirstSeen = s.FirstSeen
+	}
+	if last := ifEmpty(s.LastSeen, s.FirstSeen); last > c.LastSeen {
+		c.LastSeen = last
+	}
+}
+
+// StatementStats counts the statements of a stream by dataset, schema,
+// property (as schema:prop) and property type, along with the number of
+// distinct entities (by canonical ID) and the dates covered, overall and per
+// dataset. It marshals to JSON as a report. Distinct entities are tracked in
+// memory.
+type StatementStats struct {
+	Statements      int                     `json:"statements"`
+	Entities        int                     `json:"entities"`
+	Datasets        map[string]int          `json:"datasets"`
+	Schemata        map[string]int          `json:"schemata"`
+	Props           map[string]int          `json:"props"`
+	PropTypes       map[string]int          `json:"prop_types"`
+	Coverage        SeenCoverage            `json:"coverage"`
+	DatasetCoverage map[string]SeenCoverage `json:"dataset_coverage"`
+
+	m        *Model
+	entities map[string]struct{}
+}
+
+// NewStatementStats creates an empty collector. The model, if not nil, fills
+// in the property type of statements without one.
+func NewStatementStats(m *Model) *StatementStats {
+	return &StatementStats{
+		Datasets:        map[string]int{},
+		Schemata:        map[string]int{},
+		Props:           map[string]int{},
+		PropTypes:       map[string]int{},
+		DatasetCoverage: map[string]SeenCoverage{},
+		m:               m,
+		entities:        map[string]struct{}{},
+	}
+}
+
+// Add counts a statement.
+func (st *StatementStats) Add(s Statement) {
+	st.Statements++
+	st.Datasets[s.Dataset]++
+	st.Schemata[s.Schema]++
+	st.Props[s.Schema+":"+s.Prop]++
+	propType := s.PropType
+	if propType == "" && st.m != nil {
+		propType = statementPropType(st.m, s)
+	}
+	if propType != "" {
+		st.PropTypes[propType]++
+	}
+	if _, ok := st.entities[s.GroupKey()]; !ok {
+		st.entities[s.GroupKey()] = struct{}{}
+		st.Entities++
+	}
+	st.Coverage.add(s)
+	cov := st.DatasetCoverage[s.Dataset]
+	cov.add(s)
+	st.DatasetCoverage[s.Dataset] = cov
+}
+
+// CollectStatementStats counts all statements of a stream, stopping at the
+// first read error.
+func CollectStatementStats(m *Model, src iter.Seq2[Statement, error]) (*StatementStats, error) {
+	st := NewStatementStats(m)
+	for s, err := range src {
+		if err != nil {
+			return st, err
+		}
+		st.Add(s)
+	}
+	return st, nil
+}
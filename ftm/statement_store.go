@@ -0,0 +1,181 @@
+package ftm
+
+import (
+	"iter"
+	"sort"
+	"sync"
+)
+
+// StatementStore persists statements and builds entities from them, in the
+// manner of nomenklatura's store. Statements are grouped by canonical ID (see
+// Statement.GroupKey) and identified by their key (Statement.MakeKey), so
+// adding the same statement twice keeps one copy.
+type StatementStore interface {
+	// Add stores statements, replacing stored statements with the same key.
+	Add(statements ...Statement) error
+	// Statements iterates over all statements, ordered by canonical ID, so the
+	// result can be passed to IterAggregate.
+	Statements() iter.Seq2[Statement, error]
+	// Canonical iterates over the statements of one canonical ID.
+	Canonical(id string) iter.Seq2[Statement, error]
+	// Dataset iterates over the statements of one dataset, ordered by canonical ID.
+	Dataset(name string) iter.Seq2[Statement, error]
+	// DeleteDataset removes all statements of a dataset.
+	DeleteDataset(name string) error
+	// Entity aggregates the statements of a canonical ID into an entity. It
+	// returns ErrEntityNotFound if there are none.
+	Entity(id string) (*EntityProxy, error)
+}
+
+// StoreEntities aggregates all statements of a store into entities.
+func StoreEntities(m *Model, store StatementStore) iter.Seq2[*EntityProxy, error] {
+	return IterAggregate(m, store.Statements())
+}
+
+// MemoryStatementStore is the reference StatementStore, holding statements in
+// memory. It is safe for concurrent use; iterators work on a snapshot taken
+// when iteration starts.
+type MemoryStatementStore struct {
+	m  *Model
+	mu sync.RWMutex
+	// groups holds statements by canonical ID, in order of insertion, and
+	// keys maps statement keys to their canonical ID.
+	groups map[string][]Statement
+	keys   map[string]string
+}
+
+// NewMemoryStatementStore creates an empty store building entities with m.
+func NewMemoryStatementStore(m *Model) *MemoryStatementStore {
+	return &MemoryStatementStore{m: m, groups: map[string][]Statement{}, keys: map[string]string{}}
+}
+
+// Add implements StatementStore. Statements without a key (no property or
+// value) are ignored.
+func (s *MemoryStatementStore) Add(statements ...Statement) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, st := range statements {
+		key := st.ID
+		if key == "" {
+			key = st.MakeKey()
+		}
+		if key == "" {
+			continue
+		}
+		if prev, ok := s.keys[key]; ok {
+			s.removeKey(prev, key)
+		}
+		gk := st.GroupKey()
+		s.groups[gk] = append(s.groups[gk], st)
+		s.keys[key] = gk
+	}
+	return nil
+}
+
+func (s *MemoryStatementStore) removeKey(gk, key string) {
+	group := s.groups[gk]
+	for i, st := range group {
+		if st.ID == key {
+			group = append(group[:i:i], group[i+1:]...)
+			break
+		}
+	}
+	if len(group) == 0 {
+		delete(s.groups, gk)
+	} else {
+		s.groups[gk] = group
+	}
+	delete(s.keys, key)
+}
+
+// Statements implements StatementStore.
+func (s *MemoryStatementStore) Statements() iter.Seq2[Statement, error] {
+	return s.snapshot(func(Statement) bool { return true })
+}
+
+// Canonical implements StatementStore.
+func (s *MemoryStatementStore) Canonical(id string) iter.Seq2[Statement, error] {
+	s.mu.RLock()
+	group := append([]Statement(nil), s.groups[id]...)
+	s.mu.RUnlock()
+	return func(yield func(Statement, error) bool) {
+		for _, st := range group {
+			if !yield(st, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Dataset implements StatementStore.
+func (s *MemoryStatementStore) Dataset(name string) iter.Seq2[Statement, error] {
+	return s.snapshot(func(st Statement) bool { return st.Dataset == name })
+}
+
+// snapshot copies the matching statements, ordered by canonical ID.
+func (s *MemoryStatementStore) snapshot(keep func(Statement) bool) iter.Seq2[Statement, error] {
+	return func(yield func(Statement, error) bool) {
+		s.mu.RLock()
+		ids := make([]string, 0, len(s.groups))
+		for id := range s.groups {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		var out []Statement
+		for _, id := range ids {
+			for _, st := range s.groups[id] {
+				if keep(st) {
+					out = append(out, st)
+				}
+			}
+		}
+		s.mu.RUnlock()
+		for _, st := range out {
+			if !yield(st, nil) {
+				return
+			}
+		}
+	}
+}
+
+// DeleteDataset implements StatementStore.
+func (s *MemoryStatementStore) DeleteDataset(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for gk, group := range s.groups {
+		kept := group[:0]
+		for _, st := range group {
+			if st.Dataset == name {
+				delete(s.keys, st.ID)
+				continue
+			}
+			kept = append(kept, st)
+		}
+		if len(kept) == 0 {
+			delete(s.groups, gk)
+		} else {
+			s.groups[gk] = kept
+		}
+	}
+	return nil
+}
+
+// Entity implements StatementStore.
+func (s *MemoryStatementStore) Entity(id string) (*EntityProxy, error) {
+	agg := NewStatementAggregator(s.m)
+	for st := range s.Canonical(id) {
+		agg.Add(st)
+	}
+	e := agg.Flush()
+	if e == nil {
+		return nil, ErrEntityNotFound
+	}
+	return e, nil
+}
+
+// Len returns the number of stored statements.
+func (s *MemoryStatementStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.keys)
+}
@@ -2,6 +2,13 @@ package ftm
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
 	"sort"
 	"strings"
 	"testing"
@@ -82,6 +89,38 @@ func TestAggregateCanonicalIDCollapse(t *testing.T) {
 	}
 }
 
+func TestAggregateSortedStatementsWith(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	st := []Statement{
+		{EntityID: "a", Prop: "name", Schema: "Person", Value: "Ana Lima", Dataset: "ds1"},
+		{EntityID: "a", Prop: "name", Schema: "Person", Value: "Ana  Lima ", Dataset: "ds2"},
+		{EntityID: "a", Prop: "name", Schema: "Person", Value: "AnaLima", Dataset: "ds2"},
+		{EntityID: "a", Prop: "idNumber", Schema: "Person", Value: "AB-123", Dataset: "ds1"},
+		{EntityID: "a", Prop: "idNumber", Schema: "Person", Value: "ab 123", Dataset: "ds2"},
+	}
+	plain := AggregateSortedStatements(m, append([]Statement(nil), st...))
+	if len(plain) != 1 || len(plain[0].Get("idNumber")) != 2 {
+		t.Fatalf("plain aggregation should keep all identifiers: %v", plain[0].ToDict())
+	}
+	es := AggregateSortedStatementsWith(m, append([]Statement(nil), st...), AggregateOptions{Dedupe: true})
+	if len(es) != 1 {
+		t.Fatalf("expected 1 entity, got %d", len(es))
+	}
+	// Whitespace runs collapse to one space; words are not joined.
+	if got := es[0].Get("name"); len(got) != 2 || got[0] != "Ana Lima" || got[1] != "AnaLima" {
+		t.Fatalf("names: %q", got)
+	}
+	if got := es[0].Get("idNumber"); len(got) != 1 || got[0] != "AB-123" {
+		t.Fatalf("identifiers: %q", got)
+	}
+	if ds := contextStrings(es[0].Context["datasets"]); len(ds) != 2 {
+		t.Fatalf("provenance: %v", es[0].Context)
+	}
+}
+
 func TestStatementsCSVAndMsgpackRoundTrip(t *testing.T) {
 	m, err := NewModel("../schema")
 	if err != nil {
@@ -134,6 +173,30 @@ func TestStatementsCSVAndMsgpackRoundTrip(t *testing.T) {
 	}
 }
 
+func TestReadStatementsCSVNomenklaturaLayout(t *testing.T) {
+	// column order and boolean encoding as written by nomenklatura
+	data := "canonical_id,entity_id,prop,prop_type,schema,value,dataset,lang,original_value,external,first_seen,last_seen,id\n" +
+		"c1,p1,name,name,Person,Ana,ds,,,f,2024-01-01T00:00:00,2024-02-01T00:00:00,s1\n" +
+		"c1,p1,nationality,,Person,br,ds,,,t,,,s2\n"
+	var got []Statement
+	if err := ReadStatementsCSV(strings.NewReader(data), func(s Statement) error { got = append(got, s); return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].ID != "s1" || got[0].CanonicalID != "c1" || got[0].External || !got[1].External {
+		t.Fatalf("unexpected statements %#v", got)
+	}
+	if got[0].PropType != "name" || got[1].PropType != "country" {
+		t.Fatalf("unexpected prop_type %q, %q", got[0].PropType, got[1].PropType)
+	}
+	var buf bytes.Buffer
+	if err := WriteStatementsCSV(&buf, got); err != nil {
+		t.Fatal(err)
+	}
+	if header, _, _ := strings.Cut(buf.String(), "\n"); !strings.Contains(header, ",prop_type,") {
+		t.Fatalf("missing prop_type column: %s", header)
+	}
+}
+
 // BaseID semantics are tested in statement_entity_test.go
 
 func TestStatementAggregatorStream(t *testing.T) {
@@ -164,3 +227,951 @@ func TestStatementAggregatorStream(t *testing.T) {
 		t.Fatalf("expected 2 entities, got %d", len(out))
 	}
 }
+
+func TestStatementAggregatorDedupe(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	st := []Statement{
+		{EntityID: "a", Prop: "idNumber", Schema: "Person", Value: "AB-123", Dataset: "ds"},
+		{EntityID: "a", Prop: "idNumber", Schema: "Person", Value: "ab123", Dataset: "ds"},
+		{EntityID: "a", Prop: "name", Schema: "Person", Value: "Ana Maria", Dataset: "ds"},
+		{EntityID: "a", Prop: "name", Schema: "Person", Value: "AnaMaria", Dataset: "ds"},
+	}
+	agg := NewStatementAggregator(m)
+	agg.Dedupe = true
+	for _, s := range st {
+		agg.Add(s)
+	}
+	ent := agg.Flush()
+	// Names differing in more than spacing are distinct.
+	if agg.Collapsed != 1 {
+		t.Fatalf("expected 1 collapsed value, got %d", agg.Collapsed)
+	}
+	if ent.First("idNumber") != "AB-123" || len(ent.Get("name")) != 2 {
+		t.Fatalf("unexpected values after dedupe: %v", ent.ToDict())
+	}
+}
+
+func TestIterStatementsAndAggregate(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	e := NewEntityProxy(m.Get("Person"), "p1")
+	_ = e.Add("name", []string{"Ana"}, false)
+	buf := bytes.Buffer{}
+	if err := WriteStatementsJSONL(&buf, StatementsFromEntity(e, "ds", "2025-01-01", "", false, "")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	buf.WriteString("{broken\n")
+	var ents []*EntityProxy
+	var iterErr error
+	for ent, err := range IterAggregate(m, IterStatementsJSONL(&buf)) {
+		if err != nil {
+			iterErr = err
+			break
+		}
+		ents = append(ents, ent)
+	}
+	if iterErr == nil {
+		t.Fatalf("expected decode error to propagate")
+	}
+	if len(ents) != 0 {
+		t.Fatalf("entity should not be emitted before the stream fails, got %d", len(ents))
+	}
+}
+
+func TestReadStatementsJSONLRejects(t *testing.T) {
+	in := `{"entity_id":"a","prop":"name","schema":"Person","value":"Ana","dataset":"ds"}
+{"entity_id": broken}
+{"entity_id":"b","prop":"name","schema":"Person","value":"Bob","dataset":"ds"}
+`
+	var rejected []*RecordError
+	n := 0
+	err := ReadStatementsJSONLWith(strings.NewReader(in), ReadOptions{OnReject: func(re *RecordError) error {
+		rejected = append(rejected, re)
+		return nil
+	}}, func(Statement) error { n++; return nil })
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if n != 2 || len(rejected) != 1 || rejected[0].Record != 2 || !strings.Contains(rejected[0].Snippet, "broken") {
+		t.Fatalf("unexpected result: n=%d rejected=%v", n, rejected)
+	}
+
+	err = ReadStatementsJSONL(strings.NewReader(in), func(Statement) error { return nil })
+	var re *RecordError
+	if !errors.As(err, &re) || re.Record != 2 {
+		t.Fatalf("expected RecordError at record 2, got %v", err)
+	}
+}
+
+func TestStatementSamplerStableAndDrift(t *testing.T) {
+	var stmts []Statement
+	for i := 0; i < 200; i++ {
+		stmts = append(stmts, Statement{Schema: "Person", Prop: "name", Value: strings.Repeat("x", i%50+1), Dataset: "d"})
+	}
+	forward, backward := NewStatementSampler("qa", 10), NewStatementSampler("qa", 10)
+	for i := range stmts {
+		forward.Add(stmts[i])
+		backward.Add(stmts[len(stmts)-1-i])
+	}
+	a, b := forward.Snapshot(), backward.Snapshot()
+	if len(a.Sample) != 10 {
+		t.Fatalf("expected 10 samples, got %d", len(a.Sample))
+	}
+	for i := range a.Sample {
+		if a.Sample[i] != b.Sample[i] {
+			t.Fatalf("sample depends on input order at %d: %v vs %v", i, a.Sample[i], b.Sample[i])
+		}
+	}
+
+	backward.Add(Statement{Schema: "Person", Prop: "birthDate", Value: "1970", Dataset: "d"})
+	drifts, overlap := CompareSnapshots(a, backward.Snapshot(), 0.01)
+	if len(drifts) != 1 || drifts[0].Key != "Person:birthDate" {
+		t.Fatalf("expected drift for new property, got %v", drifts)
+	}
+	if overlap < 0.9 {
+		t.Fatalf("expected stable sample overlap, got %f", overlap)
+	}
+}
+
+func TestTeeStatements(t *testing.T) {
+	var stmts []Statement
+	for i := 0; i < 100; i++ {
+		stmts = append(stmts, Statement{EntityID: "e" + strings.Repeat("x", i%3), Schema: "Person", Prop: "name", Value: "Ana", Dataset: "d"})
+	}
+	src := func(yield func(Statement, error) bool) {
+		for _, s := range stmts {
+			if !yield(s, nil) {
+				return
+			}
+		}
+	}
+	var buf bytes.Buffer
+	count := 0
+	err := TeeStatements(src, 4, JSONLSink(&buf), func(seq iter.Seq[Statement]) error {
+		for range seq {
+			count++
+		}
+		return nil
+	}, func(seq iter.Seq[Statement]) error {
+		for range seq {
+			break // stops early without error
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("TeeStatements: %v", err)
+	}
+	if count != len(stmts) || strings.Count(buf.String(), "\n") != len(stmts) {
+		t.Fatalf("expected every sink to see %d statements, got %d", len(stmts), count)
+	}
+
+	boom := errors.New("boom")
+	err = TeeStatements(src, 1, func(seq iter.Seq[Statement]) error {
+		for range seq {
+			return boom
+		}
+		return nil
+	}, func(seq iter.Seq[Statement]) error {
+		for range seq {
+		}
+		return nil
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected sink error to propagate, got %v", err)
+	}
+}
+
+func TestStatementAggregatorSchemaPolicy(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	st := []Statement{
+		{EntityID: "a", Prop: "name", Schema: "LegalEntity", Value: "ACME", Dataset: "ds"},
+		{EntityID: "a", Prop: "nationality", Schema: "Person", Value: "de", Dataset: "ds"},
+		{EntityID: "a", Prop: "capital", Schema: "Company", Value: "100", Dataset: "ds"},
+		{EntityID: "b", Prop: "name", Schema: "Person", Value: "Bob", Dataset: "ds"},
+	}
+	run := func(policy SchemaPolicy) ([]*EntityProxy, *StatementAggregator) {
+		agg := NewStatementAggregator(m)
+		agg.SchemaPolicy = policy
+		var out []*EntityProxy
+		for _, s := range st {
+			if ent := agg.Add(s); ent != nil {
+				out = append(out, ent)
+			}
+		}
+		for ent := agg.Flush(); ent != nil; ent = agg.Flush() {
+			out = append(out, ent)
+		}
+		return out, agg
+	}
+
+	out, agg := run(SchemaKeepFirst)
+	if len(out) != 2 || out[0].Schema.Name != "LegalEntity" || len(agg.Conflicts) != 1 {
+		t.Fatalf("keep first: %d entities, %v", len(out), agg.Conflicts)
+	}
+	if got := agg.Conflicts[0].Dropped; len(got) != 2 || got[0] != "Person:nationality" || got[1] != "Company:capital" {
+		t.Fatalf("keep first: unexpected dropped %v", got)
+	}
+	out, agg = run(SchemaCoerce)
+	if len(out) != 2 || out[0].Schema.Name != "LegalEntity" || out[0].First("name") != "ACME" {
+		t.Fatalf("coerce: unexpected %v", out[0].ToDict())
+	}
+	if got := agg.Conflicts[0].Dropped; len(got) != 2 || got[0] != "Person:nationality" {
+		t.Fatalf("coerce: unexpected dropped %v", got)
+	}
+
+	// A schema resolver makes Person and Company mergeable, but aggregation
+	// still applies its own policy to them.
+	m.SetSchemaResolver(ResolveCommonAncestor)
+	out, agg = run(SchemaSplit)
+	m.SetSchemaResolver(nil)
+	if len(out) != 3 || len(agg.Conflicts) != 1 {
+		t.Fatalf("split with resolver: %d entities, %v", len(out), agg.Conflicts)
+	}
+	out, agg = run(SchemaSplit)
+	if len(out) != 3 || out[0].Schema.Name != "Person" || out[1].Schema.Name != "Company" || out[1].ID == "a" {
+		t.Fatalf("split: unexpected %d entities", len(out))
+	}
+	if got := agg.Conflicts[0].Schemata; len(got) != 3 || got[2] != "Company" {
+		t.Fatalf("split: unexpected conflict %v", got)
+	}
+	out, agg = run(SchemaReject)
+	if len(out) != 1 || out[0].ID != "b" || agg.Conflicts[0].EntityID != "a" {
+		t.Fatalf("reject: unexpected %d entities", len(out))
+	}
+}
+
+func TestMemoryStatementStore(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	a := NewEntityProxy(m.Get("Person"), "p1")
+	_ = a.Add("name", []string{"Ana Lima"}, false)
+	b := NewEntityProxy(m.Get("Person"), "p1")
+	_ = b.Add("nationality", []string{"br"}, false)
+	c := NewEntityProxy(m.Get("Company"), "c1")
+	_ = c.Add("name", []string{"Acme"}, false)
+
+	var store StatementStore = NewMemoryStatementStore(m)
+	_ = store.Add(StatementsFromEntity(a, "ds1", "", "", false, "")...)
+	_ = store.Add(StatementsFromEntity(b, "ds2", "", "", false, "")...)
+	_ = store.Add(StatementsFromEntity(c, "ds1", "", "", false, "")...)
+	_ = store.Add(StatementsFromEntity(c, "ds1", "", "", false, "")...) // duplicate
+	if n := store.(*MemoryStatementStore).Len(); n != 6 {
+		t.Fatalf("expected 6 statements, got %d", n)
+	}
+
+	e, err := store.Entity("p1")
+	if err != nil || e.First("name") != "Ana Lima" || e.First("nationality") != "br" {
+		t.Fatalf("unexpected entity %v, %v", e, err)
+	}
+	var ids []string
+	for e, err := range StoreEntities(m, store) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, e.ID)
+	}
+	if strings.Join(ids, ",") != "c1,p1" {
+		t.Fatalf("unexpected entity order %v", ids)
+	}
+
+	if err := store.DeleteDataset("ds1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Entity("c1"); err != ErrEntityNotFound {
+		t.Fatalf("expected c1 to be deleted, got %v", err)
+	}
+	e, _ = store.Entity("p1")
+	if e.Has("name") || e.First("nationality") != "br" {
+		t.Fatalf("expected only ds2 values, got %v", e.ToDict())
+	}
+	n := 0
+	for st := range store.Dataset("ds2") {
+		if st.Dataset != "ds2" {
+			t.Fatalf("unexpected dataset %s", st.Dataset)
+		}
+		n++
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 ds2 statements, got %d", n)
+	}
+}
+
+func TestSortStatements(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	var st []Statement
+	for _, id := range []string{"p3", "p1", "p2", "p1", "p3", "p2", "p1"} {
+		e := NewEntityProxy(m.Get("Person"), id)
+		_ = e.Add("name", []string{"Name " + id + " " + string(rune('a'+len(st)))}, false)
+		st = append(st, StatementsFromEntity(e, "ds", "", "", false, "")...)
+	}
+	for _, format := range []string{"jsonl", "csv"} {
+		var in, out bytes.Buffer
+		if format == "csv" {
+			_ = WriteStatementsCSV(&in, st)
+		} else {
+			_ = WriteStatementsJSONL(&in, st)
+		}
+		// a chunk size of 3 spills several chunks to disk
+		if err := SortStatements(&in, &out, SortOptions{Format: format, ChunkSize: 3, TempDir: t.TempDir()}); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		read := IterStatementsJSONL
+		if format == "csv" {
+			read = IterStatementsCSV
+		}
+		var back []Statement
+		for s, err := range read(&out) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			back = append(back, s)
+		}
+		if len(back) != len(st) {
+			t.Fatalf("%s: expected %d statements, got %d", format, len(st), len(back))
+		}
+		if !sort.SliceIsSorted(back, func(i, j int) bool { return compareStatements(back[i], back[j]) < 0 }) {
+			t.Fatalf("%s: statements not sorted", format)
+		}
+		es := AggregateSortedStatements(m, back)
+		if len(es) != 3 || len(es[0].Get("name")) != 3 {
+			t.Fatalf("%s: unexpected aggregation %v", format, es)
+		}
+	}
+}
+
+func TestProtoCodec(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	e := NewEntityProxy(m.Get("Person"), "p1")
+	_ = e.Add("name", []string{"Ana Lima", "Ana"}, false)
+	_ = e.Add("nationality", []string{"br"}, false)
+	e.Context["datasets"] = []any{"ds"}
+
+	st := StatementsFromEntity(e, "ds", "2024-01-01", "", true, "")
+	st[1].Lang = "por"
+	var buf bytes.Buffer
+	if err := WriteStatementsProto(&buf, st); err != nil {
+		t.Fatal(err)
+	}
+	var back []Statement
+	if err := ReadStatementsProto(&buf, func(s Statement) error {
+		back = append(back, s)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(back) != len(st) {
+		t.Fatalf("expected %d statements, got %d", len(st), len(back))
+	}
+	for i := range st {
+		if back[i] != st[i] {
+			t.Fatalf("statement %d: got %+v, want %+v", i, back[i], st[i])
+		}
+	}
+
+	buf.Reset()
+	if err := WriteEntitiesProto(&buf, []*EntityProxy{e}); err != nil {
+		t.Fatal(err)
+	}
+	var got *EntityProxy
+	if err := ReadEntitiesProto(m, &buf, func(e *EntityProxy) error {
+		got = e
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := json.Marshal(e)
+	have, _ := json.Marshal(got)
+	if string(want) != string(have) {
+		t.Fatalf("entity round trip: got %s, want %s", have, want)
+	}
+
+	// a truncated stream is reported as a record error
+	buf.Reset()
+	_ = WriteStatementsProto(&buf, st[:1])
+	var rerr *RecordError
+	err = ReadStatementsProto(bytes.NewReader(buf.Bytes()[:buf.Len()-2]), func(Statement) error { return nil })
+	if !errors.As(err, &rerr) || rerr.Record != 1 {
+		t.Fatalf("expected record error, got %v", err)
+	}
+}
+
+func TestCompressedStatements(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	e := NewEntityProxy(m.Get("Person"), "p1")
+	_ = e.Add("name", []string{"Ana Lima"}, false)
+	st := StatementsFromEntity(e, "ds", "", "", false, "")
+
+	for _, c := range []Compression{CompressionGzip, CompressionZstd} {
+		var buf bytes.Buffer
+		w, err := Compress(&buf, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = WriteStatementsJSONL(w, st)
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Contains(buf.Bytes(), []byte("Ana Lima")) {
+			t.Fatalf("%s: output is not compressed", c)
+		}
+		n := 0
+		if err := ReadStatementsJSONL(&buf, func(Statement) error { n++; return nil }); err != nil {
+			t.Fatalf("%s: %v", c, err)
+		}
+		if n != len(st) {
+			t.Fatalf("%s: expected %d statements, got %d", c, len(st), n)
+		}
+	}
+
+	path := t.TempDir() + "/statements.csv.zst"
+	w, err := CreateStream(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = WriteStatementsCSV(w, st)
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r, err := OpenStream(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	n := 0
+	for _, err := range IterStatementsCSV(r) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		n++
+	}
+	if n != len(st) {
+		t.Fatalf("expected %d statements from %s, got %d", len(st), path, n)
+	}
+}
+
+func TestDiffStatements(t *testing.T) {
+	mk := func(entity, prop, value, lastSeen string) Statement {
+		s := Statement{EntityID: entity, Prop: prop, Schema: "Person", Value: value, Dataset: "ds", LastSeen: lastSeen}
+		s.MakeKey()
+		return s
+	}
+	sorted := func(st ...Statement) iter.Seq2[Statement, error] {
+		sort.Slice(st, func(i, j int) bool { return compareStatements(st[i], st[j]) < 0 })
+		return func(yield func(Statement, error) bool) {
+			for _, s := range st {
+				if !yield(s, nil) {
+					return
+				}
+			}
+		}
+	}
+	old := func() iter.Seq2[Statement, error] {
+		return sorted(mk("a", "name", "Ana", "2024"), mk("a", "nationality", "br", "2024"),
+			mk("b", "name", "Bob", "2024"), mk("c", "name", "Cid", "2024"))
+	}
+	cur := func() iter.Seq2[Statement, error] {
+		return sorted(mk("a", "name", "Ana", "2025"), mk("a", "nationality", "pt", "2025"),
+			mk("c", "name", "Cid", "2025"), mk("d", "name", "Dee", "2025"))
+	}
+
+	var ops []string
+	for ch, err := range DiffStatements(old(), cur()) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ops = append(ops, string(ch.Op)+" "+ch.Statement.EntityID+" "+ch.Statement.Value)
+	}
+	sort.Strings(ops)
+	if got := strings.Join(ops, ","); got != "ADD a pt,ADD d Dee,DEL a br,DEL b Bob" {
+		t.Fatalf("unexpected statement changes: %s", got)
+	}
+
+	var ents []string
+	for ch, err := range DiffEntities(old(), cur()) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ents = append(ents, string(ch.Op)+" "+ch.ID)
+	}
+	if got := strings.Join(ents, ","); got != "MOD a,DEL b,ADD d" {
+		t.Fatalf("unexpected entity changes: %s", got)
+	}
+
+	unsorted := func(yield func(Statement, error) bool) {
+		_ = yield(mk("b", "name", "Bob", ""), nil) && yield(mk("a", "name", "Ana", ""), nil)
+	}
+	var lastErr error
+	for _, err := range DiffStatements(old(), unsorted) {
+		lastErr = err
+	}
+	if lastErr == nil || !strings.Contains(lastErr.Error(), "out of order") {
+		t.Fatalf("expected order error, got %v", lastErr)
+	}
+}
+
+func TestResolver(t *testing.T) {
+	data := `["NK-abc", "q1", "positive", null, "user", "2024-01-01T00:00:00"]
+["q2", "q1", "positive", null, "user", "2024-01-01T00:00:00"]
+["q3", "q1", "positive", null, "user", "2024-01-01T00:00:00"]
+["q3", "q1", "negative", null, "user", "2024-01-02T00:00:00"]
+["q4", "q1", "unsure", null, "user", "2024-01-01T00:00:00"]
+{"x1": "x2"}
+`
+	r, err := LoadResolver(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := r.Canonical("q2"); c != "NK-abc" {
+		t.Fatalf("expected NK-abc, got %s", c)
+	}
+	if got := strings.Join(r.Connected("q1"), ","); got != "NK-abc,q1,q2" {
+		t.Fatalf("unexpected cluster %s", got)
+	}
+	if c := r.Canonical("q3"); c != "q3" {
+		t.Fatalf("expected reverted merge, got %s", c)
+	}
+	if c := r.Canonical("x1"); c != "x2" {
+		t.Fatalf("expected linker mapping, got %s", c)
+	}
+	if j := r.Judgement("q1", "q4"); j != JudgementUnsure {
+		t.Fatalf("unexpected judgement %s", j)
+	}
+
+	st := []Statement{{EntityID: "q2", Prop: "name", Schema: "Person", Value: "A", Dataset: "ds", CanonicalID: "stale"}}
+	for s, err := range r.ApplyStatements(sliceStatements(st)) {
+		if err != nil || s.CanonicalID != "NK-abc" {
+			t.Fatalf("unexpected statement %+v, %v", s, err)
+		}
+	}
+
+	if _, err := LoadResolver(strings.NewReader(`["a", "b", "maybe"]`)); err == nil {
+		t.Fatal("expected an error for an unknown judgement")
+	}
+}
+
+func sliceStatements(st []Statement) iter.Seq2[Statement, error] {
+	return func(yield func(Statement, error) bool) {
+		for _, s := range st {
+			if !yield(s, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestStatementFilters(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	st := []Statement{
+		{EntityID: "p1", Prop: "name", Schema: "Person", Value: "Ana", Dataset: "a", FirstSeen: "2023-05-01T00:00:00", Origin: "crawl"},
+		{EntityID: "p1", Prop: "nationality", Schema: "Person", Value: "br", Dataset: "a", FirstSeen: "2024-03-01T00:00:00", Origin: "crawl"},
+		{EntityID: "c1", Prop: "name", Schema: "Company", Value: "Acme", Dataset: "b", FirstSeen: "2024-12-31T23:00:00", External: true},
+		{EntityID: "o1", Prop: "name", Schema: "Organization", Value: "Org", Dataset: "b", FirstSeen: "2025-01-01T00:00:00"},
+	}
+	values := func(filters ...StatementFilter) string {
+		var out []string
+		for s, err := range FilterStatements(sliceStatements(st), filters...) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			out = append(out, s.Value)
+		}
+		return strings.Join(out, ",")
+	}
+	orgs, _ := m.SchemaSet("Organization")
+	cases := []struct {
+		name    string
+		filters []StatementFilter
+		want    string
+	}{
+		{"none", nil, "Ana,br,Acme,Org"},
+		{"dataset", []StatementFilter{DatasetFilter("b")}, "Acme,Org"},
+		{"schema with descendants", []StatementFilter{SchemaFilter(orgs)}, "Acme,Org"},
+		{"prop type", []StatementFilter{PropTypeFilter(m, "country")}, "br"},
+		{"prop group", []StatementFilter{PropGroupFilter(m, "names")}, "Ana,Acme,Org"},
+		{"first seen", []StatementFilter{FirstSeenFilter("2024", "2024")}, "br,Acme"},
+		{"origin", []StatementFilter{OriginFilter("crawl")}, "Ana,br"},
+		{"external", []StatementFilter{ExternalFilter(false), DatasetFilter("b")}, "Org"},
+		{"or", []StatementFilter{OrFilter(ExternalFilter(true), PropTypeFilter(m, "country"))}, "br,Acme"},
+		{"not", []StatementFilter{NotFilter(DatasetFilter("a"))}, "Acme,Org"},
+	}
+	for _, tc := range cases {
+		if got := values(tc.filters...); got != tc.want {
+			t.Errorf("%s: got %s, want %s", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestStatementsFromEntityValueMeta(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	e := NewEntityProxy(m.Get("Person"), "p1")
+	_ = e.AddWithLang("name", []string{"Ana Lima"}, false, "por")
+	_ = e.Add("nationality", []string{" BR "}, false)
+	st := StatementsFromEntity(e, "ds", "", "", false, "")
+	byProp := map[string]Statement{}
+	for _, s := range st {
+		byProp[s.Prop] = s
+	}
+	if s := byProp["name"]; s.Lang != "por" || s.Original != "" {
+		t.Fatalf("unexpected name statement %+v", s)
+	}
+	if s := byProp["nationality"]; s.Value != "br" || s.Original != " BR " {
+		t.Fatalf("unexpected nationality statement %+v", s)
+	}
+
+	// the metadata survives aggregation back into an entity
+	back := AggregateSortedStatements(m, st)[0]
+	if back.ValueLang("name", "Ana Lima") != "por" || back.ValueOriginal("nationality", "br") != " BR " {
+		t.Fatalf("lost value metadata: %q, %q", back.ValueLang("name", "Ana Lima"), back.ValueOriginal("nationality", "br"))
+	}
+}
+
+func TestAggregationSeenRange(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	st := []Statement{
+		{EntityID: "p1", Prop: "name", Schema: "Person", Value: "Ana", Dataset: "a", FirstSeen: "2023-05-01T00:00:00", LastSeen: "2024-01-01T00:00:00"},
+		{EntityID: "p1", Prop: "name", Schema: "Person", Value: "Ana Lima", Dataset: "b", FirstSeen: "2022-01-01T00:00:00", LastSeen: "2022-06-01T00:00:00"},
+		{EntityID: "p1", Prop: "nationality", Schema: "Person", Value: "br", Dataset: "c", FirstSeen: "2024-07-01T00:00:00"},
+		{EntityID: "p2", Prop: "name", Schema: "Person", Value: "Bob", Dataset: "a"},
+	}
+	check := func(name string, es []*EntityProxy) {
+		if len(es) != 2 {
+			t.Fatalf("%s: expected 2 entities, got %d", name, len(es))
+		}
+		if es[0].Context["first_seen"] != "2022-01-01T00:00:00" || es[0].Context["last_seen"] != "2024-07-01T00:00:00" {
+			t.Fatalf("%s: unexpected seen range %v", name, es[0].Context)
+		}
+		if _, ok := es[1].Context["first_seen"]; ok {
+			t.Fatalf("%s: unexpected seen range for unseen entity %v", name, es[1].Context)
+		}
+	}
+	check("slice", AggregateSortedStatements(m, append([]Statement(nil), st...)))
+	var es []*EntityProxy
+	for e, err := range IterAggregate(m, sliceStatements(st)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		es = append(es, e)
+	}
+	check("stream", es)
+}
+
+func TestAggregationDatasetsReferents(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	st := []Statement{
+		{EntityID: "q1", CanonicalID: "NK-1", Prop: "name", Schema: "Person", Value: "Ana", Dataset: "b"},
+		{EntityID: "q2", CanonicalID: "NK-1", Prop: "name", Schema: "Person", Value: "Ana Lima", Dataset: "a"},
+		{EntityID: "q2", CanonicalID: "NK-1", Prop: "nationality", Schema: "Person", Value: "br", Dataset: "a"},
+		{EntityID: "p2", Prop: "name", Schema: "Person", Value: "Bob", Dataset: "a"},
+	}
+	for e, err := range IterAggregate(m, sliceStatements(st)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := json.Marshal(map[string]any{"datasets": e.Context["datasets"], "referents": e.Context["referents"]})
+		want := map[string]string{
+			"NK-1": `{"datasets":["a","b"],"referents":["q1","q2"]}`,
+			"p2":   `{"datasets":["a"],"referents":[]}`,
+		}[e.ID]
+		if string(data) != want {
+			t.Fatalf("%s: got %s, want %s", e.ID, data, want)
+		}
+	}
+}
+
+func TestValidateStatements(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	st := []Statement{
+		{EntityID: "p1", Prop: BaseID, Schema: "Person", Value: "p1"},
+		{EntityID: "p1", Prop: "name", Schema: "Person", Value: "Ana"},
+		{EntityID: "p1", Prop: "birthDate", Schema: "Person", Value: "not a date"},
+		{EntityID: "p1", Prop: "color", Schema: "Person", Value: "red"},
+		{EntityID: "x1", Prop: "name", Schema: "Spaceship", Value: "Enterprise"},
+	}
+	report, err := ValidateStatements(m, sliceStatements(st))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Statements != 5 || report.Invalid != 3 || report.Valid() {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	var kinds []string
+	for _, issue := range report.Issues {
+		kinds = append(kinds, fmt.Sprintf("%d:%s", issue.Record, issue.Kind))
+	}
+	if got := strings.Join(kinds, ","); got != "3:invalid-value,4:unknown-property,5:unknown-schema" {
+		t.Fatalf("unexpected issues: %s", got)
+	}
+	var kept []string
+	for s, err := range FilterStatements(sliceStatements(st), ValidStatementFilter(m)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		kept = append(kept, s.Prop)
+	}
+	if got := strings.Join(kept, ","); got != "id,name" {
+		t.Fatalf("unexpected valid statements: %s", got)
+	}
+}
+
+func TestStatementKeyAlgorithm(t *testing.T) {
+	s := Statement{Dataset: "d", EntityID: "e1", Schema: "Person", Prop: "name", PropType: "name", Value: "Ana"}
+	legacy := MakeStatementKey("d", "e1", "name", "Ana", false)
+	if got := s.MakeKey(); got != legacy || len(got) != 40 {
+		t.Fatalf("default key changed: %s", got)
+	}
+	alg, err := ParseStatementKeyAlgorithm("sha256+schema+prop_type")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alg.String() != "sha256+schema+prop_type" {
+		t.Fatalf("unexpected recipe: %s", alg)
+	}
+	sum := sha256.Sum256([]byte("d.e1.Person.name.name.Ana"))
+	if got := alg.Key(s); got != hex.EncodeToString(sum[:]) {
+		t.Fatalf("unexpected key: %s", got)
+	}
+
+	// Readers take a recipe of their own, independent of the selected one.
+	in := `{"entity_id":"e1","schema":"Person","prop":"name","prop_type":"name","value":"Ana","dataset":"d"}` + "\n"
+	var ids []string
+	for _, opts := range []ReadOptions{{}, {KeyAlgorithm: &alg}} {
+		err := ReadStatementsJSONLWith(strings.NewReader(in), opts, func(r Statement) error {
+			ids = append(ids, r.ID)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if ids[0] != legacy || ids[1] != alg.Key(s) {
+		t.Fatalf("unexpected reader keys: %v", ids)
+	}
+
+	// Writers fill in missing IDs with the same recipe, so round trips keep them.
+	var jsonl, csvBuf bytes.Buffer
+	s.ID = ""
+	if err := WriteStatementsJSONLWith(&jsonl, WriteOptions{KeyAlgorithm: &alg}, []Statement{s}); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteStatementsCSVWith(&csvBuf, WriteOptions{KeyAlgorithm: &alg}, []Statement{s}); err != nil {
+		t.Fatal(err)
+	}
+	ids = ids[:0]
+	collect := func(r Statement) error {
+		ids = append(ids, r.ID)
+		return nil
+	}
+	if err := ReadStatementsJSONLWith(&jsonl, ReadOptions{KeyAlgorithm: &alg}, collect); err != nil {
+		t.Fatal(err)
+	}
+	if err := ReadStatementsCSVWith(&csvBuf, ReadOptions{KeyAlgorithm: &alg}, collect); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != alg.Key(s) || ids[1] != alg.Key(s) {
+		t.Fatalf("unexpected round-trip keys: %v", ids)
+	}
+
+	sha256Only := StatementKeyAlgorithm{SHA256: true}
+	SetStatementKeyAlgorithm(sha256Only)
+	defer SetStatementKeyAlgorithm(DefaultStatementKeyAlgorithm)
+	if s.MakeKey() != sha256Only.Key(s) || MakeStatementKey("d", "e1", "name", "Ana", false) != s.ID {
+		t.Fatal("MakeKey and MakeStatementKey should follow the selected recipe")
+	}
+	for _, spec := range []string{"md5", "sha1+dataset"} {
+		if _, err := ParseStatementKeyAlgorithm(spec); err == nil {
+			t.Errorf("expected error for %s", spec)
+		}
+	}
+}
+
+func TestMergeSortedStatements(t *testing.T) {
+	a := []Statement{
+		{ID: "1", EntityID: "a", Prop: "name", Value: "A", Dataset: "x"},
+		{ID: "2", EntityID: "c", Prop: "name", Value: "C", Dataset: "x"},
+	}
+	b := []Statement{
+		{ID: "3", EntityID: "a", Prop: "name", Value: "A2", Dataset: "y"},
+		{ID: "4", EntityID: "b", Prop: "name", Value: "B", Dataset: "y"},
+		{ID: "5", EntityID: "d", Prop: "name", Value: "D", Dataset: "y"},
+	}
+	var got []string
+	for s, err := range MergeSortedStatements(sliceStatements(a), sliceStatements(b), sliceStatements(nil)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, s.Value)
+	}
+	if strings.Join(got, ",") != "A,A2,B,C,D" {
+		t.Fatalf("unexpected order: %v", got)
+	}
+	var err error
+	for _, err = range MergeSortedStatements(sliceStatements(a), sliceStatements([]Statement{b[2], b[0]})) {
+		if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		t.Fatal("expected an error for an unsorted source")
+	}
+}
+
+func TestStatementStats(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	st := []Statement{
+		{EntityID: "p1", Prop: BaseID, Schema: "Person", Value: "p1", Dataset: "a", FirstSeen: "2023-01-01"},
+		{EntityID: "p1", Prop: "name", Schema: "Person", Value: "Ana", Dataset: "a", FirstSeen: "2023-01-01", LastSeen: "2024-02-01"},
+		{EntityID: "p2", CanonicalID: "p1", Prop: "name", Schema: "Person", Value: "Anna", Dataset: "b"},
+		{EntityID: "c1", Prop: "country", Schema: "Company", Value: "br", Dataset: "b", FirstSeen: "2022-06-01"},
+	}
+	stats, err := CollectStatementStats(m, sliceStatements(st))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Statements != 4 || stats.Entities != 2 {
+		t.Fatalf("unexpected totals: %+v", stats)
+	}
+	if stats.Datasets["b"] != 2 || stats.Schemata["Person"] != 3 || stats.Props["Person:name"] != 2 || stats.PropTypes["country"] != 1 {
+		t.Fatalf("unexpected counts: %+v", stats)
+	}
+	if stats.Coverage != (SeenCoverage{FirstSeen: "2022-06-01", LastSeen: "2024-02-01", Undated: 1}) {
+		t.Fatalf("unexpected coverage: %+v", stats.Coverage)
+	}
+	if cov := stats.DatasetCoverage["a"]; cov.FirstSeen != "2023-01-01" || cov.LastSeen != "2024-02-01" {
+		t.Fatalf("unexpected dataset coverage: %+v", cov)
+	}
+	raw, err := json.Marshal(stats)
+	if err != nil || !bytes.Contains(raw, []byte(`"prop_types":{"country":1,"id":1,"name":2}`)) {
+		t.Fatalf("unexpected report: %s (%v)", raw, err)
+	}
+}
+
+func TestStatementAggregatorPrecedence(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	st := []Statement{
+		{EntityID: "p1", Schema: "Person", Prop: "birthDate", Value: "1970-01-01", Dataset: "scraped"},
+		{EntityID: "p1", Schema: "Person", Prop: "birthDate", Value: "1971-02-03", Dataset: "registry", Origin: "web"},
+		{EntityID: "p1", Schema: "Person", Prop: "birthDate", Value: "1971-02-04", Dataset: "registry", Origin: "api"},
+		{EntityID: "p1", Schema: "Person", Prop: "gender", Value: "female", Dataset: "scraped"},
+		{EntityID: "p1", Schema: "Person", Prop: "gender", Value: "male", Dataset: "other"},
+		{EntityID: "p1", Schema: "Person", Prop: "name", Value: "Ana", Dataset: "scraped"},
+		{EntityID: "p1", Schema: "Person", Prop: "name", Value: "Anna", Dataset: "registry"},
+	}
+	agg := NewStatementAggregator(m)
+	agg.Precedence = &SourcePrecedence{
+		Datasets: map[string]int{"registry": 10, "scraped": 1},
+		Origins:  map[string]int{"api": 1},
+	}
+	for _, s := range st {
+		agg.Add(s)
+	}
+	e := agg.Flush()
+	if got := e.Get("birthDate"); len(got) != 1 || got[0] != "1971-02-04" {
+		t.Fatalf("unexpected birthDate: %v", got)
+	}
+	if got := e.Get("gender"); len(got) != 1 || got[0] != "female" {
+		t.Fatalf("unexpected gender: %v", got)
+	}
+	if got := e.Get("name"); len(got) != 2 {
+		t.Fatalf("multi-valued names should accumulate: %v", got)
+	}
+	if agg.Overruled != 3 {
+		t.Fatalf("expected 3 overruled statements, got %d", agg.Overruled)
+	}
+
+	// The slice path applies the same precedence.
+	es := AggregateSortedStatementsWith(m, append([]Statement(nil), st...), AggregateOptions{Precedence: agg.Precedence})
+	if len(es) != 1 {
+		t.Fatalf("expected one entity, got %d", len(es))
+	}
+	for _, prop := range []string{"birthDate", "gender", "name"} {
+		got, want := slices.Sorted(slices.Values(es[0].Get(prop))), slices.Sorted(slices.Values(e.Get(prop)))
+		if !slices.Equal(got, want) {
+			t.Fatalf("%s: slice path %v, streaming %v", prop, got, want)
+		}
+	}
+}
+
+func TestDedupeWriter(t *testing.T) {
+	st := []Statement{
+		{EntityID: "a", Schema: "Person", Prop: "name", Value: "Ana", Dataset: "x", FirstSeen: "2024-02-01"},
+		{EntityID: "a", Schema: "Person", Prop: "country", Value: "br", Dataset: "x", FirstSeen: "2024-02-01"},
+		{EntityID: "a", Schema: "Person", Prop: "name", Value: "Ana", Dataset: "x", FirstSeen: "2024-01-01", LastSeen: "2024-01-15"},
+		{EntityID: "b", Schema: "Person", Prop: "name", Value: "Bo", Dataset: "x", FirstSeen: "2024-01-01"},
+		{EntityID: "a", Schema: "Person", Prop: "name", Value: "Ana", Dataset: "x", FirstSeen: "2024-03-01"},
+	}
+	for _, grouped := range []bool{false, true} {
+		var out []Statement
+		dw := NewDedupeWriter(func(s Statement) error {
+			out = append(out, s)
+			return nil
+		})
+		dw.Grouped = grouped
+		input := st
+		if grouped {
+			input = st[:4] // ordered by group key
+		}
+		for _, s := range input {
+			if err := dw.Write(s); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := dw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if grouped {
+			if len(out) != 3 || dw.Dropped != 1 || out[0].FirstSeen != "2024-01-01" || out[0].LastSeen != "2024-02-01" {
+				t.Fatalf("grouped: unexpected output %v", out)
+			}
+			continue
+		}
+		if len(out) != 3 || dw.Dropped != 2 {
+			t.Fatalf("unexpected output: %v", out)
+		}
+		if out[0].Value != "Ana" || out[0].FirstSeen != "2024-01-01" || out[0].LastSeen != "2024-03-01" {
+			t.Fatalf("unexpected merged statement: %+v", out[0])
+		}
+		if out[1].Value != "br" || out[2].Value != "Bo" {
+			t.Fatalf("order of first appearance not kept: %v", out)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package ftm
+
+import (
+	"fmt"
+	"iter"
+)
+
+// StatementIssue is a problem found in a statement by ValidateStatements.
+type StatementIssue struct {
+	Record      int    `json:"record"` // position in the stream, from 1
+	StatementID string `json:"statement_id,omitempty"`
+	EntityID    string `json:"entity_id"`
+	Schema      string `json:"schema"`
+	Prop        string `json:"prop"`
+	Value       string `json:"value,omitempty"`
+	Kind        string `json:"kind"` // "unknown-schema", "unknown-property" or "invalid-value"
+	Message     string `json:"message"`
+}
+
+func (si StatementIssue) String() string {
+	return fmt.Sprintf("statement %d (%s) [%s]: %s", si.Record, si.EntityID, si.Kind, si.Message)
+}
+
+// StatementReport summarizes the validation of a statement stream.
+type StatementReport struct {
+	Statements int              `json:"statements"`
+	Invalid    int              `json:"invalid"`
+	Issues     []StatementIssue `json:"issues"`
+}
+
+// Valid reports whether no issues were found.
+func (r *StatementReport) Valid() bool { return len(r.Issues) == 0 }
+
+// ValidateStatements checks that the schema of each statement exists, that its
+// property exists on the schema and that the value passes the property type's
+// Validate. It reads the whole stream and returns every issue found; a read
+// error stops validation and is returned with the report so far. Use
+// ValidStatementFilter to drop invalid statements from a stream instead.
+func ValidateStatements(m *Model, src iter.Seq2[Statement, error]) (*StatementReport, error) {
+	report := &StatementReport{Issues: []StatementIssue{}}
+	for s, err := range src {
+		if err != nil {
+			return report, err
+		}
+		report.Statements++
+		if kind, msg := checkStatement(m, s); kind != "" {
+			report.Invalid++
+			report.Issues = append(report.Issues, StatementIssue{
+				Record:      report.Statements,
+				StatementID: s.ID,
+				EntityID:    s.EntityID,
+				Schema:      s.Schema,
+				Prop:        s.Prop,
+				Value:       s.Value,
+				Kind:        kind,
+				Message:     msg,
+			})
+		}
+	}
+	return report, nil
+}
+
+// ValidStatementFilter accepts the statements that ValidateStatements finds no
+// issue with.
+func ValidStatementFilter(m *Model) StatementFilter {
+	return func(s Statement) bool {
+		kind, _ := checkStatement(m, s)
+		return kind == ""
+	}
+}
+
+// checkStatement returns the kind and message of the first problem found in s,
+// or empty strings if it is valid. BaseID statements only need a known schema.
+func checkStatement(m *Model, s Statement) (kind, msg string) {
+	sc := m.Get(s.Schema)
+	if sc == nil {
+		return "unknown-schema", fmt.Sprintf("schema not found: %s", s.Schema)
+	}
+	if s.Prop == BaseID {
+		return "", ""
+	}
+	p := sc.Get(s.Prop)
+	if p == nil {
+		return "unknown-property", fmt.Sprintf("property %s not found on %s", s.Prop, sc.Name)
+	}
+	if !p.Type.Validate(s.Value) {
+		return "invalid-value", fmt.Sprintf("invalid %s value for %s: %q", p.Type.Name(), p.QName, s.Value)
+	}
+	return "", ""
+}
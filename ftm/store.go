@@ -0,0 +1,108 @@
+package ftm
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrEntityNotFound is returned by entity stores when no entity has the requested ID.
+var ErrEntityNotFound = errors.New("entity not found")
+
+// EntityStore is a read-only source of entities by ID, such as a local database or
+// a remote API client. Get returns ErrEntityNotFound if the store has no such entity.
+type EntityStore interface {
+	Get(id string) (*EntityProxy, error)
+}
+
+// MemoryEntityStore is an EntityStore backed by a map. It is safe for concurrent use.
+type MemoryEntityStore struct {
+	mu       sync.RWMutex
+	entities map[string]*EntityProxy
+}
+
+// NewMemoryEntityStore creates a store holding the given entities.
+func NewMemoryEntityStore(entities ...*EntityProxy) *MemoryEntityStore {
+	s := &MemoryEntityStore{entities: map[string]*EntityProxy{}}
+	for _, e := range entities {
+		_ = s.Put(e)
+	}
+	return s
+}
+
+// Put adds an entity, merging it into a stored entity with the same ID.
+func (s *MemoryEntityStore) Put(e *EntityProxy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if prev, ok := s.entities[e.ID]; ok {
+		_, err := prev.Merge(e)
+		return err
+	}
+	s.entities[e.ID] = e.Clone()
+	return nil
+}
+
+// Get returns a copy of the stored entity.
+func (s *MemoryEntityStore) Get(id string) (*EntityProxy, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	e, ok := s.entities[id]
+	if !ok {
+		return nil, ErrEntityNotFound
+	}
+	return e.Clone(), nil
+}
+
+// Len returns the number of stored entities.
+func (s *MemoryEntityStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.entities)
+}
+
+// MultiStore layers several stores in order of precedence. By default Get returns
+// the entity from the first store that has it; with Merge set, the versions from
+// all stores are merged onto the one with the highest precedence.
+type MultiStore struct {
+	Stores []EntityStore
+	Merge  bool
+	// SkipErrors treats failing stores (other than not-found) as missing the entity
+	// instead of aborting the lookup.
+	SkipErrors bool
+}
+
+// NewMultiStore creates a first-match view over the given stores.
+func NewMultiStore(stores ...EntityStore) *MultiStore {
+	return &MultiStore{Stores: stores}
+}
+
+// Get looks up an entity in all layers, honoring the store order.
+func (ms *MultiStore) Get(id string) (*EntityProxy, error) {
+	var result *EntityProxy
+	for i, store := range ms.Stores {
+		e, err := store.Get(id)
+		if errors.Is(err, ErrEntityNotFound) {
+			continue
+		}
+		if err != nil {
+			if ms.SkipErrors {
+				continue
+			}
+			return nil, fmt.Errorf("store %d: %w", i, err)
+		}
+		if !ms.Merge {
+			return e, nil
+		}
+		if result == nil {
+			result = e.Clone()
+			continue
+		}
+		if _, err := result.Merge(e); err != nil {
+			return nil, fmt.Errorf("store %d: %w", i, err)
+		}
+	}
+	if result == nil {
+		return nil, ErrEntityNotFound
+	}
+	return result, nil
+}
@@ -0,0 +1,85 @@
+package ftm
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"iter"
+	"sync"
+)
+
+// StatementSink consumes a statement stream, e.g. writing an archive or feeding
+// an aggregator. It may stop ranging early; returning an error aborts the tee.
+type StatementSink func(statements iter.Seq[Statement]) error
+
+// TeeStatements reads src once and feeds every statement to all sinks, each
+// running in its own goroutine behind a buffer of the given size. A slow sink
+// blocks the source when its buffer is full, so memory stays bounded. The first
+// error from the source or any sink stops all sinks; all errors are returned joined.
+func TeeStatements(src iter.Seq2[Statement, error], buffer int, sinks ...StatementSink) error {
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+	abort := func() { stopOnce.Do(func() { close(stop) }) }
+
+	chans := make([]chan Statement, len(sinks))
+	done := make([]chan struct{}, len(sinks))
+	errs := make([]error, len(sinks)+1)
+	for i, sink := range sinks {
+		ch := make(chan Statement, buffer)
+		chans[i], done[i] = ch, make(chan struct{})
+		go func(i int, sink StatementSink) {
+			defer close(done[i])
+			seq := func(yield func(Statement) bool) {
+				for {
+					select {
+					case s, ok := <-ch:
+						if !ok || !yield(s) {
+							return
+						}
+					case <-stop:
+						return
+					}
+				}
+			}
+			if err := sink(seq); err != nil {
+				errs[i+1] = err
+				abort()
+			}
+		}(i, sink)
+	}
+
+read:
+	for s, err := range src {
+		if err != nil {
+			errs[0] = err
+			abort()
+			break
+		}
+		for i, ch := range chans {
+			select {
+			case ch <- s:
+			case <-done[i]: // sink finished early
+			case <-stop:
+				break read
+			}
+		}
+	}
+	for i, ch := range chans {
+		close(ch)
+		<-done[i]
+	}
+	return errors.Join(errs...)
+}
+
+// JSONLSink returns a sink writing statements as JSON lines to w.
+func JSONLSink(w io.Writer) StatementSink {
+	return func(statements iter.Seq[Statement]) error {
+		bw := bufio.NewWriter(w)
+		for s := range statements {
+			if err := WriteStatementsJSONL(bw, []Statement{s}); err != nil {
+				return err
+			}
+		}
+		return bw.Flush()
+	}
+}
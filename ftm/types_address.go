@@ -11,7 +11,7 @@ import (
 type AddressType struct{ BaseType }
 
 func NewAddressType() *AddressType {
-	return &AddressType{BaseType{name: "address", group: "addresses", label: "Address", matchable: true, pivot: true}}
+	return &AddressType{BaseType{name: "address", group: "addresses", label: "Address", plural: "Addresses", matchable: true, pivot: true}}
 }
 
 var addrLineBreaks = regexp.MustCompile(`(\r\n|\n|<BR/>|<BR>|\t|ESQ\.,|ESQ,|;)`)
@@ -8,6 +8,7 @@ type PropertyType interface {
 	Name() string
 	Group() string   // logical group name, may be empty
 	Label() string   // human-readable name
+	Plural() string  // human-readable name for several values
 	Matchable() bool // included in matching/comparison
 	Pivot() bool     // used to form graph pivots
 	MaxLength() int  // maximum length of a single value
@@ -24,11 +25,18 @@ type PropertyType interface {
 	CompareSets(left, right []string) float64
 }
 
+// LangCleaner is implemented by types whose cleaning can use a language hint
+// (ISO-639-3 code), e.g. to parse localized month names.
+type LangCleaner interface {
+	CleanLang(text string, fuzzy bool, format string, lang string, proxy *EntityProxy) (string, bool)
+}
+
 // BaseType offers default implementations.
 type BaseType struct {
 	name      string
 	group     string
 	label     string
+	plural    string
 	matchable bool
 	pivot     bool
 	maxLength int
@@ -38,6 +46,7 @@ type BaseType struct {
 func (b BaseType) Name() string                          { return b.name }
 func (b BaseType) Group() string                         { return b.group }
 func (b BaseType) Label() string                         { return b.label }
+func (b BaseType) Plural() string                        { return b.plural }
 func (b BaseType) Matchable() bool                       { return b.matchable }
 func (b BaseType) Pivot() bool                           { return b.pivot }
 func (b BaseType) MaxLength() int                        { return b.maxLength }
@@ -9,7 +9,7 @@ import (
 type ChecksumType struct{ BaseType }
 
 func NewChecksumType() *ChecksumType {
-	return &ChecksumType{BaseType{name: "checksum", group: "checksums", label: "Checksum", matchable: true, pivot: true, maxLength: 40}}
+	return &ChecksumType{BaseType{name: "checksum", group: "checksums", label: "Checksum", plural: "Checksums", matchable: true, pivot: true, maxLength: 40}}
 }
 
 var sha1Hex = regexp.MustCompile(`^[0-9a-f]{40}$`)
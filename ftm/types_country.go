@@ -3,13 +3,16 @@ package ftm
 import (
 	"regexp"
 	"strings"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
 )
 
 // CountryType accepts ISO-3166 alpha-2 codes and common FtM codes.
 type CountryType struct{ BaseType }
 
 func NewCountryType() *CountryType {
-	return &CountryType{BaseType{name: "country", group: "countries", label: "Country", matchable: true, maxLength: 16}}
+	return &CountryType{BaseType{name: "country", group: "countries", label: "Country", plural: "Countries", matchable: true, maxLength: 16}}
 }
 
 var countryAlpha2 = regexp.MustCompile(`^[A-Za-z]{2}$`)
@@ -40,3 +43,11 @@ func (t *CountryType) Clean(text string, _ bool, _ string, _ *EntityProxy) (stri
 	}
 	return "", false
 }
+func (t *CountryType) Caption(value string, _ string) string {
+	if r, err := language.ParseRegion(value); err == nil {
+		if name := display.English.Regions().Name(r); name != "" && name != "Unknown Region" {
+			return name
+		}
+	}
+	return value
+}
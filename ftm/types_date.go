@@ -1,7 +1,9 @@
 package ftm
 
 import (
+	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -9,11 +11,23 @@ var isoDateFull = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
 var isoDateMonth = regexp.MustCompile(`^\d{4}-\d{2}$`)
 var isoDateYear = regexp.MustCompile(`^\d{4}$`)
 
+// dateMonthNames maps a language code to lowercase month name prefixes (January first).
+var dateMonthNames = map[string][]string{
+	"eng": {"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"},
+	"deu": {"jan", "feb", "mär", "apr", "mai", "jun", "jul", "aug", "sep", "okt", "nov", "dez"},
+	"fra": {"janv", "févr", "mars", "avr", "mai", "juin", "juil", "août", "sept", "oct", "nov", "déc"},
+	"spa": {"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sep", "oct", "nov", "dic"},
+	"por": {"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+	"rus": {"янв", "фев", "мар", "апр", "ма", "июн", "июл", "авг", "сен", "окт", "ноя", "дек"},
+}
+
+var dateTokens = regexp.MustCompile(`[\p{L}]+|\d+`)
+
 // DateType supports YYYY, YYYY-MM, YYYY-MM-DD.
 type DateType struct{ BaseType }
 
 func NewDateType() *DateType {
-	return &DateType{BaseType{name: "date", label: "Date", matchable: true}}
+	return &DateType{BaseType{name: "date", label: "Date", plural: "Dates", matchable: true}}
 }
 func (t *DateType) Validate(value string) bool {
 	return isoDateFull.MatchString(value) || isoDateMonth.MatchString(value) || isoDateYear.MatchString(value)
@@ -30,3 +44,46 @@ func (t *DateType) Clean(text string, _ bool, _ string, _ *EntityProxy) (string,
 	}
 	return "", false
 }
+
+// CleanLang parses dates with localized month names (e.g. "3. März 2021") before
+// falling back to the language-independent cleaner.
+func (t *DateType) CleanLang(text string, fuzzy bool, format string, lang string, proxy *EntityProxy) (string, bool) {
+	if months, ok := dateMonthNames[strings.ToLower(lang)]; ok {
+		if out, ok := parseLocalDate(strings.ToLower(text), months); ok {
+			return out, true
+		}
+	}
+	return t.Clean(text, fuzzy, format, proxy)
+}
+
+// parseLocalDate extracts day, month name and year tokens from a textual date.
+func parseLocalDate(text string, months []string) (string, bool) {
+	year, month, day := 0, 0, 0
+	for _, tok := range dateTokens.FindAllString(text, -1) {
+		if n, err := strconv.Atoi(tok); err == nil {
+			switch {
+			case len(tok) == 4 && year == 0:
+				year = n
+			case n >= 1 && n <= 31 && day == 0:
+				day = n
+			}
+			continue
+		}
+		if month != 0 {
+			continue
+		}
+		for i, prefix := range months {
+			if strings.HasPrefix(tok, prefix) {
+				month = i + 1
+				break
+			}
+		}
+	}
+	if year == 0 || month == 0 {
+		return "", false
+	}
+	if day == 0 {
+		return fmt.Sprintf("%04d-%02d", year, month), true
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", year, month, day), true
+}
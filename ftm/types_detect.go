@@ -0,0 +1,87 @@
+package ftm
+
+import (
+	"sort"
+	"strings"
+)
+
+// TypeGuess is a plausible property type for a raw string.
+type TypeGuess struct {
+	Type   PropertyType `json:"-"`
+	Name   string       `json:"type"`
+	Format string       `json:"format,omitempty"` // e.g. "iban" for identifiers
+	Value  string       `json:"value"`            // the value as cleaned by the type
+	Score  float64      `json:"score"`            // 0..1, higher is more specific
+}
+
+// detector scores a raw value for one type; a zero score means no match.
+type detector struct {
+	t      PropertyType
+	format string
+	score  func(raw, clean string) float64
+}
+
+// detectors lists the types Detect tries. Scores reflect how unlikely a false
+// match is: an email address is almost never something else, while a short
+// number may be a date, an amount or a code.
+func (r *Registry) detectors() []detector {
+	fixed := func(s float64) func(string, string) float64 {
+		return func(string, string) float64 { return s }
+	}
+	return []detector{
+		{r.Email, "", fixed(1.0)},
+		{r.Identifier, "iban", fixed(0.95)},
+		{r.Phone, "", func(raw, _ string) float64 {
+			if strings.HasPrefix(strings.TrimSpace(raw), "+") {
+				return 0.9
+			}
+			return 0.6
+		}},
+		{r.IP, "", fixed(0.9)},
+		{r.Date, "", func(_, clean string) float64 {
+			// full dates are distinctive, bare years are not
+			if len(clean) >= len("2006-01-02") {
+				return 0.85
+			}
+			return 0.4
+		}},
+		{r.URL, "", func(raw, _ string) float64 {
+			if strings.Contains(raw, "://") {
+				return 0.9
+			}
+			if strings.Contains(raw, ".") && !strings.Contains(raw, " ") {
+				return 0.5
+			}
+			return 0
+		}},
+		// country codes collide with many abbreviations
+		{r.Country, "", fixed(0.4)},
+		{r.Number, "", fixed(0.3)},
+	}
+}
+
+// Detect scores which property types a raw string plausibly belongs to, for
+// classifying unlabeled data. Guesses are sorted by descending score; an empty
+// result means no specific type matched and the value is best treated as text.
+func (r *Registry) Detect(value string) []TypeGuess {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	var out []TypeGuess
+	for _, d := range r.detectors() {
+		clean, ok := d.t.Clean(value, false, d.format, nil)
+		if !ok || clean == "" {
+			continue
+		}
+		if score := d.score(value, clean); score > 0 {
+			out = append(out, TypeGuess{Type: d.t, Name: d.t.Name(), Format: d.format, Value: clean, Score: score})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+	return out
+}
+
+// Detect scores a raw string against the default registry. See Registry.Detect.
+func Detect(value string) []TypeGuess {
+	return defaultRegistry.Detect(value)
+}
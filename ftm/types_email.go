@@ -12,7 +12,7 @@ import (
 type EmailType struct{ BaseType }
 
 func NewEmailType() *EmailType {
-	return &EmailType{BaseType{name: "email", group: "emails", label: "E-Mail Address", matchable: true, pivot: true}}
+	return &EmailType{BaseType{name: "email", group: "emails", label: "E-Mail Address", plural: "E-Mail Addresses", matchable: true, pivot: true}}
 }
 
 var emailLocalRe = regexp.MustCompile(`^[^<>()[\]\\,;:\?\s@\"]{1,64}$`)
@@ -4,7 +4,7 @@ package ftm
 type EntityType struct{ BaseType }
 
 func NewEntityType() *EntityType {
-	return &EntityType{BaseType{name: "entity", label: "Entity", matchable: false}}
+	return &EntityType{BaseType{name: "entity", label: "Entity", plural: "Entities", matchable: false}}
 }
 func (t *EntityType) Validate(value string) bool { return value != "" }
 func (t *EntityType) Clean(text string, _ bool, _ string, _ *EntityProxy) (string, bool) {
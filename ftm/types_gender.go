@@ -9,7 +9,7 @@ type GenderType struct {
 }
 
 func NewGenderType() *GenderType {
-	return &GenderType{BaseType: BaseType{name: "gender", group: "genders", label: "Gender", matchable: false, maxLength: 16}, values: map[string]struct{}{"male": {}, "female": {}, "other": {}}}
+	return &GenderType{BaseType: BaseType{name: "gender", group: "genders", label: "Gender", plural: "Genders", matchable: false, maxLength: 16}, values: map[string]struct{}{"male": {}, "female": {}, "other": {}}}
 }
 func (t *GenderType) Clean(text string, _ bool, _ string, _ *EntityProxy) (string, bool) {
 	code := strings.ToLower(strings.TrimSpace(text))
@@ -4,7 +4,7 @@ package ftm
 type HTMLType struct{ BaseType }
 
 func NewHTMLType() *HTMLType {
-	return &HTMLType{BaseType{name: "html", label: "HTML", matchable: false, maxLength: 65000, totalSize: 30 * 1024 * 1024}}
+	return &HTMLType{BaseType{name: "html", label: "HTML", plural: "HTMLs", matchable: false, maxLength: 65000, totalSize: 30 * 1024 * 1024}}
 }
 func (t *HTMLType) Validate(value string) bool { return value != "" }
 func (t *HTMLType) Clean(text string, _ bool, _ string, _ *EntityProxy) (string, bool) {
@@ -10,7 +10,7 @@ import (
 type IdentifierType struct{ BaseType }
 
 func NewIdentifierType() *IdentifierType {
-	return &IdentifierType{BaseType{name: "identifier", group: "identifiers", label: "Identifier", matchable: true, pivot: true, maxLength: 64}}
+	return &IdentifierType{BaseType{name: "identifier", group: "identifiers", label: "Identifier", plural: "Identifiers", matchable: true, pivot: true, maxLength: 64}}
 }
 func (t *IdentifierType) Validate(value string) bool {
 	_, ok := t.Clean(value, false, "", nil)
@@ -9,7 +9,7 @@ import (
 type IpType struct{ BaseType }
 
 func NewIpType() *IpType {
-	return &IpType{BaseType{name: "ip", group: "ips", label: "IP Address", matchable: true, pivot: true, maxLength: 64}}
+	return &IpType{BaseType{name: "ip", group: "ips", label: "IP Address", plural: "IP Addresses", matchable: true, pivot: true, maxLength: 64}}
 }
 func (t *IpType) Validate(value string) bool { return net.ParseIP(value) != nil }
 func (t *IpType) Clean(text string, _ bool, _ string, _ *EntityProxy) (string, bool) {
@@ -6,7 +6,7 @@ import "encoding/json"
 type JsonType struct{ BaseType }
 
 func NewJsonType() *JsonType {
-	return &JsonType{BaseType{name: "json", label: "Nested data", matchable: false}}
+	return &JsonType{BaseType{name: "json", label: "Nested data", plural: "Nested data", matchable: false}}
 }
 func (t *JsonType) Validate(value string) bool {
 	var v any
@@ -1,12 +1,17 @@
 package ftm
 
-import "strings"
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
 
 // LanguageType with ISO-639-3 whitelist.
 type LanguageType struct{ BaseType }
 
 func NewLanguageType() *LanguageType {
-	return &LanguageType{BaseType{name: "language", group: "languages", label: "Language", matchable: false, maxLength: 16}}
+	return &LanguageType{BaseType{name: "language", group: "languages", label: "Language", plural: "Languages", matchable: false, maxLength: 16}}
 }
 
 var languageWhitelist = map[string]struct{}{}
@@ -28,3 +33,11 @@ func (t *LanguageType) Clean(text string, _ bool, _ string, _ *EntityProxy) (str
 	}
 	return "", false
 }
+func (t *LanguageType) Caption(value string, _ string) string {
+	if b, err := language.ParseBase(value); err == nil {
+		if name := display.English.Languages().Name(b); name != "" {
+			return name
+		}
+	}
+	return value
+}
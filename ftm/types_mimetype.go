@@ -9,7 +9,7 @@ import (
 type MimeType struct{ BaseType }
 
 func NewMimeType() *MimeType {
-	return &MimeType{BaseType{name: "mimetype", group: "mimetypes", label: "MIME-Type", matchable: false}}
+	return &MimeType{BaseType{name: "mimetype", group: "mimetypes", label: "MIME-Type", plural: "MIME-Types", matchable: false}}
 }
 
 var mimeRe = regexp.MustCompile(`^[a-zA-Z0-9!#$&^_.+-]{1,127}/[a-zA-Z0-9!#$&^_.+-]{1,127}$`)
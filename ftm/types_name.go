@@ -11,7 +11,7 @@ import (
 type NameType struct{ BaseType }
 
 func NewNameType() *NameType {
-	return &NameType{BaseType{name: "name", group: "names", label: "Name", matchable: true, pivot: true, maxLength: 512}}
+	return &NameType{BaseType{name: "name", group: "names", label: "Name", plural: "Names", matchable: true, pivot: true, maxLength: 512}}
 }
 func (t *NameType) Validate(value string) bool { return value != "" }
 func (t *NameType) Clean(text string, _ bool, _ string, _ *EntityProxy) (string, bool) {
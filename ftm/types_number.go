@@ -9,7 +9,7 @@ import (
 type NumberType struct{ BaseType }
 
 func NewNumberType() *NumberType {
-	return &NumberType{BaseType{name: "number", label: "Number", matchable: true}}
+	return &NumberType{BaseType{name: "number", label: "Number", plural: "Numbers", matchable: true}}
 }
 func (t *NumberType) Validate(value string) bool {
 	_, err := strconv.ParseFloat(value, 64)
@@ -10,7 +10,7 @@ import (
 type PhoneType struct{ BaseType }
 
 func NewPhoneType() *PhoneType {
-	return &PhoneType{BaseType{name: "phone", group: "phones", label: "Phone number", matchable: true, pivot: true, maxLength: 64}}
+	return &PhoneType{BaseType{name: "phone", group: "phones", label: "Phone number", plural: "Phone numbers", matchable: true, pivot: true, maxLength: 64}}
 }
 func (t *PhoneType) Validate(value string) bool {
 	n, err := phonenumbers.Parse(value, "")
@@ -59,4 +59,12 @@ func (t *PhoneType) CountryHint(value string) (string, bool) {
 	}
 	return strings.ToLower(reg), true
 }
+func (t *PhoneType) Caption(value string, _ string) string {
+	n, err := phonenumbers.Parse(value, "")
+	if err != nil {
+		return value
+	}
+	return phonenumbers.Format(n, phonenumbers.INTERNATIONAL)
+}
 func (t *PhoneType) NodeID(value string) (string, bool) { return "tel:" + value, true }
+func (t *PhoneType) usesProxy() bool                    { return true }
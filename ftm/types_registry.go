@@ -1,5 +1,7 @@
 package ftm
 
+import "encoding/json"
+
 // Registry holds known property types and helpers.
 type Registry struct {
 	// Commonly referenced types
@@ -58,20 +60,173 @@ func NewRegistry() *Registry {
 		groups:     map[string]PropertyType{},
 	}
 	for _, t := range []PropertyType{r.String, r.Text, r.HTML, r.Name, r.Date, r.Number, r.URL, r.Country, r.Email, r.IP, r.Phone, r.Address, r.Language, r.Mime, r.Checksum, r.Identifier, r.Entity, r.Topic, r.Gender, r.Json} {
-		r.types[t.Name()] = t
-		if t.Matchable() {
-			r.matchable[t.Name()] = t
-		}
-		if t.Pivot() {
-			r.pivots[t.Name()] = t
-		}
-		if g := t.Group(); g != "" {
-			r.groups[g] = t
-		}
+		r.Register(t)
 	}
 	return r
 }
 
+// Register adds a custom property type, replacing any type of the same name.
+// A type of one of the built-in Go types (e.g. a *TopicType with other values)
+// also replaces the matching typed field. Register a type before loading
+// models with the registry; it is not safe to call while the registry is in
+// use.
+func (r *Registry) Register(t PropertyType) {
+	r.setField(t)
+	name := t.Name()
+	if prev, ok := r.types[name]; ok && prev.Group() != "" {
+		delete(r.groups, prev.Group())
+	}
+	r.types[name] = t
+	delete(r.matchable, name)
+	delete(r.pivots, name)
+	if t.Matchable() {
+		r.matchable[name] = t
+	}
+	if t.Pivot() {
+		r.pivots[name] = t
+	}
+	if g := t.Group(); g != "" {
+		r.groups[g] = t
+	}
+}
+
+// setField points the typed field of t's Go type at t.
+func (r *Registry) setField(t PropertyType) {
+	switch tt := t.(type) {
+	case *StringType:
+		r.String = tt
+	case *TextType:
+		r.Text = tt
+	case *HTMLType:
+		r.HTML = tt
+	case *NameType:
+		r.Name = tt
+	case *DateType:
+		r.Date = tt
+	case *NumberType:
+		r.Number = tt
+	case *URLType:
+		r.URL = tt
+	case *CountryType:
+		r.Country = tt
+	case *EmailType:
+		r.Email = tt
+	case *IpType:
+		r.IP = tt
+	case *PhoneType:
+		r.Phone = tt
+	case *AddressType:
+		r.Address = tt
+	case *LanguageType:
+		r.Language = tt
+	case *MimeType:
+		r.Mime = tt
+	case *ChecksumType:
+		r.Checksum = tt
+	case *IdentifierType:
+		r.Identifier = tt
+	case *EntityType:
+		r.Entity = tt
+	case *TopicType:
+		r.Topic = tt
+	case *GenderType:
+		r.Gender = tt
+	case *JsonType:
+		r.Json = tt
+	}
+}
+
 func (r *Registry) Get(name string) PropertyType { return r.types[name] }
 
-var registry = NewRegistry()
+// DefaultRegistry returns the registry shared by models loaded without their own
+// registry, see ModelOptions.
+func DefaultRegistry() *Registry { return defaultRegistry }
+
+// Types returns all property types, sorted by name.
+func (r *Registry) Types() []PropertyType { return sortedTypes(r.types) }
+
+// Matchable returns the types used when comparing entities, sorted by name.
+func (r *Registry) Matchable() []PropertyType { return sortedTypes(r.matchable) }
+
+// Pivots returns the types used to link entities in graphs, sorted by name.
+func (r *Registry) Pivots() []PropertyType { return sortedTypes(r.pivots) }
+
+func sortedTypes(types map[string]PropertyType) []PropertyType {
+	out := make([]PropertyType, 0, len(types))
+	for _, name := range sortedKeys(types) {
+		out = append(out, types[name])
+	}
+	return out
+}
+
+// TypeInfo is the metadata of a property type, as listed in the model dump.
+type TypeInfo struct {
+	Name      string `json:"name"`
+	Label     string `json:"label"`
+	Plural    string `json:"plural"`
+	Group     string `json:"group,omitempty"`
+	Matchable bool   `json:"matchable"`
+	Pivot     bool   `json:"pivot"`
+	MaxLength int    `json:"maxLength"`
+	TotalSize int    `json:"totalSize,omitempty"`
+}
+
+// Info returns the metadata of the named type.
+func (r *Registry) Info(name string) (TypeInfo, bool) {
+	t, ok := r.types[name]
+	if !ok {
+		return TypeInfo{}, false
+	}
+	return TypeInfo{
+		Name:      t.Name(),
+		Label:     t.Label(),
+		Plural:    t.Plural(),
+		Group:     t.Group(),
+		Matchable: t.Matchable(),
+		Pivot:     t.Pivot(),
+		MaxLength: t.MaxLength(),
+		TotalSize: t.TotalSize(),
+	}, true
+}
+
+// PropertyGroup collects the values of one property type across all properties
+// of an entity, e.g. all "names" or "countries", as used to drive facets.
+type PropertyGroup struct {
+	Name   string       `json:"name"`
+	Label  string       `json:"label"`
+	Plural string       `json:"plural"`
+	Type   PropertyType `json:"-"`
+}
+
+// MarshalJSON adds the name of the group's type.
+func (g PropertyGroup) MarshalJSON() ([]byte, error) {
+	type plain PropertyGroup
+	return json.Marshal(struct {
+		plain
+		Type string `json:"type"`
+	}{plain(g), g.Type.Name()})
+}
+
+// Groups returns the property groups, sorted by name.
+func (r *Registry) Groups() []PropertyGroup {
+	out := make([]PropertyGroup, 0, len(r.groups))
+	for _, name := range sortedKeys(r.groups) {
+		out = append(out, r.newGroup(name))
+	}
+	return out
+}
+
+// Group returns the property group of the given name.
+func (r *Registry) Group(name string) (PropertyGroup, bool) {
+	if _, ok := r.groups[name]; !ok {
+		return PropertyGroup{}, false
+	}
+	return r.newGroup(name), true
+}
+
+func (r *Registry) newGroup(name string) PropertyGroup {
+	t := r.groups[name]
+	return PropertyGroup{Name: name, Label: t.Label(), Plural: t.Plural(), Type: t}
+}
+
+var defaultRegistry = NewRegistry()
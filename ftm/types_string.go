@@ -4,7 +4,7 @@ package ftm
 type StringType struct{ BaseType }
 
 func NewStringType() *StringType {
-	return &StringType{BaseType{name: "string", label: "String", matchable: false, maxLength: 1024}}
+	return &StringType{BaseType{name: "string", label: "String", plural: "Strings", matchable: false, maxLength: 1024}}
 }
 func (t *StringType) Validate(value string) bool { _, ok := sanitizeText(value); return ok }
 func (t *StringType) Clean(text string, _ bool, _ string, _ *EntityProxy) (string, bool) {
@@ -1,6 +1,7 @@
 package ftm
 
 import (
+	"encoding/json"
 	"testing"
 )
 
@@ -111,3 +112,171 @@ func TestJsonType(t *testing.T) {
 		t.Fatalf("json clean string failed: %v %v", ok, out)
 	}
 }
+
+func TestCleanCache(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	c := NewCleanCache(2)
+	SetCleanCache(c)
+	defer SetCleanCache(nil)
+
+	p := NewEntityProxy(m.Get("Person"), "p1")
+	_ = p.Add("nationality", []string{"DE"}, false)
+	_ = p.Add("country", []string{"DE", "FR", "BR"}, false)
+	hits, misses := c.Stats()
+	if hits != 1 || misses != 3 {
+		t.Fatalf("unexpected cache stats: hits=%d misses=%d", hits, misses)
+	}
+	if c.Len() != 2 {
+		t.Fatalf("cache should be bounded to 2 entries, got %d", c.Len())
+	}
+	if p.First("country") != "de" {
+		t.Fatalf("cached clean result wrong: %v", p.Get("country"))
+	}
+}
+
+func TestTopicHierarchy(t *testing.T) {
+	tt := NewTopicType()
+	if !tt.IsA("crime.fraud", "crime") || tt.IsA("crimea", "crime") {
+		t.Fatalf("topic IsA failed")
+	}
+	got := tt.Filter([]string{"crime", "crime.traffick.drug", "sanction", "role.pep"}, "crime*", "role.pep")
+	if len(got) != 3 {
+		t.Fatalf("unexpected topic filter result: %v", got)
+	}
+}
+
+func TestValueCaptions(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	p := NewEntityProxy(m.Get("Person"), "p1")
+	_ = p.Add("nationality", []string{"de"}, false)
+	_ = p.Add("topics", []string{"role.pep"}, false)
+	_ = p.Add("phone", []string{"+12025557612"}, false)
+	if got := p.CaptionFor("nationality"); got != "Germany" {
+		t.Fatalf("country caption: %q", got)
+	}
+	if got := p.CaptionFor("topics"); got != "Politician" {
+		t.Fatalf("topic caption: %q", got)
+	}
+	if got := p.CaptionFor("phone"); got != "+1 202-555-7612" {
+		t.Fatalf("phone caption: %q", got)
+	}
+}
+
+func TestInferMapping(t *testing.T) {
+	m, err := NewModel("../schema")
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	person := m.Get("Person")
+	header := []string{"person_id", "Full Name", "Contact", "Born", "Nationality"}
+	rows := [][]string{
+		{"1", "Ana Silva", "ana@example.com", "1980-02-03", "Brazil"},
+		{"2", "Bob Jones", "bob@example.org", "1975-07-01", "DE"},
+	}
+	guesses := InferMapping(person, header, rows)
+	want := []string{"", "name", "email", "", "nationality"}
+	for i, g := range guesses {
+		if want[i] != "" && g.Property != want[i] {
+			t.Fatalf("column %s: expected %s, got %+v", g.Column, want[i], g)
+		}
+	}
+	if guesses[3].Type != "date" {
+		t.Fatalf("expected date detection for Born, got %+v", guesses[3])
+	}
+	if keys := InferKeys(person, guesses); len(keys) != 1 || keys[0] != "person_id" {
+		t.Fatalf("unexpected keys: %v", keys)
+	}
+}
+
+func TestRegistryDetect(t *testing.T) {
+	cases := map[string]string{
+		"ana@example.com":             "email",
+		"DE89 3704 0044 0532 0130 00": "identifier",
+		"+12025557612":                "phone",
+		"2021-03-04":                  "date",
+		"https://example.com/x":       "url",
+		"de":                          "country",
+	}
+	for value, want := range cases {
+		guesses := Detect(value)
+		if len(guesses) == 0 || guesses[0].Name != want {
+			t.Fatalf("Detect(%q): expected %s first, got %+v", value, want, guesses)
+		}
+	}
+	if guesses := Detect("DE89 3704 0044 0532 0130 00"); guesses[0].Format != "iban" || guesses[0].Value != "DE89370400440532013000" {
+		t.Fatalf("expected cleaned IBAN, got %+v", guesses[0])
+	}
+	if guesses := Detect("just some words"); len(guesses) != 0 {
+		t.Fatalf("expected no guesses for free text, got %+v", guesses)
+	}
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	v := map[string]any{
+		"b": []any{1.0, 1e21, 0.0000001, "x<y"},
+		"a": "Café",
+		"é": true,
+	}
+	got, err := CanonicalJSON(v)
+	if err != nil {
+		t.Fatalf("CanonicalJSON: %v", err)
+	}
+	want := `{"a":"Café","b":[1,1e+21,1e-7,"x<y"],"é":true}`
+	if string(got) != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+	h1, _ := ContentHash(map[string]any{"x": 1, "y": "Café"})
+	h2, _ := ContentHash(map[string]any{"y": "Café", "x": 1.0})
+	if h1 != h2 || len(h1) != 64 {
+		t.Fatalf("expected equal hashes, got %s and %s", h1, h2)
+	}
+}
+
+func TestRegistryGroups(t *testing.T) {
+	r := NewRegistry()
+	groups := r.Groups()
+	if len(groups) == 0 || groups[0].Name > groups[len(groups)-1].Name {
+		t.Fatalf("expected sorted groups, got %v", groups)
+	}
+	g, ok := r.Group("countries")
+	if !ok || g.Label != "Country" || g.Plural != "Countries" || g.Type != r.Country {
+		t.Fatalf("unexpected group %+v", g)
+	}
+	if _, ok := r.Group("dates"); ok {
+		t.Fatalf("expected no dates group")
+	}
+	raw, err := json.Marshal(g)
+	if err != nil || string(raw) != `{"name":"countries","label":"Country","plural":"Countries","type":"country"}` {
+		t.Fatalf("unexpected JSON %s (%v)", raw, err)
+	}
+}
+
+func TestRegistryIntrospection(t *testing.T) {
+	r := DefaultRegistry()
+	types := r.Types()
+	if len(types) != 20 || types[0].Name() != "address" {
+		t.Fatalf("unexpected types: %d, first %s", len(types), types[0].Name())
+	}
+	for _, pt := range r.Matchable() {
+		if !pt.Matchable() {
+			t.Fatalf("%s is not matchable", pt.Name())
+		}
+	}
+	pivots := r.Pivots()
+	if len(pivots) == 0 || !pivots[0].Pivot() {
+		t.Fatalf("expected pivot types")
+	}
+	info, ok := r.Info("name")
+	if !ok || info.Group != "names" || info.MaxLength != 512 || !info.Pivot {
+		t.Fatalf("unexpected info %+v", info)
+	}
+	if _, ok := r.Info("nope"); ok {
+		t.Fatalf("expected unknown type")
+	}
+}
@@ -4,7 +4,7 @@ package ftm
 type TextType struct{ BaseType }
 
 func NewTextType() *TextType {
-	return &TextType{BaseType{name: "text", label: "Text", matchable: false, maxLength: 65000, totalSize: 30 * 1024 * 1024}}
+	return &TextType{BaseType{name: "text", label: "Text", plural: "Texts", matchable: false, maxLength: 65000, totalSize: 30 * 1024 * 1024}}
 }
 func (t *TextType) Validate(value string) bool { return value != "" }
 func (t *TextType) Clean(text string, _ bool, _ string, _ *EntityProxy) (string, bool) {
@@ -79,7 +79,7 @@ type TopicType struct {
 }
 
 func NewTopicType() *TopicType {
-	t := &TopicType{BaseType: BaseType{name: "topic", group: "topics", label: "Topic", matchable: false, maxLength: 64}, values: map[string]string{}}
+	t := &TopicType{BaseType: BaseType{name: "topic", group: "topics", label: "Topic", plural: "Topics", matchable: false, maxLength: 64}, values: map[string]string{}}
 	for k, v := range TopicTypeValues {
 		t.values[k] = v
 	}
@@ -102,3 +102,41 @@ func (t *TopicType) Caption(value, _ string) string {
 	}
 	return value
 }
+
+// IsA reports whether child equals parent or sits below it in the dot-separated
+// topic hierarchy (e.g. "crime.fraud" is a "crime").
+func (t *TopicType) IsA(child, parent string) bool {
+	child = strings.ToLower(strings.TrimSpace(child))
+	parent = strings.ToLower(strings.TrimSpace(parent))
+	return child == parent || strings.HasPrefix(child, parent+".")
+}
+
+// Match tests a topic against a pattern. A trailing "*" selects the whole topic
+// family ("crime*" matches "crime" and "crime.fraud"); otherwise the match is exact.
+func (t *TopicType) Match(value, pattern string) bool {
+	if family, ok := strings.CutSuffix(pattern, "*"); ok {
+		return t.IsA(value, strings.TrimSuffix(family, "."))
+	}
+	return strings.EqualFold(strings.TrimSpace(value), strings.TrimSpace(pattern))
+}
+
+// Filter returns the values matching any of the patterns.
+func (t *TopicType) Filter(values []string, patterns ...string) []string {
+	var out []string
+	for _, v := range values {
+		for _, p := range patterns {
+			if t.Match(v, p) {
+				out = append(out, v)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// HasTopic tests whether any topic-typed value of the entity matches one of the
+// patterns, using the semantics of TopicType.Match (e.g. `topics:crime*`).
+func (e *EntityProxy) HasTopic(patterns ...string) bool {
+	topic := e.Schema.Model.registry.Topic
+	return len(topic.Filter(e.GetTypeValues(topic, false), patterns...)) > 0
+}
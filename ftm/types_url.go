@@ -10,7 +10,7 @@ import (
 type URLType struct{ BaseType }
 
 func NewURLType() *URLType {
-	return &URLType{BaseType{name: "url", label: "URL", matchable: true, maxLength: 4096}}
+	return &URLType{BaseType{name: "url", label: "URL", plural: "URLs", matchable: true, maxLength: 4096}}
 }
 func (t *URLType) Validate(value string) bool {
 	u, err := url.Parse(value)
@@ -0,0 +1,128 @@
+package ftm
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// WriteTypeScript emits TypeScript definitions for the model, so frontends can be
+// typed against the same schemata at build time:
+//   - enums SchemaName, PropertyType and PropertyGroup;
+//   - a <Schema>Properties interface per schema, extending those of its parents,
+//     with every property as an optional string array;
+//   - a <Schema> entity interface per concrete schema, and an Entity union.
+//
+// The output is sorted and therefore stable across runs.
+func WriteTypeScript(w io.Writer, m *Model) error {
+	bw := bufio.NewWriter(w)
+	p := func(format string, args ...any) { fmt.Fprintf(bw, format, args...) }
+
+	names := sortedKeys(m.Schemata)
+
+	p("// Code generated by ftm typescript. DO NOT EDIT.\n\n")
+	p("export enum SchemaName {\n")
+	for _, name := range names {
+		p("  %s = %s,\n", name, strconv.Quote(name))
+	}
+	p("}\n\n")
+	writeTSEnum(p, "PropertyType", sortedKeys(m.registry.types))
+	writeTSEnum(p, "PropertyGroup", sortedKeys(m.registry.groups))
+
+	p("export interface EntityBase {\n")
+	p("  id: string;\n")
+	p("  schema: SchemaName;\n")
+	p("  properties: { [property: string]: string[] };\n")
+	p("}\n")
+
+	var concrete []string
+	for _, name := range names {
+		s := m.Schemata[name]
+		parents := make([]string, 0, len(s.Extends))
+		for _, parent := range s.Extends {
+			parents = append(parents, parent.Name+"Properties")
+		}
+		sort.Strings(parents)
+		p("\n")
+		writeTSDoc(p, "", s.Label, s.Description, s.Deprecated)
+		if len(parents) > 0 {
+			p("export interface %sProperties extends %s {\n", name, strings.Join(parents, ", "))
+		} else {
+			p("export interface %sProperties {\n", name)
+		}
+		var props []*Property
+		for _, prop := range s.Properties {
+			if prop.Schema == s {
+				props = append(props, prop)
+			}
+		}
+		sort.Slice(props, func(i, j int) bool { return props[i].Name < props[j].Name })
+		for _, prop := range props {
+			writeTSDoc(p, "  ", prop.Label, prop.Description, prop.Deprecated)
+			key := prop.Name
+			if !tsIdentifier.MatchString(key) {
+				key = strconv.Quote(key)
+			}
+			p("  %s?: string[];\n", key)
+		}
+		p("}\n")
+		if !s.Abstract {
+			concrete = append(concrete, name)
+			p("\nexport interface %s extends EntityBase {\n", name)
+			p("  schema: SchemaName.%s;\n", name)
+			p("  properties: %sProperties;\n", name)
+			p("}\n")
+		}
+	}
+
+	p("\nexport type Entity =\n")
+	for i, name := range concrete {
+		sep := ""
+		if i == len(concrete)-1 {
+			sep = ";"
+		}
+		p("  | %s%s\n", name, sep)
+	}
+	return bw.Flush()
+}
+
+func writeTSEnum(p func(string, ...any), name string, values []string) {
+	p("export enum %s {\n", name)
+	for _, v := range values {
+		key := v
+		if !tsIdentifier.MatchString(key) {
+			key = strconv.Quote(key)
+		}
+		p("  %s = %s,\n", key, strconv.Quote(v))
+	}
+	p("}\n\n")
+}
+
+func writeTSDoc(p func(string, ...any), indent, label, description string, deprecated bool) {
+	lines := []string{label}
+	if d := strings.Join(strings.Fields(description), " "); d != "" {
+		lines = append(lines, "", d)
+	}
+	if deprecated {
+		lines = append(lines, "@deprecated")
+	}
+	if len(lines) == 1 {
+		p("%s/** %s */\n", indent, strings.ReplaceAll(label, "*/", "* /"))
+		return
+	}
+	p("%s/**\n", indent)
+	for _, line := range lines {
+		if line == "" {
+			p("%s *\n", indent)
+			continue
+		}
+		p("%s * %s\n", indent, strings.ReplaceAll(line, "*/", "* /"))
+	}
+	p("%s */\n", indent)
+}
@@ -90,6 +90,38 @@ func makeEntityID(keyPrefix string, parts ...string) (string, bool) {
 	return hex.EncodeToString(out), true
 }
 
+// IDRecipe configures how entity IDs are derived from natural key parts.
+type IDRecipe struct {
+	KeyPrefix     string // secret/dataset key mixed into the hash
+	IncludeSchema bool   // mix the schema name into the hash
+	Prefix        string // readable prefix, emitted as "<prefix>-<hash>" (crawler convention)
+}
+
+// Make hashes the key parts according to the recipe. It returns false if all parts are empty.
+func (r IDRecipe) Make(schema *Schema, parts ...string) (string, bool) {
+	if r.IncludeSchema && schema != nil {
+		hasPart := false
+		for _, p := range parts {
+			if p != "" {
+				hasPart = true
+				break
+			}
+		}
+		if !hasPart {
+			return "", false
+		}
+		parts = append([]string{schema.Name}, parts...)
+	}
+	id, ok := makeEntityID(r.KeyPrefix, parts...)
+	if !ok {
+		return "", false
+	}
+	if r.Prefix != "" {
+		id = r.Prefix + "-" + id
+	}
+	return id, true
+}
+
 // shortest returns the shortest non-empty string.
 func shortest(values ...string) string {
 	nonEmpty := make([]string, 0, len(values))
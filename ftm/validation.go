@@ -0,0 +1,168 @@
+package ftm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// PropertyError describes a single validation problem with a property value.
+// Value is empty for problems concerning the property as a whole (e.g. a missing required property).
+type PropertyError struct {
+	Property string `json:"property"`
+	Value    string `json:"value,omitempty"`
+	Reason   string `json:"reason"`
+}
+
+func (pe PropertyError) Error() string {
+	if pe.Value == "" {
+		return fmt.Sprintf("%s: %s", pe.Property, pe.Reason)
+	}
+	return fmt.Sprintf("%s: %s (%q)", pe.Property, pe.Reason, pe.Value)
+}
+
+// maxLength returns the effective maximum value length of a property (0 = unlimited).
+func (p *Property) maxLength() int {
+	if p.MaxLength > 0 {
+		return p.MaxLength
+	}
+	return p.Type.MaxLength()
+}
+
+// ValidationError aggregates all property errors found while validating an entity.
+type ValidationError struct {
+	Schema string          `json:"schema"`
+	Errors []PropertyError `json:"errors"`
+}
+
+func (ve *ValidationError) Error() string {
+	msgs := make([]string, len(ve.Errors))
+	for i, pe := range ve.Errors {
+		msgs[i] = pe.Error()
+	}
+	return fmt.Sprintf("invalid %s: %s", ve.Schema, strings.Join(msgs, "; "))
+}
+
+// Validate checks required properties, type validity, allowed values and maximum value lengths,
+// returning every problem found. An empty result means the entity is valid.
+func (e *EntityProxy) Validate() []PropertyError {
+	return e.Schema.validateValues(e.props)
+}
+
+// EntityResolver looks up the schema of the entity with the given ID. It
+// reports false if no such entity exists.
+type EntityResolver func(id string) (*Schema, bool)
+
+// ValidateOptions configures ValidateWith.
+type ValidateOptions struct {
+	// Resolve, if set, is used to check that entity references point to
+	// existing entities whose schema matches the range of the property.
+	Resolve EntityResolver
+}
+
+// ValidateWith is Schema.Validate with options.
+func (s *Schema) ValidateWith(data map[string][]string, opts ValidateOptions) error {
+	errs := s.validateValues(data)
+	errs = append(errs, s.validateReferences(data, opts.Resolve)...)
+	if len(errs) > 0 {
+		return &ValidationError{Schema: s.Name, Errors: errs}
+	}
+	return nil
+}
+
+// ValidateWith is EntityProxy.Validate with options, e.g. to catch broken
+// edges before export.
+func (e *EntityProxy) ValidateWith(opts ValidateOptions) []PropertyError {
+	return append(e.Schema.validateValues(e.props), e.Schema.validateReferences(e.props, opts.Resolve)...)
+}
+
+// validateReferences checks entity values of data against resolve, in
+// property name order.
+func (s *Schema) validateReferences(data map[string][]string, resolve EntityResolver) []PropertyError {
+	if resolve == nil {
+		return nil
+	}
+	var errs []PropertyError
+	for _, name := range sortedKeys(data) {
+		p := s.Get(name)
+		if p == nil || p.Type.Name() != s.Model.registry.Entity.Name() {
+			continue
+		}
+		for _, v := range data[name] {
+			target, ok := resolve(v)
+			switch {
+			case !ok:
+				errs = append(errs, PropertyError{Property: p.Name, Value: v, Reason: "unresolved entity reference"})
+			case p.Range != nil && target != nil && !target.IsA(p.Range.Name):
+				errs = append(errs, PropertyError{Property: p.Name, Value: v, Reason: fmt.Sprintf("referenced %s does not match range %s", target.Name, p.Range.Name)})
+			}
+		}
+	}
+	return errs
+}
+
+// validateValues checks data against the schema, in property name order.
+// Values of properties unknown to the schema are ignored.
+func (s *Schema) validateValues(data map[string][]string) []PropertyError {
+	var errs []PropertyError
+	for _, req := range s.Required {
+		if len(data[req]) == 0 {
+			errs = append(errs, PropertyError{Property: req, Reason: "required property missing"})
+		}
+	}
+	for _, group := range s.requiredGroups() {
+		if !hasAnyValue(data, group) {
+			errs = append(errs, PropertyError{Property: strings.Join(group, "|"), Reason: "at least one of these properties is required"})
+		}
+	}
+	names := make([]string, 0, len(data))
+	for name := range data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		p := s.Get(name)
+		if p == nil {
+			continue
+		}
+		for _, v := range data[name] {
+			if !p.Type.Validate(v) {
+				errs = append(errs, PropertyError{Property: p.Name, Value: v, Reason: "invalid " + p.Type.Name()})
+				continue
+			}
+			if _, ok := p.allowedValue(v); !ok {
+				errs = append(errs, PropertyError{Property: p.Name, Value: v, Reason: "not an allowed value"})
+				continue
+			}
+			if limit := p.maxLength(); limit > 0 && utf8.RuneCountInString(v) > limit {
+				errs = append(errs, PropertyError{Property: p.Name, Value: v, Reason: fmt.Sprintf("exceeds max length %d", limit)})
+			}
+		}
+	}
+	return errs
+}
+
+// requiredGroups collects RequiredOneOf groups of the schema and its ancestors.
+func (s *Schema) requiredGroups() [][]string {
+	names := make([]string, 0, len(s.Schemata))
+	for name := range s.Schemata {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var out [][]string
+	for _, name := range names {
+		out = append(out, s.Schemata[name].RequiredOneOf...)
+	}
+	return out
+}
+
+// hasAnyValue reports whether any of the named properties has a value in data.
+func hasAnyValue(data map[string][]string, names []string) bool {
+	for _, name := range names {
+		if len(data[name]) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package ftm
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// ModelListener is notified after each reload attempt. On failure m is nil and the
+// previous model stays in place.
+type ModelListener func(m *Model, err error)
+
+// ModelWatcher reloads a YAML model directory when its files change.
+type ModelWatcher struct {
+	path      string
+	watcher   *fsnotify.Watcher
+	debounce  time.Duration
+	setGlobal bool
+
+	mu        sync.Mutex
+	current   *Model
+	listeners []ModelListener
+	done      chan struct{}
+}
+
+// WatchModel loads the model at path and reloads it whenever a YAML file below path
+// changes. Bursts of changes are coalesced. If setDefault is true, each reloaded
+// model also replaces the instance returned by Default.
+func WatchModel(path string, setDefault bool) (*ModelWatcher, error) {
+	m, err := NewModel(path)
+	if err != nil {
+		return nil, err
+	}
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return err
+		}
+		return w.Add(p)
+	})
+	if err != nil {
+		w.Close()
+		return nil, err
+	}
+	mw := &ModelWatcher{
+		path:      path,
+		watcher:   w,
+		debounce:  200 * time.Millisecond,
+		setGlobal: setDefault,
+		current:   m,
+		done:      make(chan struct{}),
+	}
+	if setDefault {
+		SetDefault(m)
+	}
+	go mw.run()
+	return mw, nil
+}
+
+// Model returns the most recently loaded model.
+func (mw *ModelWatcher) Model() *Model {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+	return mw.current
+}
+
+// OnReload registers a listener for reload attempts.
+func (mw *ModelWatcher) OnReload(fn ModelListener) {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+	mw.listeners = append(mw.listeners, fn)
+}
+
+// Close stops watching.
+func (mw *ModelWatcher) Close() error {
+	err := mw.watcher.Close()
+	<-mw.done
+	return err
+}
+
+func (mw *ModelWatcher) run() {
+	defer close(mw.done)
+	var timer *time.Timer
+	var fire <-chan time.Time
+	for {
+		select {
+		case ev, ok := <-mw.watcher.Events:
+			if !ok {
+				return
+			}
+			if ev.Has(fsnotify.Create) {
+				// watch new subdirectories as well
+				_ = mw.watcher.Add(ev.Name)
+			}
+			if !strings.HasSuffix(ev.Name, ".yml") && !strings.HasSuffix(ev.Name, ".yaml") {
+				continue
+			}
+			if timer == nil {
+				timer = time.NewTimer(mw.debounce)
+			} else {
+				timer.Reset(mw.debounce)
+			}
+			fire = timer.C
+		case <-fire:
+			fire = nil
+			mw.reload()
+		case err, ok := <-mw.watcher.Errors:
+			if !ok {
+				return
+			}
+			mw.notify(nil, err)
+		}
+	}
+}
+
+func (mw *ModelWatcher) reload() {
+	m, err := NewModel(mw.path)
+	if err != nil {
+		mw.notify(nil, err)
+		return
+	}
+	mw.mu.Lock()
+	mw.current = m
+	mw.mu.Unlock()
+	if mw.setGlobal {
+		SetDefault(m)
+	}
+	mw.notify(m, nil)
+}
+
+func (mw *ModelWatcher) notify(m *Model, err error) {
+	mw.mu.Lock()
+	listeners := append([]ModelListener{}, mw.listeners...)
+	mw.mu.Unlock()
+	for _, fn := range listeners {
+		fn(m, err)
+	}
+}
@@ -2,7 +2,7 @@ package ftmschema
 
 import "embed"
 
-// Files embeds the YAML schema definitions shipped with the library.
-//go:embed *.yaml *.yml
+// Files embeds the YAML schema definitions shipped with the library, and the
+// VERSION file naming the upstream followthemoney release they were synced from.
+//go:embed *.yaml *.yml VERSION
 var Files embed.FS
-